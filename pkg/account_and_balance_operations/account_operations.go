@@ -414,6 +414,9 @@ func AccountGetBalance(l *ledger.Ledger, accountID string) (float64, error) {
 		return 0, fmt.Errorf("invalid account ID: %w", err)
 	}
 
+	accountMutex.Lock()
+	defer accountMutex.Unlock()
+
 	account, err := l.AccountsWalletLedger.GetAccount(accountID)
 	if err != nil {
 		return 0, fmt.Errorf("account not found: %w", err)
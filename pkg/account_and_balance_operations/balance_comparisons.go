@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"synnergy_network/pkg/ledger"
 	"time"
 )
@@ -192,18 +193,17 @@ func BalanceTransferWithLock(l *ledger.Ledger, fromID, toID string, amount float
 	if err != nil {
 		return fmt.Errorf("source account not found: %w", err)
 	}
-	if fromAccount.Balance < amount {
-		return errors.New("insufficient balance")
-	}
 
 	toAccount, err := l.AccountsWalletLedger.GetAccount(toID)
 	if err != nil {
 		return fmt.Errorf("destination account not found: %w", err)
 	}
 
-	// Perform transfer
-	fromAccount.Balance -= amount
-	toAccount.Balance += amount
+	// Perform transfer; the amount stays locked until the lock expires
+	if err := fromAccount.Debit(big.NewFloat(amount)); err != nil {
+		return fmt.Errorf("insufficient balance: %w", err)
+	}
+	toAccount.LockedBalance += amount
 
 	// Create and apply lock
 	lock := ledger.BalanceLock{
@@ -211,6 +211,7 @@ func BalanceTransferWithLock(l *ledger.Ledger, fromID, toID string, amount float
 		AccountID: toID,
 		Amount:    amount,
 		UnlockAt:  lockUntil,
+		Escrowed:  true,
 	}
 	toAccount.LockedBalances = append(toAccount.LockedBalances, lock)
 
@@ -250,39 +251,24 @@ func BalanceTransferWithUnlock(l *ledger.Ledger, accountID, toID, lockID string)
 	accountMutex.Lock()
 	defer accountMutex.Unlock()
 
-	// Retrieve source account
-	account, err := l.AccountsWalletLedger.GetAccount(accountID)
-	if err != nil {
-		return fmt.Errorf("source account not found: %w", err)
+	// Check the destination exists before taking the lock
+	if _, err := l.AccountsWalletLedger.GetAccount(toID); err != nil {
+		return fmt.Errorf("destination account not found: %w", err)
 	}
 
-	// Locate and validate lock
-	var lock ledger.BalanceLock
-	lockIndex := -1
-	for i, lockedBalance := range account.LockedBalances {
-		if lockedBalance.ID == lockID && lockedBalance.UnlockAt.Before(time.Now()) {
-			lock = lockedBalance
-			lockIndex = i
-			break
-		}
-	}
-	if lockIndex == -1 {
-		return errors.New("no valid lock found for transfer")
+	// Take the expired lock out of the source account
+	lock, err := l.AccountsWalletLedger.TakeExpiredLock(accountID, lockID, time.Now())
+	if err != nil {
+		return fmt.Errorf("no valid lock found for transfer: %w", err)
 	}
 
-	// Retrieve destination account
+	// Pay the lock to the destination account, re-read in case it is the source
 	toAccount, err := l.AccountsWalletLedger.GetAccount(toID)
 	if err != nil {
 		return fmt.Errorf("destination account not found: %w", err)
 	}
-
-	// Perform transfer and update balances
-	toAccount.Balance += lock.Amount
-	account.LockedBalances = append(account.LockedBalances[:lockIndex], account.LockedBalances[lockIndex+1:]...)
-
-	// Update accounts in ledger
-	if err := l.AccountsWalletLedger.UpdateAccount(accountID, *account); err != nil {
-		return fmt.Errorf("failed to update source account: %w", err)
+	if err := toAccount.Credit(big.NewFloat(lock.Amount)); err != nil {
+		return fmt.Errorf("failed to credit destination account: %w", err)
 	}
 	if err := l.AccountsWalletLedger.UpdateAccount(toID, *toAccount); err != nil {
 		return fmt.Errorf("failed to update destination account: %w", err)
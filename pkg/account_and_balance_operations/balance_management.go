@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/big"
 	"synnergy_network/pkg/ledger"
 	"time"
 )
@@ -554,3 +555,95 @@ func BalanceTransferInBatch(l *ledger.Ledger, transfers []ledger.BalanceTransfer
 	log.Printf("Batch transfer completed successfully with %d transfers", len(transfers))
 	return nil
 }
+
+// availableBalance returns the funds an account can spend. Holds and reservations are moved
+// out of Balance when placed, so HeldBalance and ReservedBalance are never spendable.
+func availableBalance(account *ledger.Account, now time.Time) (float64, error) {
+	if account.IsFrozen || now.Before(account.FreezeUntil) {
+		return 0, errors.New("account is frozen")
+	}
+	return account.Balance, nil
+}
+
+// ExecuteTransfers applies a batch of transfers atomically. Every source is checked for
+// sufficient available balance before anything is applied, and if any update fails all
+// accounts already written are restored.
+func ExecuteTransfers(l *ledger.Ledger, transfers []ledger.BalanceTransfer) error {
+	logAction("ExecuteTransfers", "BatchProcessing")
+
+	if len(transfers) == 0 {
+		return errors.New("no transfers to execute")
+	}
+
+	accountMutex.Lock()
+	defer accountMutex.Unlock()
+
+	// Load every account touched by the batch, keeping the originals for rollback
+	originals := make(map[string]ledger.Account)
+	working := make(map[string]*ledger.Account)
+	var order []string
+	for _, transfer := range transfers {
+		if err := validateAccountID(transfer.FromID); err != nil {
+			return fmt.Errorf("invalid source account ID: %w", err)
+		}
+		if err := validateAccountID(transfer.ToID); err != nil {
+			return fmt.Errorf("invalid destination account ID: %w", err)
+		}
+		if transfer.FromID == transfer.ToID {
+			return fmt.Errorf("transfer source and destination are both %s", transfer.FromID)
+		}
+		if transfer.Amount <= 0 {
+			return fmt.Errorf("transfer amount must be positive for transfer from %s to %s", transfer.FromID, transfer.ToID)
+		}
+
+		for _, accountID := range []string{transfer.FromID, transfer.ToID} {
+			if _, loaded := working[accountID]; loaded {
+				continue
+			}
+			account, err := l.AccountsWalletLedger.GetAccount(accountID)
+			if err != nil {
+				return fmt.Errorf("account not found: %w", err)
+			}
+			originals[accountID] = *account
+			working[accountID] = account
+			order = append(order, accountID)
+		}
+	}
+
+	// Validate the whole batch in order before applying anything
+	now := time.Now()
+	for _, transfer := range transfers {
+		from, to := working[transfer.FromID], working[transfer.ToID]
+
+		if _, err := availableBalance(from, now); err != nil {
+			return fmt.Errorf("source account %s: %w", transfer.FromID, err)
+		}
+
+		amount := big.NewFloat(transfer.Amount)
+		if err := from.Debit(amount); err != nil {
+			return fmt.Errorf("insufficient available funds: %w", err)
+		}
+		if err := to.Credit(amount); err != nil {
+			return fmt.Errorf("failed to credit account %s: %w", transfer.ToID, err)
+		}
+	}
+
+	// Apply every account, restoring those already written if any update fails
+	var applied []string
+	for _, accountID := range order {
+		account := working[accountID]
+		account.LastUpdated = now
+		if err := l.AccountsWalletLedger.UpdateAccount(accountID, *account); err != nil {
+			for _, appliedID := range applied {
+				if rollbackErr := l.AccountsWalletLedger.UpdateAccount(appliedID, originals[appliedID]); rollbackErr != nil {
+					log.Printf("Failed to roll back account %s: %v", appliedID, rollbackErr)
+				}
+			}
+			return fmt.Errorf("failed to update account %s, batch rolled back: %w", accountID, err)
+		}
+		applied = append(applied, accountID)
+	}
+
+	log.Printf("Executed %d transfers atomically across %d accounts", len(transfers), len(order))
+	return nil
+}
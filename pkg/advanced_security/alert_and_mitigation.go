@@ -333,7 +333,9 @@ func LogThreatEvent(event string) error {
     ledgerInstance := &ledger.Ledger{}
 
     // Record the threat event in the ledger
-    ledgerInstance.AdvancedSecurityLedger.RecordThreatEvent(event, time.Now())
+    if _, err := ledgerInstance.AdvancedSecurityLedger.RecordThreatEvent("General", event, nil, time.Now()); err != nil {
+        return fmt.Errorf("failed to record threat event: %v", err)
+    }
 
     log.Printf("Threat event logged: %s\n", event)
     return nil
@@ -467,7 +469,7 @@ func MonitorAPIUsage(api string, responseTime float64) error {
     ledgerInstance := &ledger.Ledger{}
 
     // Record only API name and total requests in the ledger
-    ledgerInstance.AdvancedSecurityLedger.RecordAPIUsage(api, usageStats.TotalRequests)
+    ledgerInstance.AdvancedSecurityLedger.RecordAPIUsageMetrics(api, usageStats.TotalRequests)
 
     log.Printf("API usage for %s monitored successfully. Total Requests: %d", api, usageStats.TotalRequests)
     return nil
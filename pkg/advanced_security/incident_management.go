@@ -165,17 +165,18 @@ func DeactivateIncidentResponsePlan(incidentID string) error {
 }
 
 // LogIncidentResponseEvent logs events occurring during an incident response
-func LogIncidentResponseEvent(event, incidentID string) error {
+func LogIncidentResponseEvent(ledgerInstance *ledger.Ledger, event, incidentID string) error {
+	if ledgerInstance == nil {
+		return errors.New("ledger instance cannot be nil")
+	}
 	if incidentID == "" || event == "" {
 		return errors.New("incident ID and event cannot be empty")
 	}
 
 	// Record event in ledger
-	timestamp := time.Now().Format(time.RFC3339)
-	ledgerInstance := &ledger.Ledger{}
-
-	// Directly call RecordIncidentEvent without handling a return value
-	ledgerInstance.AdvancedSecurityLedger.RecordIncidentEvent(incidentID, event, timestamp)
+	if _, err := ledgerInstance.AdvancedSecurityLedger.RecordIncidentEvent(incidentID, event, time.Now()); err != nil {
+		return fmt.Errorf("failed to log event for incident %s: %w", incidentID, err)
+	}
 
 	log.Printf("Incident response event logged: Incident ID %s, Event: %s", incidentID, event)
 	return nil
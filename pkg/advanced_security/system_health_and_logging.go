@@ -203,10 +203,11 @@ func VerifySystemHealthStatus() (string, error) {
 
 
 // RecordSystemHealthEvent logs a significant health-related event within the system.
-func RecordSystemHealthEvent(event string) error {
+// severity is one of ledger.HealthSeverityHealthy, HealthSeverityDegraded or HealthSeverityCritical.
+func RecordSystemHealthEvent(event, severity string) error {
     // Record event in ledger
     ledgerInstance := &ledger.Ledger{}
-    err := ledgerInstance.AdvancedSecurityLedger.RecordHealthEvent(event, time.Now())
+    err := ledgerInstance.AdvancedSecurityLedger.RecordHealthEvent(event, severity, time.Now())
     if err != nil {
         return fmt.Errorf("failed to record system health event: %v", err)
     }
@@ -67,8 +67,8 @@ func (automation *ConsensusIntegrityMonitoringAutomation) validatePoH() bool {
 
 // validatePoS checks if PoS validators hold the required stake for validation
 func (automation *ConsensusIntegrityMonitoringAutomation) validatePoS() bool {
-    validator := automation.consensusEngine.PoS.SelectValidator()
-    if validator == nil {
+    validator, err := automation.consensusEngine.PoS.SelectValidator(automation.consensusEngine.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("PoS validator stake validation failed.")
         return false
     }
@@ -71,8 +71,8 @@ func (automation *ConsensusLatencyMonitoringAutomation) trackPoHLatency() time.D
 // trackPoSLatency measures the latency of the PoS stage
 func (automation *ConsensusLatencyMonitoringAutomation) trackPoSLatency() time.Duration {
     start := time.Now()
-    validator := automation.consensusEngine.PoS.SelectValidator()
-    if validator == nil {
+    validator, err := automation.consensusEngine.PoS.SelectValidator(automation.consensusEngine.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("Error tracking PoS latency.")
         return time.Duration(0)
     }
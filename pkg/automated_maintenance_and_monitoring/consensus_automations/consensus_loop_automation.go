@@ -59,8 +59,8 @@ func (automation *ConsensusMechanismExecutionAutomation) validateSubBlock() {
         }
     } else {
         // Step 2: Use PoS to validate the next sub-block
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting PoS validator.")
             return
         }
@@ -70,6 +70,9 @@ func (automation *ConsensusMechanismExecutionAutomation) validateSubBlock() {
         success := automation.consensusSystem.PoS.ValidateSubBlock(validator)
         if success {
             fmt.Println("Sub-block validated by PoS.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error validating sub-block via PoS.")
             return
@@ -60,8 +60,8 @@ func (automation *CrossConsensusSwitchAutomation) switchConsensusStages() {
         fmt.Println("PoH proof generated successfully for sub-block.")
     } else {
         // Step 2: Use PoS to validate the next sub-block
-        validatorSelected := automation.consensusEngine.PoS.SelectValidator()
-        if !validatorSelected {
+        validator, err := automation.consensusEngine.PoS.SelectValidator(automation.consensusEngine.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting PoS validator for sub-block.")
             return
         }
@@ -71,6 +71,9 @@ func (automation *CrossConsensusSwitchAutomation) switchConsensusStages() {
             return
         }
         fmt.Println("Sub-block validated successfully via PoS.")
+        if err := automation.consensusEngine.PoS.CommitSelection(validator); err != nil {
+            fmt.Printf("Error committing validator selection: %v\n", err)
+        }
     }
 
     // Increment the sub-block counter
@@ -83,8 +83,8 @@ func (automation *DynamicConsensusHoppingAutomation) generatePoHProof() {
 
 // validatePoSBlock handles PoS validation for sub-blocks
 func (automation *DynamicConsensusHoppingAutomation) validatePoSBlock() {
-    validatorSelected := automation.consensusEngine.PoS.SelectValidator()
-    if !validatorSelected {
+    validator, err := automation.consensusEngine.PoS.SelectValidator(automation.consensusEngine.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("Error selecting PoS validator.")
         return
     }
@@ -92,6 +92,9 @@ func (automation *DynamicConsensusHoppingAutomation) validatePoSBlock() {
     success := automation.consensusEngine.PoS.ValidateSubBlock()
     if success {
         fmt.Println("Sub-block validated successfully via PoS.")
+        if err := automation.consensusEngine.PoS.CommitSelection(validator); err != nil {
+            fmt.Printf("Error committing validator selection: %v\n", err)
+        }
     } else {
         fmt.Println("Error validating sub-block via PoS.")
     }
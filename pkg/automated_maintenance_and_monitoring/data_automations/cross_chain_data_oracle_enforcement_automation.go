@@ -66,8 +66,8 @@ func (automation *CrossChainDataOracleEnforcementAutomation) enforceDataConsiste
 
 // triggerEnforcementActions triggers actions to enforce data consistency across chains
 func (automation *CrossChainDataOracleEnforcementAutomation) triggerEnforcementActions() {
-    validator := automation.consensusSystem.PoS.SelectValidator()
-    if validator == nil {
+    validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("Error selecting validator for enforcement.")
         return
     }
@@ -77,6 +77,9 @@ func (automation *CrossChainDataOracleEnforcementAutomation) triggerEnforcementA
     enforcementSuccess := automation.consensusSystem.EnforceCrossChainConsistency(validator)
     if enforcementSuccess {
         fmt.Println("Cross-chain data consistency successfully enforced.")
+        if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+            fmt.Printf("Error committing validator selection: %v\n", err)
+        }
     } else {
         fmt.Println("Error enforcing cross-chain data consistency.")
     }
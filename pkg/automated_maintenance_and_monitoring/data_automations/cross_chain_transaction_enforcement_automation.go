@@ -72,8 +72,8 @@ func (automation *CrossChainTransactionEnforcementAutomation) enforceTransaction
 // triggerTransactionEnforcement enforces cross-chain transaction validation when pending transactions exceed the threshold
 func (automation *CrossChainTransactionEnforcementAutomation) triggerTransactionEnforcement(pendingTxs []common.Transaction) {
     for _, tx := range pendingTxs {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for transaction enforcement.")
             continue
         }
@@ -87,6 +87,9 @@ func (automation *CrossChainTransactionEnforcementAutomation) triggerTransaction
         validationSuccess := automation.consensusSystem.EnforceCrossChainTransaction(validator, encryptedTx)
         if validationSuccess {
             fmt.Println("Cross-chain transaction successfully enforced.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing cross-chain transaction.")
         }
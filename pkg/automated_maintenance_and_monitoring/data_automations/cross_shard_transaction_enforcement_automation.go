@@ -71,8 +71,8 @@ func (automation *CrossShardTransactionEnforcementAutomation) enforceShardTransa
 // triggerShardTransactionEnforcement enforces cross-shard transaction validation when pending transactions exceed the threshold
 func (automation *CrossShardTransactionEnforcementAutomation) triggerShardTransactionEnforcement(pendingShardTxs []common.Transaction) {
     for _, tx := range pendingShardTxs {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for cross-shard transaction enforcement.")
             continue
         }
@@ -86,6 +86,9 @@ func (automation *CrossShardTransactionEnforcementAutomation) triggerShardTransa
         validationSuccess := automation.consensusSystem.EnforceCrossShardTransaction(validator, encryptedTx)
         if validationSuccess {
             fmt.Println("Cross-shard transaction successfully enforced.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing cross-shard transaction.")
         }
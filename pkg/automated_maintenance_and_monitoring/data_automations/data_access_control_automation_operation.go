@@ -70,8 +70,8 @@ func (automation *DataAccessControlAutomation) monitorAndEnforceAccessControl()
 // triggerAccessControlEnforcement enforces access control rules based on detected violations
 func (automation *DataAccessControlAutomation) triggerAccessControlEnforcement(violations []common.AccessViolation) {
     for _, violation := range violations {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for access control enforcement.")
             continue
         }
@@ -85,6 +85,9 @@ func (automation *DataAccessControlAutomation) triggerAccessControlEnforcement(v
         enforcementSuccess := automation.consensusSystem.EnforceAccessControlPolicy(validator, encryptedViolation)
         if enforcementSuccess {
             fmt.Println("Access control policy successfully enforced.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing access control policy.")
         }
@@ -70,8 +70,8 @@ func (automation *DataCompressionEfficiencyMonitoringAutomation) checkCompressio
 
 // triggerCompressionEnforcement takes action to enforce better data compression efficiency when violations are detected
 func (automation *DataCompressionEfficiencyMonitoringAutomation) triggerCompressionEnforcement(compressionEfficiency int) {
-    validator := automation.consensusSystem.PoS.SelectValidator()
-    if validator == nil {
+    validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("Error selecting validator for compression enforcement.")
         return
     }
@@ -85,6 +85,9 @@ func (automation *DataCompressionEfficiencyMonitoringAutomation) triggerCompress
     enforcementSuccess := automation.consensusSystem.EnforceCompressionEfficiency(validator, encryptedEfficiency)
     if enforcementSuccess {
         fmt.Println("Compression efficiency successfully enforced.")
+        if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+            fmt.Printf("Error committing validator selection: %v\n", err)
+        }
     } else {
         fmt.Println("Error enforcing compression efficiency.")
     }
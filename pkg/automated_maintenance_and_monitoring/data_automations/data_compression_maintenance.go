@@ -70,8 +70,8 @@ func (automation *DataCompressionMaintenanceAutomation) runCompressionMaintenanc
 
 // triggerCompressionMaintenance performs the necessary actions to restore acceptable compression efficiency
 func (automation *DataCompressionMaintenanceAutomation) triggerCompressionMaintenance(compressionEfficiency int) {
-    validator := automation.consensusSystem.PoS.SelectValidator()
-    if validator == nil {
+    validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("Error selecting validator for compression maintenance.")
         return
     }
@@ -85,6 +85,9 @@ func (automation *DataCompressionMaintenanceAutomation) triggerCompressionMainte
     maintenanceSuccess := automation.consensusSystem.MaintainCompressionEfficiency(validator, encryptedEfficiency)
     if maintenanceSuccess {
         fmt.Println("Compression efficiency successfully maintained.")
+        if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+            fmt.Printf("Error committing validator selection: %v\n", err)
+        }
     } else {
         fmt.Println("Error maintaining compression efficiency.")
     }
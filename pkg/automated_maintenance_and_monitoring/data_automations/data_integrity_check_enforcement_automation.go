@@ -70,8 +70,8 @@ func (automation *DataIntegrityCheckEnforcementAutomation) checkDataIntegrity()
 
 // triggerIntegrityEnforcement takes action to resolve any detected integrity violations
 func (automation *DataIntegrityCheckEnforcementAutomation) triggerIntegrityEnforcement() {
-    validator := automation.consensusSystem.PoS.SelectValidator()
-    if validator == nil {
+    validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+    if err != nil {
         fmt.Println("Error selecting validator for data integrity enforcement.")
         return
     }
@@ -85,6 +85,9 @@ func (automation *DataIntegrityCheckEnforcementAutomation) triggerIntegrityEnfor
     enforcementSuccess := automation.consensusSystem.EnforceDataIntegrity(validator, encryptedData)
     if enforcementSuccess {
         fmt.Println("Data integrity successfully enforced.")
+        if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+            fmt.Printf("Error committing validator selection: %v\n", err)
+        }
     } else {
         fmt.Println("Error enforcing data integrity.")
     }
@@ -70,8 +70,8 @@ func (automation *DataPrivacyEnforcementAutomation) monitorAndEnforceDataPrivacy
 // triggerPrivacyEnforcement enforces data privacy actions based on detected violations
 func (automation *DataPrivacyEnforcementAutomation) triggerPrivacyEnforcement(violations []common.PrivacyViolation) {
     for _, violation := range violations {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for privacy enforcement.")
             continue
         }
@@ -85,6 +85,9 @@ func (automation *DataPrivacyEnforcementAutomation) triggerPrivacyEnforcement(vi
         enforcementSuccess := automation.consensusSystem.EnforcePrivacy(validator, encryptedViolation)
         if enforcementSuccess {
             fmt.Println("Privacy successfully enforced for violation.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing privacy for violation.")
         }
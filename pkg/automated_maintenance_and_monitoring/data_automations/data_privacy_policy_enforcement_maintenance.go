@@ -70,8 +70,8 @@ func (automation *DataPrivacyPolicyEnforcementMaintenance) monitorAndEnforcePriv
 // triggerPrivacyPolicyEnforcement enforces privacy policies based on detected violations
 func (automation *DataPrivacyPolicyEnforcementMaintenance) triggerPrivacyPolicyEnforcement(violations []common.PrivacyViolation) {
     for _, violation := range violations {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for privacy enforcement.")
             continue
         }
@@ -85,6 +85,9 @@ func (automation *DataPrivacyPolicyEnforcementMaintenance) triggerPrivacyPolicyE
         enforcementSuccess := automation.consensusSystem.EnforcePrivacyPolicy(validator, encryptedViolation)
         if enforcementSuccess {
             fmt.Println("Privacy policy successfully enforced.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing privacy policy.")
         }
@@ -70,8 +70,8 @@ func (automation *DataProtectionComplianceMonitoring) monitorAndEnforceComplianc
 // triggerComplianceEnforcement triggers enforcement of data protection policies based on violations
 func (automation *DataProtectionComplianceMonitoring) triggerComplianceEnforcement(violations []common.ComplianceViolation) {
     for _, violation := range violations {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for compliance enforcement.")
             continue
         }
@@ -85,6 +85,9 @@ func (automation *DataProtectionComplianceMonitoring) triggerComplianceEnforceme
         enforcementSuccess := automation.consensusSystem.EnforceCompliancePolicy(validator, encryptedViolation)
         if enforcementSuccess {
             fmt.Println("Compliance policy successfully enforced.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing compliance policy.")
         }
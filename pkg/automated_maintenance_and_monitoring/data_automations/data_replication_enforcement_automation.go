@@ -71,8 +71,8 @@ func (automation *DataReplicationEnforcementAutomation) monitorAndEnforceReplica
 // triggerReplicationEnforcement enforces data replication policies based on violations
 func (automation *DataReplicationEnforcementAutomation) triggerReplicationEnforcement(violations []common.ReplicationViolation) {
     for _, violation := range violations {
-        validator := automation.consensusSystem.PoS.SelectValidator()
-        if validator == nil {
+        validator, err := automation.consensusSystem.PoS.SelectValidator(automation.consensusSystem.PoS.SelectionSeed())
+        if err != nil {
             fmt.Println("Error selecting validator for replication enforcement.")
             continue
         }
@@ -86,6 +86,9 @@ func (automation *DataReplicationEnforcementAutomation) triggerReplicationEnforc
         enforcementSuccess := automation.consensusSystem.EnforceReplicationPolicy(validator, encryptedViolation)
         if enforcementSuccess {
             fmt.Println("Data replication policy successfully enforced.")
+            if err := automation.consensusSystem.PoS.CommitSelection(validator); err != nil {
+                fmt.Printf("Error committing validator selection: %v\n", err)
+            }
         } else {
             fmt.Println("Error enforcing data replication policy.")
         }
@@ -81,30 +81,49 @@ func (bc *Blockchain) mineBlock(block Block) string {
 
 
 
-// ValidateBlock verifies the block's hash and ensures it matches the difficulty
+// ValidateBlock verifies the block's hash and ensures it matches the difficulty.
+// Results are cached in the ledger by block ID and hash, so a block is only
+// checked once until its cached result is invalidated. Failures that depend on
+// chain state, such as a parent that has not arrived yet, are not cached.
 func (bc *Blockchain) ValidateBlock(block Block) bool {
+    if block.BlockID != "" {
+        if cached, found := bc.Ledger.BlockchainConsensusCoinLedger.GetCachedValidation(block.BlockID, block.Hash); found {
+            return cached.IsValid
+        }
+    }
+
+    valid, cacheable := bc.validateBlock(block)
+    if block.BlockID != "" && cacheable {
+        bc.Ledger.BlockchainConsensusCoinLedger.CacheValidationResult(block.BlockID, block.Hash, valid, time.Now())
+    }
+    return valid
+}
+
+// validateBlock runs the full validation checks for a block. cacheable is
+// false when the outcome depends on chain state and may change later.
+func (bc *Blockchain) validateBlock(block Block) (valid bool, cacheable bool) {
     expectedHash := calculateBlockHash(block)
     if block.Hash != expectedHash {
         fmt.Printf("Block %d validation failed: Invalid hash.\n", block.Index)
-        return false
+        return false, true
     }
 
     if !strings.HasPrefix(block.Hash, strings.Repeat("0", block.Difficulty)) {
         fmt.Printf("Block %d validation failed: Hash does not meet difficulty.\n", block.Index)
-        return false
+        return false, true
     }
 
     // Convert Blockchain.Block to ledger.Block before validation
     ledgerBlock := ConvertToLedgerBlock(block)
 
-    // Ensure the block exists in the ledger
+    // Ensure the block fits the ledger's chain
     if err := bc.Ledger.BlockchainConsensusCoinLedger.ValidateBlock(ledgerBlock); err != nil { 
         fmt.Printf("Block %d validation failed: Not present in the ledger (%v).\n", block.Index, err)
-        return false
+        return false, false
     }
 
     fmt.Printf("Block %d successfully validated.\n", block.Index)
-    return true
+    return true, true
 }
 
 
@@ -125,31 +144,28 @@ func (bc *Blockchain) AddSubBlocksToBlock(subBlocks []SubBlock, pow *PoW) {
 }
 
 
-// calculateBlockHash calculates the SHA-256 hash of the block's content.
+// calculateBlockHash returns the block's hash. It delegates to ComputeHash so that
+// mining, validation, PoW and fork resolution all share one hashing rule.
 func calculateBlockHash(block Block) string {
-	// Convert block data into a string to be hashed
-	blockData := fmt.Sprintf(
-		"%s%d%s%s%d%d%f%s", // Format: BlockID, Index, PrevHash, Timestamp, Nonce, Difficulty, MinerReward, Validators (joined)
-		block.BlockID,
-		block.Index,
-		block.PrevHash,
-		block.Timestamp.String(),   // Convert timestamp to string
-		block.Nonce,
-		block.Difficulty,
-		block.MinerReward,
-		joinValidators(block.Validators), // Convert validators list to a single string
-	)
-
-	// Create a SHA-256 hash of the block data
-	hash := sha256.New()
-	hash.Write([]byte(blockData))
-	hashed := hash.Sum(nil)
-
-	// Convert the hashed bytes to a hexadecimal string and return
-	return hex.EncodeToString(hashed)
+    return block.ComputeHash()
 }
 
-// Helper function to join validators into a single string
-func joinValidators(validators []string) string {
-	return fmt.Sprintf("%v", validators) // Join validators into a single string
-}
\ No newline at end of file
+// ComputeHash recomputes the block's PoW hash from its index, timestamp,
+// previous hash, nonce and the hashes of its sub-blocks.
+func (b *Block) ComputeHash() string {
+    var subBlockHashes []string
+    for _, subBlock := range b.SubBlocks {
+        subBlockHashes = append(subBlockHashes, subBlock.Hash)
+    }
+
+    hashInput := fmt.Sprintf("%d:%s:%s:%d:%s",
+        b.Index,
+        b.Timestamp.Format(time.RFC3339Nano),
+        b.PrevHash,
+        b.Nonce,
+        strings.Join(subBlockHashes, ""))
+
+    hash := sha256.New()
+    hash.Write([]byte(hashInput))
+    return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,169 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"synnergy_network/pkg/ledger"
+	"time"
+)
+
+const (
+	defaultListenerRetries     = 3                      // Retries per delivery after the first attempt
+	defaultListenerBackoff     = 500 * time.Millisecond // Initial backoff, doubled on each retry
+	defaultListenerMaxFailures = 5                      // Consecutive failed deliveries before a listener is deactivated
+	listenerRequestTimeout     = 10 * time.Second       // Timeout for a single callback request
+)
+
+// ListenerRegistry delivers block events to registered BlockListeners by
+// POSTing the event payload as JSON to each listener's CallbackURL.
+type ListenerRegistry struct {
+	Listeners   map[string]*ledger.BlockListener // Registered listeners by ID
+	Failures    map[string]int                   // Consecutive failed deliveries by listener ID
+	MaxRetries  int                              // Retries per delivery after the first attempt
+	BaseBackoff time.Duration                    // Initial retry backoff, doubled on each retry
+	MaxFailures int                              // Consecutive failures before a listener is deactivated
+	client      *http.Client                     // HTTP client used for callbacks
+	mu          sync.Mutex                       // Mutex for thread-safe access
+}
+
+// NewListenerRegistry creates a registry with the default retry policy.
+func NewListenerRegistry() *ListenerRegistry {
+	return &ListenerRegistry{
+		Listeners:   make(map[string]*ledger.BlockListener),
+		Failures:    make(map[string]int),
+		MaxRetries:  defaultListenerRetries,
+		BaseBackoff: defaultListenerBackoff,
+		MaxFailures: defaultListenerMaxFailures,
+		client:      &http.Client{Timeout: listenerRequestTimeout},
+	}
+}
+
+// Register adds or replaces a listener, resetting its failure count.
+func (r *ListenerRegistry) Register(l *ledger.BlockListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Listeners[l.ID] = l
+	r.Failures[l.ID] = 0
+	l.LastUpdated = time.Now()
+	log.Printf("[INFO] Block listener %s registered for events %v", l.ID, l.Events)
+}
+
+// Unregister removes a listener from the registry.
+func (r *ListenerRegistry) Unregister(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.Listeners, id)
+	delete(r.Failures, id)
+	log.Printf("[INFO] Block listener %s unregistered", id)
+}
+
+// Dispatch POSTs the payload to every active listener subscribed to event.
+// Each delivery is retried with exponential backoff; a listener whose
+// deliveries fail MaxFailures times in a row is deactivated. Dispatch returns
+// once every delivery has succeeded or exhausted its retries.
+func (r *ListenerRegistry) Dispatch(event string, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[WARNING] Failed to marshal payload for event %s: %v", event, err)
+		return
+	}
+
+	r.mu.Lock()
+	var targets []*ledger.BlockListener
+	for _, l := range r.Listeners {
+		if l.Active && subscribedTo(l, event) {
+			targets = append(targets, l)
+		}
+	}
+	r.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, l := range targets {
+		wg.Add(1)
+		go func(l *ledger.BlockListener, url string) {
+			defer wg.Done()
+			err := r.deliver(url, event, body)
+			r.recordDelivery(l, event, err)
+		}(l, l.CallbackURL)
+	}
+	wg.Wait()
+}
+
+// AttachToLedger dispatches a "BlockFinalized" event for every block the
+// ledger finalizes. Delivery runs in the background so the ledger is never
+// held up by slow listeners.
+func (r *ListenerRegistry) AttachToLedger(l *ledger.Ledger) {
+	l.BlockchainConsensusCoinLedger.RegisterBlockListener(func(block ledger.Block) {
+		go r.Dispatch("BlockFinalized", block)
+	})
+}
+
+// deliver POSTs body to url, retrying with exponential backoff.
+func (r *ListenerRegistry) deliver(url, event string, body []byte) error {
+	backoff := r.BaseBackoff
+	var lastErr error
+	for attempt := 0; attempt <= r.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to build request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Block-Event", event)
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("listener returned status: %s", resp.Status)
+	}
+	return lastErr
+}
+
+// recordDelivery updates the listener's failure count after a delivery and
+// deactivates it once MaxFailures consecutive deliveries have failed.
+func (r *ListenerRegistry) recordDelivery(l *ledger.BlockListener, event string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, registered := r.Listeners[l.ID]; !registered {
+		return
+	}
+	if err == nil {
+		r.Failures[l.ID] = 0
+		return
+	}
+
+	r.Failures[l.ID]++
+	log.Printf("[WARNING] Delivery of %s to listener %s failed (%d consecutive): %v", event, l.ID, r.Failures[l.ID], err)
+	if r.Failures[l.ID] >= r.MaxFailures {
+		l.Active = false
+		l.LastUpdated = time.Now()
+		log.Printf("[WARNING] Block listener %s deactivated after %d consecutive failures", l.ID, r.Failures[l.ID])
+	}
+}
+
+// subscribedTo reports whether the listener subscribes to event.
+func subscribedTo(l *ledger.BlockListener, event string) bool {
+	for _, e := range l.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
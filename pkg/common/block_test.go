@@ -0,0 +1,86 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"synnergy_network/pkg/ledger"
+)
+
+func newValidationTestChain() *Blockchain {
+	return &Blockchain{Ledger: &ledger.Ledger{}}
+}
+
+func TestValidateBlockServesCachedResult(t *testing.T) {
+	bc := newValidationTestChain()
+	block := Block{BlockID: "block-1", Hash: "not-the-real-hash"}
+	bc.Ledger.BlockchainConsensusCoinLedger.CacheValidationResult(block.BlockID, block.Hash, true, time.Now())
+
+	if !bc.ValidateBlock(block) {
+		t.Fatal("expected the cached valid result to be returned without re-validating")
+	}
+}
+
+func TestValidateBlockCacheIsKeyedByHash(t *testing.T) {
+	bc := newValidationTestChain()
+	bc.Ledger.BlockchainConsensusCoinLedger.CacheValidationResult("block-1", "hash-a", true, time.Now())
+
+	tampered := Block{BlockID: "block-1", Hash: "hash-b"}
+	if bc.ValidateBlock(tampered) {
+		t.Fatal("a block with the same ID but a different hash must not reuse the cached result")
+	}
+}
+
+func TestValidateBlockCachesIntrinsicFailure(t *testing.T) {
+	bc := newValidationTestChain()
+	block := Block{BlockID: "block-1", Hash: "bad-hash"}
+
+	if bc.ValidateBlock(block) {
+		t.Fatal("expected a block with an invalid hash to fail validation")
+	}
+	cached, found := bc.Ledger.BlockchainConsensusCoinLedger.GetCachedValidation(block.BlockID, block.Hash)
+	if !found || cached.IsValid {
+		t.Fatalf("cached result = %+v, found = %v; want a cached failure", cached, found)
+	}
+}
+
+func TestValidateBlockDoesNotCacheChainStateFailure(t *testing.T) {
+	bc := newValidationTestChain()
+	// Index 5 cannot follow an empty ledger, as if its parent had not arrived yet.
+	block := Block{BlockID: "block-5", Index: 5, Timestamp: time.Now()}
+	block.Hash = calculateBlockHash(block)
+
+	if bc.ValidateBlock(block) {
+		t.Fatal("expected a block without its parent to fail validation")
+	}
+	if _, found := bc.Ledger.BlockchainConsensusCoinLedger.GetCachedValidation(block.BlockID, block.Hash); found {
+		t.Fatal("a failure that depends on chain state must not be cached")
+	}
+}
+
+func TestInvalidateValidationForcesRecheck(t *testing.T) {
+	bc := newValidationTestChain()
+	block := Block{BlockID: "block-1", Hash: "not-the-real-hash"}
+	bc.Ledger.BlockchainConsensusCoinLedger.CacheValidationResult(block.BlockID, block.Hash, true, time.Now())
+
+	bc.Ledger.BlockchainConsensusCoinLedger.InvalidateValidation(block.BlockID)
+
+	if bc.ValidateBlock(block) {
+		t.Fatal("expected the block to be re-validated, and fail, once its cached result was invalidated")
+	}
+}
+
+func TestBlockHashRulesAgree(t *testing.T) {
+	block := Block{
+		BlockID:   "block-1",
+		Index:     1,
+		Timestamp: time.Now(),
+		PrevHash:  "prev",
+		Nonce:     7,
+		SubBlocks: []SubBlock{{Hash: "sub-1"}, {Hash: "sub-2"}},
+	}
+
+	if got, want := calculateBlockHash(block), (&PoW{}).calculateBlockHash(&block); got != want {
+		t.Fatalf("calculateBlockHash = %s, PoW hash = %s; want one hashing rule", got, want)
+	}
+}
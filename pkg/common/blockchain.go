@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"synnergy_network/pkg/ledger"
 	"time"
@@ -16,6 +17,7 @@ type Blockchain struct {
 	OwnerWallet         string         // The owner's wallet address
 	mutex               sync.Mutex     // Mutex for thread-safe operations
 	Ledger              *ledger.Ledger // Ledger to store blocks and transactions
+	SenderNonces        map[string]uint64 // Next nonce expected from each sender once pending transactions confirm
 }
 
 // ConvertTransactions converts []Blockchain.Transaction to []Ledger.Transaction.
@@ -114,13 +116,148 @@ func (bc *Blockchain) AddBlock(newBlock Block) {
 	}
 }
 
-// AddTransaction adds a new transaction to the list of pending transactions.
-func (bc *Blockchain) AddTransaction(tx Transaction) {
+// AddTransaction assigns tx the sender's next nonce and adds it to the mempool
+// through the same checks as AddPendingTransaction.
+func (bc *Blockchain) AddTransaction(tx Transaction) error {
 	bc.mutex.Lock()
 	defer bc.mutex.Unlock()
 
-	// Add the transaction to the list of pending transactions
+	tx.Nonce = bc.nextNonceLocked(tx.FromAddress)
+	return bc.addPendingLocked(tx)
+}
+
+// NextNonce returns the nonce the sender's next transaction must carry to be
+// accepted into the mempool.
+func (bc *Blockchain) NextNonce(sender string) uint64 {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	return bc.nextNonceLocked(sender)
+}
+
+// nextNonceLocked returns the sender's next nonce after its last confirmed and
+// pending transactions. The caller must hold bc.mutex.
+func (bc *Blockchain) nextNonceLocked(sender string) uint64 {
+	expectedNonce := bc.SenderNonces[sender]
+	for _, pending := range bc.PendingTransactions {
+		if pending.FromAddress == sender && pending.Nonce >= expectedNonce {
+			expectedNonce = pending.Nonce + 1
+		}
+	}
+	return expectedNonce
+}
+
+// AddPendingTransaction adds a transaction to the mempool. It is rejected if a
+// transaction with the same ID is already pending or if its nonce does not
+// directly follow the sender's last confirmed or pending nonce.
+func (bc *Blockchain) AddPendingTransaction(tx Transaction) error {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	return bc.addPendingLocked(tx)
+}
+
+// addPendingLocked validates and appends tx to the mempool. The caller must
+// hold bc.mutex.
+func (bc *Blockchain) addPendingLocked(tx Transaction) error {
+	if tx.TransactionID == "" {
+		return fmt.Errorf("transaction ID cannot be empty")
+	}
+	if err := authorizeTransaction(bc.Ledger, tx); err != nil {
+		return err
+	}
+
+	for _, pending := range bc.PendingTransactions {
+		if pending.TransactionID == tx.TransactionID {
+			return fmt.Errorf("transaction %s is already pending", tx.TransactionID)
+		}
+	}
+	if expectedNonce := bc.nextNonceLocked(tx.FromAddress); tx.Nonce != expectedNonce {
+		return fmt.Errorf("transaction %s from %s has nonce %d, expected %d", tx.TransactionID, tx.FromAddress, tx.Nonce, expectedNonce)
+	}
+
 	bc.PendingTransactions = append(bc.PendingTransactions, tx)
+	return nil
+}
+
+// SelectForSubBlock returns up to max pending transactions, highest fee first,
+// without removing them from the mempool. A sender's transactions are always
+// returned in nonce order, and ties in fee are broken by timestamp and then
+// transaction ID so every validator selects the same set in the same order.
+func (bc *Blockchain) SelectForSubBlock(max int) []Transaction {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	// Queue each sender's transactions in nonce order
+	queues := make(map[string][]Transaction)
+	for _, tx := range bc.PendingTransactions {
+		queues[tx.FromAddress] = append(queues[tx.FromAddress], tx)
+	}
+	senders := make([]string, 0, len(queues))
+	for sender, queue := range queues {
+		sort.Slice(queue, func(i, j int) bool { return queue[i].Nonce < queue[j].Nonce })
+		senders = append(senders, sender)
+	}
+	sort.Strings(senders)
+
+	var selected []Transaction
+	for len(selected) < max {
+		best := ""
+		for _, sender := range senders {
+			queue := queues[sender]
+			if len(queue) == 0 {
+				continue
+			}
+			if best == "" || pendingPriorityBefore(queue[0], queues[best][0]) {
+				best = sender
+			}
+		}
+		if best == "" {
+			break
+		}
+		selected = append(selected, queues[best][0])
+		queues[best] = queues[best][1:]
+	}
+	return selected
+}
+
+// pendingPriorityBefore reports whether a should be selected ahead of b.
+func pendingPriorityBefore(a, b Transaction) bool {
+	if a.Fee != b.Fee {
+		return a.Fee > b.Fee
+	}
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	return a.TransactionID < b.TransactionID
+}
+
+// RemoveConfirmed drops confirmed transactions from the mempool and advances
+// each sender's expected nonce past them.
+func (bc *Blockchain) RemoveConfirmed(ids []string) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	confirmed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		confirmed[id] = true
+	}
+
+	if bc.SenderNonces == nil {
+		bc.SenderNonces = make(map[string]uint64)
+	}
+
+	remaining := make([]Transaction, 0, len(bc.PendingTransactions))
+	for _, tx := range bc.PendingTransactions {
+		if !confirmed[tx.TransactionID] {
+			remaining = append(remaining, tx)
+			continue
+		}
+		if tx.Nonce+1 > bc.SenderNonces[tx.FromAddress] {
+			bc.SenderNonces[tx.FromAddress] = tx.Nonce + 1
+		}
+	}
+	bc.PendingTransactions = remaining
 }
 
 // MineBlock mines a new block and includes the pending transactions.
@@ -141,7 +278,16 @@ func (bc *Blockchain) MineBlock(minerAddress string) Block {
 	// Add transactions from the pending transactions list to the new block
 	newBlock.SubBlocks = bc.SubBlockChain.MineSubBlocks(bc.PendingTransactions)
 
-	// Clear pending transactions after they've been included in the block
+	// Clear pending transactions after they've been included in the block,
+	// advancing each sender's nonce past them
+	if bc.SenderNonces == nil {
+		bc.SenderNonces = make(map[string]uint64)
+	}
+	for _, tx := range bc.PendingTransactions {
+		if tx.Nonce+1 > bc.SenderNonces[tx.FromAddress] {
+			bc.SenderNonces[tx.FromAddress] = tx.Nonce + 1
+		}
+	}
 	bc.PendingTransactions = []Transaction{}
 
 	// Add the new block to the blockchain
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"synnergy_network/pkg/ledger"
+)
+
+func TestAddTransactionAssignsSequentialNonces(t *testing.T) {
+	bc := &Blockchain{}
+	for _, id := range []string{"tx-1", "tx-2"} {
+		if err := bc.AddTransaction(Transaction{TransactionID: id, FromAddress: "alice"}); err != nil {
+			t.Fatalf("AddTransaction(%s): %v", id, err)
+		}
+	}
+
+	if got := bc.PendingTransactions[1].Nonce; got != 1 {
+		t.Fatalf("second nonce = %d, want 1", got)
+	}
+	if got := bc.NextNonce("alice"); got != 2 {
+		t.Fatalf("NextNonce = %d, want 2", got)
+	}
+}
+
+func TestAddTransactionRejectsDuplicateID(t *testing.T) {
+	bc := &Blockchain{}
+	if err := bc.AddTransaction(Transaction{TransactionID: "tx-1", FromAddress: "alice"}); err != nil {
+		t.Fatalf("AddTransaction: %v", err)
+	}
+	if err := bc.AddTransaction(Transaction{TransactionID: "tx-1", FromAddress: "alice"}); err == nil {
+		t.Fatal("expected a duplicate transaction ID to be rejected")
+	}
+}
+
+func TestAddPendingTransactionFollowsAddTransactionNonces(t *testing.T) {
+	bc := &Blockchain{}
+	if err := bc.AddTransaction(Transaction{TransactionID: "tx-1", FromAddress: "alice"}); err != nil {
+		t.Fatalf("AddTransaction: %v", err)
+	}
+
+	if err := bc.AddPendingTransaction(Transaction{TransactionID: "tx-2", FromAddress: "alice", Nonce: 0}); err == nil {
+		t.Fatal("expected a reused nonce to be rejected")
+	}
+	if err := bc.AddPendingTransaction(Transaction{TransactionID: "tx-2", FromAddress: "alice", Nonce: 1}); err != nil {
+		t.Fatalf("AddPendingTransaction: %v", err)
+	}
+}
+
+func TestAddPendingTransactionRejectsIsolatedSender(t *testing.T) {
+	bc := &Blockchain{Ledger: &ledger.Ledger{}}
+	if _, err := bc.Ledger.AdvancedSecurityLedger.IsolateEntity("mallory", "quarantined", time.Now()); err != nil {
+		t.Fatalf("IsolateEntity: %v", err)
+	}
+
+	if err := bc.AddPendingTransaction(Transaction{TransactionID: "tx-1", FromAddress: "mallory"}); err == nil {
+		t.Fatal("expected a transaction from an isolated sender to be rejected")
+	}
+
+	if err := bc.Ledger.AdvancedSecurityLedger.ReleaseIsolation("mallory"); err != nil {
+		t.Fatalf("ReleaseIsolation: %v", err)
+	}
+	if err := bc.AddPendingTransaction(Transaction{TransactionID: "tx-1", FromAddress: "mallory"}); err != nil {
+		t.Fatalf("AddPendingTransaction after release: %v", err)
+	}
+}
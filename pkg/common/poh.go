@@ -28,6 +28,7 @@ type PoHProof struct {
 	Sequence  int       // The sequence number for this proof
 	Timestamp time.Time // The timestamp for this PoH proof
 	Hash      string    // Hash generated by PoH for this entry
+	PrevHash  string    // Hash of the PoH entry this proof follows
 }
 
 // NewPoH initializes a new PoH system and integrates it with the ledger and reward manager
@@ -82,6 +83,7 @@ func (p *PoH) GeneratePoHProof(transactions []Transaction) (PoHProof, error) {
         Sequence:  p.State.Sequence + 1,
         Timestamp: currentTime,
         Hash:      newHash,
+        PrevHash:  p.State.LastHash,
     }
 
     // Step 4: Store the proof in the ledger with concurrency control
@@ -111,6 +113,27 @@ func (p *PoH) GeneratePoHProof(transactions []Transaction) (PoHProof, error) {
 }
 
 
+// VerifyPoHChain checks that a run of PoH proofs is continuous: each proof's
+// Sequence must be one more than the previous proof's, and its PrevHash must be
+// the previous proof's Hash. On a break it returns the index of the first
+// proof that does not follow its predecessor.
+func VerifyPoHChain(proofs []PoHProof) (valid bool, gapAt int, err error) {
+    if len(proofs) == 0 {
+        return false, -1, fmt.Errorf("no PoH proofs provided")
+    }
+
+    for i := 1; i < len(proofs); i++ {
+        prev, proof := proofs[i-1], proofs[i]
+        if proof.Sequence != prev.Sequence+1 {
+            return false, i, fmt.Errorf("PoH sequence gap at index %d: expected %d, got %d", i, prev.Sequence+1, proof.Sequence)
+        }
+        if proof.PrevHash != prev.Hash {
+            return false, i, fmt.Errorf("PoH hash chain broken at index %d: sequence %d does not follow hash %s", i, proof.Sequence, prev.Hash)
+        }
+    }
+    return true, -1, nil
+}
+
 // ValidatePoHProof validates that a PoH proof is part of the correct sequence and validates the transactions it covers.
 func (p *PoH) ValidatePoHProof(proof PoHProof, transactions []Transaction) (bool, error) {
     if proof.Sequence <= 0 {
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+)
+
+func pohTestChain(n int) []PoHProof {
+	proofs := make([]PoHProof, n)
+	for i := range proofs {
+		proofs[i] = PoHProof{Sequence: i + 1, Hash: fmt.Sprintf("hash-%d", i+1)}
+		if i > 0 {
+			proofs[i].PrevHash = proofs[i-1].Hash
+		}
+	}
+	return proofs
+}
+
+func TestVerifyPoHChainAcceptsContinuousChain(t *testing.T) {
+	valid, gapAt, err := VerifyPoHChain(pohTestChain(4))
+	if err != nil || !valid || gapAt != -1 {
+		t.Fatalf("VerifyPoHChain = %v, %d, %v; want true, -1, nil", valid, gapAt, err)
+	}
+}
+
+func TestVerifyPoHChainDetectsSequenceGap(t *testing.T) {
+	proofs := pohTestChain(4)
+	proofs[2].Sequence = 5
+
+	valid, gapAt, err := VerifyPoHChain(proofs)
+	if err == nil || valid || gapAt != 2 {
+		t.Fatalf("VerifyPoHChain = %v, %d, %v; want a gap reported at index 2", valid, gapAt, err)
+	}
+}
+
+func TestVerifyPoHChainDetectsBrokenHashLink(t *testing.T) {
+	proofs := pohTestChain(3)
+	proofs[1].PrevHash = "forged"
+
+	if valid, gapAt, err := VerifyPoHChain(proofs); err == nil || valid || gapAt != 1 {
+		t.Fatalf("VerifyPoHChain = %v, %d, %v; want a break reported at index 1", valid, gapAt, err)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
-	"math/rand"
+	"sort"
 	"strings"
 	"synnergy_network/pkg/ledger"
 	"time"
@@ -67,51 +67,103 @@ func NewPoS(validators []Validator, ledgerInstance *ledger.Ledger, rewardManager
 }
 
 
-// SelectValidator selects a validator to validate a sub-block based on their stake.
-func (pos *PoS) SelectValidator() (Validator, error) {
-    // Validate the PoS state
-    if len(pos.State.Validators) == 0 {
+// SelectValidator deterministically selects a validator with probability proportional to its
+// stake, using seed (e.g. the previous block hash) as the source of randomness so every node
+// arrives at the same validator. Candidates are ordered by address so the result does not depend
+// on insertion order, and the last selected validator is skipped when others are eligible.
+//
+// Selection is split in two: SelectValidator only picks a validator and does not modify state,
+// while CommitSelection updates LastSelected, LastUpdated and the epoch, records the selection
+// and pays rewards. Callers commit once the selected validator has actually validated, so a
+// rejected sub-block neither advances the epoch nor rewards its validator. Callers without a
+// block hash at hand can use SelectionSeed.
+func (pos *PoS) SelectValidator(seed []byte) (Validator, error) {
+    if len(seed) == 0 {
+        return Validator{}, fmt.Errorf("validator selection failed: seed cannot be empty")
+    }
+
+    // Collect the validators eligible by stake
+    var candidates []Validator
+    for _, validator := range pos.State.Validators {
+        if validator.Stake > 0 && validator.Stake >= pos.MinStake {
+            candidates = append(candidates, validator)
+        }
+    }
+    if len(candidates) == 0 {
         return Validator{}, fmt.Errorf("validator selection failed: no validators available")
     }
-    if pos.State.TotalStake <= 0 {
-        return Validator{}, fmt.Errorf("validator selection failed: total stake must be greater than 0")
+    sort.Slice(candidates, func(i, j int) bool {
+        return candidates[i].Address < candidates[j].Address
+    })
+
+    // Avoid selecting the same validator twice in a row
+    if len(candidates) > 1 && pos.State.LastSelected != "" {
+        filtered := candidates[:0:0]
+        for _, validator := range candidates {
+            if validator.Address != pos.State.LastSelected {
+                filtered = append(filtered, validator)
+            }
+        }
+        candidates = filtered
     }
 
-    log.Printf("[Info] Starting validator selection for epoch %d", pos.State.Epoch+1)
+    eligibleStake := 0.0
+    for _, validator := range candidates {
+        eligibleStake += validator.Stake
+    }
 
-    // Perform weighted random selection based on stake
-    stakeThreshold := rand.Float64() * pos.State.TotalStake
-    cumulativeStake := 0.0
-    var selectedValidator Validator
+    // Derive a uniform point in [0, eligibleStake) from the seed
+    digest := sha256.Sum256(seed)
+    fraction := new(big.Float).Quo(
+        new(big.Float).SetInt(new(big.Int).SetBytes(digest[:8])),
+        new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+    )
+    point, _ := fraction.Float64()
+    stakeThreshold := point * eligibleStake
 
-    for _, validator := range pos.State.Validators {
+    selectedValidator := candidates[len(candidates)-1]
+    cumulativeStake := 0.0
+    for _, validator := range candidates {
         cumulativeStake += validator.Stake
-        if cumulativeStake >= stakeThreshold {
+        if stakeThreshold < cumulativeStake {
             selectedValidator = validator
-            pos.State.LastSelected = validator.Address
-            pos.State.LastUpdated = time.Now()
-            pos.State.Epoch++
+            break
+        }
+    }
 
-            log.Printf("[Success] Validator %s selected for epoch %d with stake %.2f", 
-                validator.Address, pos.State.Epoch, validator.Stake)
+    return selectedValidator, nil
+}
 
-            // Record the selection in the ledger
-            err := pos.LedgerInstance.BlockchainConsensusCoinLedger.RecordValidatorSelection(validator.Address, pos.State.Epoch)
-            if err != nil {
-                return Validator{}, fmt.Errorf("failed to record validator selection in ledger: %w", err)
-            }
+// SelectionSeed returns a seed for SelectValidator that every node derives alike: the hash of
+// the latest block in the ledger, or the current epoch when no block has been recorded yet.
+func (pos *PoS) SelectionSeed() []byte {
+    if pos.LedgerInstance != nil {
+        if block, err := pos.LedgerInstance.BlockchainConsensusCoinLedger.GetLatestBlock(); err == nil && block.Hash != "" {
+            return []byte(block.Hash)
+        }
+    }
+    return []byte(fmt.Sprintf("epoch-%d", pos.State.Epoch))
+}
 
-            // Distribute rewards to the selected validator
-            err = pos.RewardManager.DistributePoSRewards(validator)
-            if err != nil {
-                return Validator{}, fmt.Errorf("failed to distribute rewards to validator %s: %w", validator.Address, err)
-            }
+// CommitSelection advances the epoch for a validator chosen by SelectValidator, records the
+// selection in the ledger and distributes its rewards.
+func (pos *PoS) CommitSelection(validator Validator) error {
+    pos.State.LastSelected = validator.Address
+    pos.State.LastUpdated = time.Now()
+    pos.State.Epoch++
 
-            return validator, nil
-        }
+    log.Printf("[Success] Validator %s selected for epoch %d with stake %.2f",
+        validator.Address, pos.State.Epoch, validator.Stake)
+
+    // Record the selection in the ledger
+    err := pos.LedgerInstance.BlockchainConsensusCoinLedger.RecordValidatorSelection(validator.Address, pos.State.Epoch)
+    if err != nil {
+        return fmt.Errorf("failed to record validator selection in ledger: %w", err)
     }
 
-    return Validator{}, fmt.Errorf("validator selection failed due to stake computation error")
+    // Distribute rewards to the selected validator
+    pos.RewardManager.DistributePoSRewards(validator)
+    return nil
 }
 
 
@@ -226,7 +278,7 @@ func (pos *PoS) ValidateSubBlock(subBlock SubBlock) bool {
     log.Printf("[Info] Validating sub-block %d by validator %s...", subBlock.Index, subBlock.Validator)
 
     // Step 1: Select the validator.
-    selectedValidator, err := pos.SelectValidator()
+    selectedValidator, err := pos.SelectValidator([]byte(subBlock.PrevHash))
     if err != nil {
         log.Printf("[Error] Failed to select validator: %v", err)
         return false
@@ -1,8 +1,6 @@
 package common
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"log"
 	"math"
@@ -130,7 +128,7 @@ func (pow *PoW) ValidateSubBlock(subBlock SubBlock) bool {
     log.Printf("[Info] Validating sub-block #%d...", subBlock.Index)
 
     // Step 1: Verify hash integrity of the sub-block
-    recalculatedHash := pow.calculateSubBlockHash(subBlock)
+    recalculatedHash := subBlock.ComputeHash()
     if recalculatedHash != subBlock.Hash {
         log.Printf("[Error] Sub-block #%d hash mismatch. Expected: %s, Got: %s", subBlock.Index, recalculatedHash, subBlock.Hash)
         return false
@@ -211,18 +209,6 @@ func (pow *PoW) AdjustDifficulty(blockTime time.Duration) {
 
 
 
-// calculateSubBlockHash computes the hash of a sub-block.
-func (pow *PoW) calculateSubBlockHash(subBlock SubBlock) string {
-    transactionHashes := extractTransactionHashes(subBlock.Transactions)
-    input := fmt.Sprintf("%d:%s:%s:%s", subBlock.Index, subBlock.PrevHash, transactionHashes, subBlock.Timestamp)
-    
-    hash := sha256.New()
-    hash.Write([]byte(input))
-    return hex.EncodeToString(hash.Sum(nil))
-}
-
-
-
 // checkSubBlockCompliance verifies that the sub-block adheres to the consensus rules.
 func (pow *PoW) checkSubBlockCompliance(subBlock SubBlock) bool {
     log.Printf("[Info] Checking consensus compliance for sub-block #%d...", subBlock.Index)
@@ -313,16 +299,7 @@ func (pow *PoW) validateTransaction(tx Transaction) bool {
 
 // calculateBlockHash generates a SHA-256 hash for the block.
 func (pow *PoW) calculateBlockHash(block *Block) string {
-    hashInput := fmt.Sprintf("%d:%s:%s:%d:%s", 
-        block.Index, 
-        block.Timestamp.Format(time.RFC3339Nano), 
-        block.PrevHash, 
-        block.Nonce, 
-        pow.concatenateSubBlockHashes(block.SubBlocks))
-
-    hash := sha256.New()
-    hash.Write([]byte(hashInput))
-    return hex.EncodeToString(hash.Sum(nil))
+    return block.ComputeHash()
 }
 
 // concatenateSubBlockHashes generates a single string from all sub-block hashes.
@@ -375,7 +352,7 @@ func (pow *PoW) validateSubBlock(subBlock SubBlock) bool {
     log.Printf("[Info] Validating sub-block #%d...", subBlock.Index)
 
     // Step 1: Verify hash integrity
-    recalculatedHash := pow.calculateSubBlockHash(subBlock)
+    recalculatedHash := subBlock.ComputeHash()
     if recalculatedHash != subBlock.Hash {
         log.Printf("[Error] Sub-block #%d hash mismatch. Expected: %s, Got: %s.", subBlock.Index, recalculatedHash, subBlock.Hash)
         return false
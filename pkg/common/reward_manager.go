@@ -15,6 +15,7 @@ type RewardManager struct {
 	PoWInitialReward   float64        // Initial block reward for PoW
 	PoWHalvingInterval int            // Number of blocks before PoW reward halves
 	CurrentBlockCount  int            // Current block count to track PoW halving
+	PoWMinimumReward   float64        // Floor the PoW reward never halves below
 	RewardPool         float64        // Combined pool for validator and miner rewards
 	LedgerInstance     *ledger.Ledger // Instance of the ledger for reward tracking
 	PunishmentManager  *PunishmentManager // Reference to the PunishmentManager for enforcing penalties
@@ -50,6 +51,7 @@ func NewRewardManager(ledgerInstance *ledger.Ledger, punishmentManager *Punishme
         PoWInitialReward:   1024,              // Initial PoW reward per block
         PoWHalvingInterval: 200000,            // Halving reward every 200,000 blocks
         CurrentBlockCount:  0,
+        PoWMinimumReward:   1,                 // PoW reward never halves below 1 SYNN
         RewardPool:         0.0,               // Initialize with 0 SYNN in the reward pool
         LedgerInstance:     ledgerInstance,
         PunishmentManager:  punishmentManager, // Link to the PunishmentManager
@@ -136,13 +138,16 @@ func (pm *PunishmentManager) applyPunishment(entity string, punishmentAmount flo
 
 
 
-// ResetPunishments removes punishments older than 90 days
+// ResetPunishments removes punishments older than the reset interval
 func (pm *PunishmentManager) ResetPunishments() {
+    pm.ResetExpiredPunishments(time.Now())
+}
+
+// ResetExpiredPunishments prunes punishment history entries older than PunishmentResetInterval.
+func (pm *PunishmentManager) ResetExpiredPunishments(now time.Time) {
     pm.mutex.Lock()
     defer pm.mutex.Unlock()
 
-    now := time.Now()
-
     for entity, punishments := range pm.PunishmentHistory {
         var updatedPunishments []Punishment
 
@@ -153,14 +158,63 @@ func (pm *PunishmentManager) ResetPunishments() {
         }
 
         // Update the entity's punishment history
-        pm.PunishmentHistory[entity] = updatedPunishments
+        if len(updatedPunishments) == 0 {
+            delete(pm.PunishmentHistory, entity)
+        } else {
+            pm.PunishmentHistory[entity] = updatedPunishments
+        }
 
         if len(punishments) != len(updatedPunishments) {
-            fmt.Printf("Punishments older than 90 days for %s have been reset.\n", entity)
+            fmt.Printf("Punishments older than %s for %s have been reset.\n", pm.PunishmentResetInterval, entity)
         }
     }
 }
 
+// ApplyPoSPunishment slashes PoSPunishmentRate percent of a validator's stake when its downtime
+// reaches PoSPunishmentThreshold hours, recording the punishment in the history and the ledger.
+func (pm *PunishmentManager) ApplyPoSPunishment(validatorID string, downtime time.Duration) (slashed float64, err error) {
+    if validatorID == "" {
+        return 0, fmt.Errorf("validator ID cannot be empty")
+    }
+
+    pm.mutex.Lock()
+    defer pm.mutex.Unlock()
+
+    if downtime.Hours() < pm.PoSPunishmentThreshold {
+        return 0, nil
+    }
+
+    consensusLedger := pm.LedgerInstance.BlockchainConsensusCoinLedger
+    stake, err := consensusLedger.GetValidatorStake(validatorID)
+    if err != nil {
+        return 0, fmt.Errorf("failed to read stake for validator %s: %v", validatorID, err)
+    }
+
+    slashed = stake * pm.PoSPunishmentRate / 100
+    if err := consensusLedger.UpdateValidatorStake(validatorID, -slashed); err != nil {
+        return 0, fmt.Errorf("failed to slash stake for validator %s: %v", validatorID, err)
+    }
+
+    now := time.Now()
+    if pm.PunishmentHistory == nil {
+        pm.PunishmentHistory = make(map[string][]Punishment)
+    }
+    pm.PunishmentHistory[validatorID] = append(pm.PunishmentHistory[validatorID], Punishment{Amount: slashed, Timestamp: now})
+
+    record := ledger.PunishmentRecord{
+        ValidatorID:     validatorID,
+        Reason:          fmt.Sprintf("PoS downtime of %s exceeded %.0f hours", downtime, pm.PoSPunishmentThreshold),
+        Timestamp:       now,
+        PunishmentLevel: int(downtime.Hours() / pm.PoSPunishmentThreshold),
+    }
+    if err := consensusLedger.RecordPunishmentRecord(record); err != nil {
+        return slashed, fmt.Errorf("failed to record punishment for validator %s: %v", validatorID, err)
+    }
+
+    fmt.Printf("Validator %s slashed %.2f SYNN for %s of downtime.\n", validatorID, slashed, downtime)
+    return slashed, nil
+}
+
 // DistributeRewards splits the reward pool between validators and miners based on their contribution
 func (rm *RewardManager) DistributeRewards(validators map[string]float64, miners map[string]float64, totalSubBlockContribution float64, totalBlockContribution float64) {
     rm.mutex.Lock()
@@ -288,9 +342,32 @@ func (rm *RewardManager) DistributePoWRewards(minerAddress string) error {
 
     fmt.Printf("Distributed %.2f SYNN PoW reward to miner %s.\n", currentReward, minerAddress)
 
-    // Increment block count and check for halving (if applicable)
-    rm.CurrentBlockCount++
+    // Record the block reward and advance the block count for halving
+    return rm.advanceBlock(currentReward)
+}
+
+// CurrentPoWReward returns the PoW reward for the current block after halving.
+func (rm *RewardManager) CurrentPoWReward() float64 {
+    rm.mutex.Lock()
+    defer rm.mutex.Unlock()
+
+    return rm.calculateCurrentPoWReward()
+}
 
+// AdvanceBlock records the current block's PoW reward to the ledger and moves to the next block.
+func (rm *RewardManager) AdvanceBlock() error {
+    rm.mutex.Lock()
+    defer rm.mutex.Unlock()
+
+    return rm.advanceBlock(rm.calculateCurrentPoWReward())
+}
+
+// advanceBlock records a block reward and increments the block count. The caller must hold the mutex.
+func (rm *RewardManager) advanceBlock(reward float64) error {
+    if err := rm.LedgerInstance.BlockchainConsensusCoinLedger.RecordBlockReward(rm.CurrentBlockCount, reward); err != nil {
+        return fmt.Errorf("failed to record block reward: %v", err)
+    }
+    rm.CurrentBlockCount++
     return nil
 }
 
@@ -301,10 +378,18 @@ func (rm *RewardManager) DistributePoWRewards(minerAddress string) error {
 
 // calculateCurrentPoWReward calculates the PoW reward based on the halving interval
 func (rm *RewardManager) calculateCurrentPoWReward() float64 {
+    minimumReward := rm.PoWMinimumReward
+    if minimumReward <= 0 {
+        minimumReward = 1 // Ensure reward doesn't go below 1 SYNN
+    }
+    if rm.PoWHalvingInterval <= 0 {
+        return math.Max(rm.PoWInitialReward, minimumReward)
+    }
+
     halvings := rm.CurrentBlockCount / rm.PoWHalvingInterval // Integer division
     currentReward := rm.PoWInitialReward / math.Pow(2, float64(halvings)) // Use exponentiation instead of bitwise shift
-    if currentReward < 1 {
-        currentReward = 1 // Ensure reward doesn't go below 1 SYNN
+    if currentReward < minimumReward {
+        currentReward = minimumReward
     }
     return currentReward
 }
@@ -1,10 +1,13 @@
 package common
 
 import (
+	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"synnergy_network/pkg/ledger"
 	"time"
@@ -82,7 +85,7 @@ func (bc *SubBlockChain) NewSubBlock(transactions []Transaction, prevHash string
         PrevHash:     prevHash,
     }
 
-    subBlock.Hash = calculateSubBlockHash(subBlock)
+    subBlock.Hash = subBlock.ComputeHash()
 
     // Add the sub-block to the blockchain
     bc.SubBlocks = append(bc.SubBlocks, subBlock)
@@ -108,17 +111,73 @@ func (bc *SubBlockChain) selectValidator() string {
     return bc.Validators[rand.Intn(len(bc.Validators))]
 }
 
-// calculateSubBlockHash generates a SHA-256 hash for the sub-block
-func calculateSubBlockHash(subBlock SubBlock) string {
-    hashInput := fmt.Sprintf("%d%s%s%s", 
-        subBlock.Index, 
-        subBlock.Timestamp.String(),
-        subBlock.PrevHash,
-        subBlock.Validator)
-
-    hash := sha256.New()
-    hash.Write([]byte(hashInput))
-    return hex.EncodeToString(hash.Sum(nil))
+// validatorPublicKeys maps validator addresses to the public keys used to verify their sub-block signatures.
+var (
+    validatorPublicKeys   = make(map[string]*ecdsa.PublicKey)
+    validatorPublicKeysMu sync.RWMutex
+)
+
+// RegisterValidatorPublicKey registers the public key used to verify sub-blocks signed by a validator.
+func RegisterValidatorPublicKey(validator string, publicKey *ecdsa.PublicKey) error {
+    if validator == "" {
+        return fmt.Errorf("validator address cannot be empty")
+    }
+    if publicKey == nil {
+        return fmt.Errorf("public key for validator %s cannot be nil", validator)
+    }
+
+    validatorPublicKeysMu.Lock()
+    defer validatorPublicKeysMu.Unlock()
+    validatorPublicKeys[validator] = publicKey
+    return nil
+}
+
+// ComputeHash deterministically hashes the sub-block's index, timestamp, sorted transaction IDs,
+// validator, previous hash and PoH proof with SHA-256.
+func (sb *SubBlock) ComputeHash() string {
+    txIDs := make([]string, 0, len(sb.Transactions))
+    for _, tx := range sb.Transactions {
+        txIDs = append(txIDs, tx.TransactionID)
+    }
+    sort.Strings(txIDs)
+
+    input := fmt.Sprintf("%d|%d|%s|%s|%s|%d:%d:%s",
+        sb.Index,
+        sb.Timestamp.UnixNano(),
+        strings.Join(txIDs, ","),
+        sb.Validator,
+        sb.PrevHash,
+        sb.PoHProof.Sequence,
+        sb.PoHProof.Timestamp.UnixNano(),
+        sb.PoHProof.Hash)
+
+    hash := sha256.Sum256([]byte(input))
+    return hex.EncodeToString(hash[:])
+}
+
+// Verify checks that the sub-block's Hash matches its contents and that its Signature, in
+// "r,s" form, was made over that hash by the validator's registered public key. It is meant for
+// sub-blocks received from peers; sub-blocks built locally by NewSubBlock are not signed.
+func (sb *SubBlock) Verify() error {
+    if expected := sb.ComputeHash(); sb.Hash != expected {
+        return fmt.Errorf("sub-block %d hash mismatch: expected %s, got %s", sb.Index, expected, sb.Hash)
+    }
+
+    validatorPublicKeysMu.RLock()
+    publicKey, exists := validatorPublicKeys[sb.Validator]
+    validatorPublicKeysMu.RUnlock()
+    if !exists {
+        return fmt.Errorf("no public key registered for validator %s", sb.Validator)
+    }
+
+    signatureParts := strings.Split(sb.Signature, ",")
+    if len(signatureParts) != 2 {
+        return fmt.Errorf("invalid signature format on sub-block %d", sb.Index)
+    }
+    if !VerifyECDSASignature(publicKey, sb.Hash, signatureParts[0], signatureParts[1]) {
+        return fmt.Errorf("invalid signature on sub-block %d from validator %s", sb.Index, sb.Validator)
+    }
+    return nil
 }
 
 // AddTransactions adds new transactions to a sub-block and finalizes it
@@ -166,6 +225,15 @@ func (bc *SubBlockChain) ValidateSubBlock(subBlock SubBlock) bool {
         return false
     }
 
+    // Proof of History: a timestamped sub-block's proof must directly follow its predecessor's
+    if subBlock.Index > 0 && subBlock.Index <= len(bc.SubBlocks) && subBlock.PoHProof.Sequence > 0 {
+        prevProof := bc.SubBlocks[subBlock.Index-1].PoHProof
+        if _, _, err := VerifyPoHChain([]PoHProof{prevProof, subBlock.PoHProof}); err != nil {
+            fmt.Printf("SubBlock rejected due to discontinuous PoH proof: %v\n", err)
+            return false
+        }
+    }
+
     fmt.Printf("SubBlock %d successfully validated.\n", subBlock.Index)
     return true
 }
@@ -243,7 +311,7 @@ func (sbm *SubBlockManager) CreateNewSubBlock() *SubBlock {
 // FinalizeSubBlock finalizes and logs a sub-block in the ledger.
 func (sbm *SubBlockManager) FinalizeSubBlock(subBlock *SubBlock) error {
 	// Finalize the sub-block: Assign a validator, calculate the hash, etc.
-	subBlock.Hash = calculateSubBlockHash(*subBlock)
+	subBlock.Hash = subBlock.ComputeHash()
 
 	// Convert common SubBlock to ledger SubBlock
 	ledgerSubBlock := ConvertCommonSubBlockToLedgerSubBlock(subBlock)
@@ -106,17 +106,20 @@ func (sc *SynnergyConsensus) ProcessTransactions() {
         go func() {
             defer wg.Done()
             fmt.Println("[Info] Starting PoS validation...")
-            validator, err := sc.PoS.SelectValidator()
+            validator, err := sc.PoS.SelectValidator([]byte(subBlock.PrevHash))
             if err != nil {
                 fmt.Printf("[Error] Failed to select validator: %v\n", err)
                 return
             }
 
             fmt.Printf("[Info] Validator selected: %s\n", validator.Address)
-            sc.RewardManager.DistributePoSRewards(validator)
 
             if sc.PoS.ValidateSubBlock(subBlock) {
                 fmt.Printf("[Success] Sub-block #%d validated by validator %s.\n", subBlock.Index, validator.Address)
+                if err := sc.PoS.CommitSelection(validator); err != nil {
+                    fmt.Printf("[Error] Failed to commit validator selection: %v\n", err)
+                    return
+                }
 
                 // Add sub-block to ledger
                 ledgerSubBlock := ledger.SubBlock{
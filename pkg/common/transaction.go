@@ -32,6 +32,7 @@ type Transaction struct {
 	FrozenAmount float64 // Amount that is frozen in the transaction (if applicable)
     RefundAmount float64 // Amount refunded in case of a reversal or error
 	ReversalRequested bool    // Whether a reversal has been requested (Add this field)
+	Nonce             uint64  // Per-sender sequence number used to order pending transactions
 }
 
 type CrossChainTransaction struct {
@@ -74,6 +75,7 @@ type TransactionManager struct {
     Ledger      *ledger.Ledger              // Reference to the blockchain ledger
     Consensus   *SynnergyConsensus  // Consensus engine for Synnergy Consensus
     Encryption  *Encryption      // Encryption service
+    Mempool     *Blockchain      // Mempool created transactions are queued in (optional)
 }
 
 // NewTransactionManager initializes a new transaction manager.
@@ -101,6 +103,9 @@ func (tm *TransactionManager) CreateTransaction(fromAddress, toAddress string, a
 		Timestamp:     timestamp,
 		Status:        "pending",
 	}
+	if tm.Mempool != nil {
+		transaction.Nonce = tm.Mempool.NextNonce(fromAddress)
+	}
 
 	// Convert transaction details to bytes for encryption
 	transactionBytes := []byte(fmt.Sprintf("%v", transaction))
@@ -125,6 +130,14 @@ func (tm *TransactionManager) CreateTransaction(fromAddress, toAddress string, a
 		return nil, fmt.Errorf("error adding transaction to ledger: %v", err)
 	}
 
+	// Queue the transaction in the mempool, which rejects it if another transaction
+	// from the sender took its nonce in the meantime
+	if tm.Mempool != nil {
+		if err := tm.Mempool.AddPendingTransaction(*transaction); err != nil {
+			return nil, fmt.Errorf("error adding transaction to mempool: %v", err)
+		}
+	}
+
 	return transaction, nil
 }
 
@@ -141,6 +154,11 @@ func GenerateTransactionID() string {
 
 // ValidateTransaction performs validation on a transaction.
 func (tm *TransactionManager) ValidateTransaction(transaction *Transaction) error {
+    // Reject transactions to or from quarantined entities
+    if err := authorizeTransaction(tm.Ledger, *transaction); err != nil {
+        return err
+    }
+
     // Ensure that the sender has enough balance
     senderBalance, err := tm.Ledger.GetBalance(transaction.FromAddress)
     if err != nil {
@@ -160,6 +178,23 @@ func (tm *TransactionManager) ValidateTransaction(transaction *Transaction) erro
 }
 
 
+// authorizeTransaction rejects a transaction whose sender or recipient has been
+// isolated in the ledger.
+func authorizeTransaction(ledgerInstance *ledger.Ledger, tx Transaction) error {
+	if ledgerInstance == nil {
+		return nil
+	}
+	for _, entity := range []string{tx.FromAddress, tx.ToAddress} {
+		if entity == "" {
+			continue
+		}
+		if err := ledgerInstance.AdvancedSecurityLedger.AuthorizeEntityOperation(entity, "transaction"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ExecuteTransaction executes the transaction and records the result in the ledger.
 func (tm *TransactionManager) ExecuteTransaction(transaction *Transaction) error {
     // Define the encryption key
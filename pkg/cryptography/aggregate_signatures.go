@@ -1,8 +1,17 @@
 package cryptography
 
 import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"synnergy_network/pkg/ledger"
+	"time"
 )
 
 var signatureLock sync.Mutex
@@ -60,3 +69,183 @@ func BLSAggregateVerify(publicKeys [][]byte, messages [][]byte, aggregateSignatu
     return true, nil
 }
 
+
+// Supported SignatureAggregation algorithms
+const (
+    AggregationAlgorithmECDSA = "ECDSA"
+    AggregationAlgorithmBLS   = "BLS"
+)
+
+// Aggregate: Combines the participant signatures of sa into AggregatedSignature once at least
+// Threshold participants have signed. ECDSA signatures are concatenated in participant order.
+func Aggregate(sa *ledger.SignatureAggregation) error {
+    signatureLock.Lock()
+    defer signatureLock.Unlock()
+
+    if sa == nil {
+        return errors.New("signature aggregation cannot be nil")
+    }
+
+    signers := aggregationSigners(sa)
+    if sa.Threshold <= 0 || len(signers) < sa.Threshold {
+        LogSignatureOperation("Aggregate", fmt.Sprintf("Aggregation %s has %d of %d required signatures", sa.AggregationID, len(signers), sa.Threshold))
+        return fmt.Errorf("aggregation %s has %d signatures, %d required", sa.AggregationID, len(signers), sa.Threshold)
+    }
+
+    switch strings.ToUpper(sa.Algorithm) {
+    case "", AggregationAlgorithmECDSA:
+        var aggregated []byte
+        for _, id := range signers {
+            aggregated = appendLengthPrefixed(aggregated, []byte(id))
+            aggregated = appendLengthPrefixed(aggregated, sa.Signatures[id])
+        }
+        sa.AggregatedSignature = aggregated
+    case AggregationAlgorithmBLS:
+        return errors.New("BLS signature aggregation is not supported yet")
+    default:
+        return fmt.Errorf("unsupported aggregation algorithm: %s", sa.Algorithm)
+    }
+
+    sa.Timestamp = time.Now()
+    sa.IsVerified = false
+    sa.Status = "completed"
+    LogSignatureOperation("Aggregate", fmt.Sprintf("Aggregated %d signatures for %s", len(signers), sa.AggregationID))
+    return nil
+}
+
+// VerifyAggregate: Verifies the AggregatedSignature of sa against message. pubkeys maps participant
+// IDs to PKIX-encoded ECDSA public keys. Every signature in the aggregate must verify, come from a
+// member of Participants when that list is set, and at least Threshold distinct participants must
+// be present. The outcome is appended to VerificationLogs.
+func VerifyAggregate(sa *ledger.SignatureAggregation, message []byte, pubkeys map[string][]byte) error {
+    signatureLock.Lock()
+    defer signatureLock.Unlock()
+
+    if sa == nil {
+        return errors.New("signature aggregation cannot be nil")
+    }
+
+    err := verifyAggregate(sa, message, pubkeys)
+    entry := ledger.VerificationLog{
+        LogID:        fmt.Sprintf("%s-%d", sa.AggregationID, len(sa.VerificationLogs)+1),
+        VerifierID:   "VerifyAggregate",
+        VerifiedAt:   time.Now(),
+        IsSuccessful: err == nil,
+    }
+    if err != nil {
+        entry.Error = err.Error()
+    }
+    sa.VerificationLogs = append(sa.VerificationLogs, entry)
+    sa.IsVerified = err == nil
+
+    if err != nil {
+        LogSignatureOperation("VerifyAggregate", fmt.Sprintf("Aggregate %s verification failed: %v", sa.AggregationID, err))
+        return err
+    }
+    LogSignatureOperation("VerifyAggregate", fmt.Sprintf("Aggregate %s verified successfully", sa.AggregationID))
+    return nil
+}
+
+// verifyAggregate checks every signature packed into sa.AggregatedSignature.
+func verifyAggregate(sa *ledger.SignatureAggregation, message []byte, pubkeys map[string][]byte) error {
+    switch strings.ToUpper(sa.Algorithm) {
+    case "", AggregationAlgorithmECDSA:
+    case AggregationAlgorithmBLS:
+        return errors.New("BLS aggregate verification is not supported yet")
+    default:
+        return fmt.Errorf("unsupported aggregation algorithm: %s", sa.Algorithm)
+    }
+
+    if len(sa.AggregatedSignature) == 0 {
+        return errors.New("no aggregated signature to verify")
+    }
+
+    participants := make(map[string]bool, len(sa.Participants))
+    for _, id := range sa.Participants {
+        participants[id] = true
+    }
+
+    hash := sha256.Sum256(message)
+    verified := make(map[string]bool)
+    rest := sa.AggregatedSignature
+    for len(rest) > 0 {
+        var id, sig []byte
+        var err error
+        if id, rest, err = readLengthPrefixed(rest); err != nil {
+            return err
+        }
+        if sig, rest, err = readLengthPrefixed(rest); err != nil {
+            return err
+        }
+
+        if len(participants) > 0 && !participants[string(id)] {
+            return fmt.Errorf("signer %s is not a participant of aggregation %s", id, sa.AggregationID)
+        }
+        if verified[string(id)] {
+            return fmt.Errorf("duplicate signature from participant %s", id)
+        }
+
+        encodedKey, ok := pubkeys[string(id)]
+        if !ok {
+            return fmt.Errorf("no public key for participant %s", id)
+        }
+        parsed, err := x509.ParsePKIXPublicKey(encodedKey)
+        if err != nil {
+            return fmt.Errorf("invalid public key for participant %s: %v", id, err)
+        }
+        publicKey, ok := parsed.(*ecdsa.PublicKey)
+        if !ok {
+            return fmt.Errorf("public key for participant %s is not an ECDSA key", id)
+        }
+        if !ecdsa.VerifyASN1(publicKey, hash[:], sig) {
+            return fmt.Errorf("invalid signature from participant %s", id)
+        }
+        verified[string(id)] = true
+    }
+
+    if len(verified) < sa.Threshold {
+        return fmt.Errorf("aggregate has %d valid signatures, %d required", len(verified), sa.Threshold)
+    }
+    return nil
+}
+
+// aggregationSigners returns the sorted IDs of participants that have provided a signature. When
+// Participants is set, signatures from anyone else are ignored.
+func aggregationSigners(sa *ledger.SignatureAggregation) []string {
+    candidates := sa.Participants
+    if len(candidates) == 0 {
+        for id := range sa.Signatures {
+            candidates = append(candidates, id)
+        }
+    }
+
+    seen := make(map[string]bool)
+    var signers []string
+    for _, id := range candidates {
+        if len(sa.Signatures[id]) > 0 && !seen[id] {
+            seen[id] = true
+            signers = append(signers, id)
+        }
+    }
+    sort.Strings(signers)
+    return signers
+}
+
+// appendLengthPrefixed appends data to buf preceded by its 2-byte big-endian length.
+func appendLengthPrefixed(buf, data []byte) []byte {
+    var length [2]byte
+    binary.BigEndian.PutUint16(length[:], uint16(len(data)))
+    return append(append(buf, length[:]...), data...)
+}
+
+// readLengthPrefixed reads one length-prefixed field from buf and returns it with the remainder.
+func readLengthPrefixed(buf []byte) ([]byte, []byte, error) {
+    if len(buf) < 2 {
+        return nil, nil, errors.New("malformed aggregated signature")
+    }
+    length := int(binary.BigEndian.Uint16(buf[:2]))
+    if len(buf) < 2+length {
+        return nil, nil, errors.New("malformed aggregated signature")
+    }
+    return buf[2 : 2+length], buf[2+length:], nil
+}
@@ -0,0 +1,116 @@
+package cryptography
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+
+	"synnergy_network/pkg/ledger"
+)
+
+type testSigner struct {
+	key    *ecdsa.PrivateKey
+	pubkey []byte
+}
+
+func newTestSigner(t *testing.T) testSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	return testSigner{key: key, pubkey: pub}
+}
+
+func (s testSigner) sign(t *testing.T, message []byte) []byte {
+	t.Helper()
+	hash := sha256.Sum256(message)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, hash[:])
+	if err != nil {
+		t.Fatalf("SignASN1: %v", err)
+	}
+	return sig
+}
+
+func TestAggregateAndVerify(t *testing.T) {
+	message := []byte("block-42")
+	alice, bob := newTestSigner(t), newTestSigner(t)
+	sa := &ledger.SignatureAggregation{
+		AggregationID: "agg-1",
+		Participants:  []string{"alice", "bob"},
+		Threshold:     2,
+		Signatures: map[string][]byte{
+			"alice": alice.sign(t, message),
+			"bob":   bob.sign(t, message),
+		},
+	}
+	pubkeys := map[string][]byte{"alice": alice.pubkey, "bob": bob.pubkey}
+
+	if err := Aggregate(sa); err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if err := VerifyAggregate(sa, message, pubkeys); err != nil {
+		t.Fatalf("VerifyAggregate: %v", err)
+	}
+	if !sa.IsVerified {
+		t.Fatal("expected aggregation to be marked verified")
+	}
+	if err := VerifyAggregate(sa, []byte("block-43"), pubkeys); err == nil {
+		t.Fatal("expected verification against a different message to fail")
+	}
+}
+
+func TestVerifyAggregateRejectsNonParticipant(t *testing.T) {
+	message := []byte("block-42")
+	alice, mallory := newTestSigner(t), newTestSigner(t)
+
+	var aggregated []byte
+	aggregated = appendLengthPrefixed(aggregated, []byte("alice"))
+	aggregated = appendLengthPrefixed(aggregated, alice.sign(t, message))
+	aggregated = appendLengthPrefixed(aggregated, []byte("mallory"))
+	aggregated = appendLengthPrefixed(aggregated, mallory.sign(t, message))
+
+	sa := &ledger.SignatureAggregation{
+		AggregationID:       "agg-2",
+		Participants:        []string{"alice", "bob"},
+		Threshold:           2,
+		AggregatedSignature: aggregated,
+	}
+	pubkeys := map[string][]byte{"alice": alice.pubkey, "mallory": mallory.pubkey}
+
+	if err := VerifyAggregate(sa, message, pubkeys); err == nil {
+		t.Fatal("expected a signature from a non-participant to be rejected")
+	}
+	if sa.IsVerified {
+		t.Fatal("aggregation should not be marked verified")
+	}
+}
+
+func TestVerifyAggregateRejectsDuplicateSigner(t *testing.T) {
+	message := []byte("block-42")
+	alice := newTestSigner(t)
+	sig := alice.sign(t, message)
+
+	var aggregated []byte
+	for i := 0; i < 2; i++ {
+		aggregated = appendLengthPrefixed(aggregated, []byte("alice"))
+		aggregated = appendLengthPrefixed(aggregated, sig)
+	}
+	sa := &ledger.SignatureAggregation{
+		AggregationID:       "agg-3",
+		Participants:        []string{"alice", "bob"},
+		Threshold:           2,
+		AggregatedSignature: aggregated,
+	}
+
+	if err := VerifyAggregate(sa, message, map[string][]byte{"alice": alice.pubkey}); err == nil {
+		t.Fatal("expected a repeated signer not to satisfy the threshold")
+	}
+}
@@ -9,14 +9,16 @@ import (
 
 // DAO represents a decentralized autonomous organization on the blockchain.
 type DAO struct {
-	DAOID            string                // Unique ID of the DAO
-	Name             string                // Name of the DAO
-	CreatorWallet    string                // Wallet of the DAO creator
-	CreatedAt        time.Time             // Time of DAO creation
-	Members          map[string]*DAOMember // Members of the DAO with roles and permissions
-	FundsVault       *DAOFundVault         // DAO's fund vault
-	VotingThreshold  int                   // Minimum number of votes required for DAO decisions
-	IsActive         bool                  // Is DAO active or deactivated
+	DAOID           string                     // Unique ID of the DAO
+	Name            string                     // Name of the DAO
+	CreatorWallet   string                     // Wallet of the DAO creator
+	CreatedAt       time.Time                  // Time of DAO creation
+	Members         map[string]*DAOMember      // Members of the DAO with roles and permissions
+	FundsVault      *DAOFundVault              // DAO's fund vault
+	VotingThreshold int                        // Minimum number of votes required for DAO decisions
+	IsActive        bool                       // Is DAO active or deactivated
+	Proposals       map[string]*DAOProposal    // Proposals submitted to the DAO
+	ProposalVotes   map[string]map[string]bool // Votes per proposal, keyed by member wallet (true approves)
 }
 
 // DAOMember represents a member of a DAO with their role and permissions.
@@ -70,6 +72,10 @@ type DAOFundVault struct {
 	LastTransactionAt time.Time               // Timestamp of the last transaction
 	TransactionQueue  []VaultTransaction      // Queue of pending transactions
 	Admins            map[string]bool         // DAO admin addresses with access to funds
+	WindowStart       time.Time               // Start of the current 24-hour disbursement window
+	Disbursements     []VaultTransaction      // Disbursements executed from the vault
+	RequiredApprovals int                     // Admin approvals needed to release a queued disbursement
+	EmergencyUntil    time.Time               // End of an approved emergency's bypass of TransactionLimit
 }
 
 // VaultTransaction represents a transaction from the DAO vault.
@@ -90,6 +96,7 @@ type EmergencyAccessRequest struct {
 	Timestamp       time.Time
 	Status          string // Pending, Approved, Rejected
 	ApprovalConfirm []string
+	vault           *DAOFundVault // Vault the request was raised against
 }
 
 // GovernanceStake represents a user's governance staking record.
@@ -140,6 +147,7 @@ type QuadraticProposal struct {
 	YesVotes     float64            // Total quadratic tokens voted "Yes"
 	NoVotes      float64            // Total quadratic tokens voted "No"
 	VoterRecords map[string]float64 // Tracks how many tokens each user has voted
+	VoterSupport map[string]bool    // Side each voter has voted on (true for "Yes")
 	Status       string             // "Open", "Passed", "Rejected"
 }
 
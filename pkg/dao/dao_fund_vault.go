@@ -19,6 +19,7 @@ func NewDAOFundVault(daoID string, initialBalance float64, ledgerInstance *ledge
 		Syn900Verifier:   syn900Verifier,
 		TransactionLimit: 10000, // Example transaction limit
 		Admins:           make(map[string]bool),
+		RequiredApprovals: defaultRequiredApprovals,
 	}
 }
 
@@ -98,9 +99,11 @@ func (vault *DAOFundVault) ApproveTransaction(transactionID, adminAddress string
 		if transaction.TransactionID == transactionID {
 			// Add admin approval
 			transaction.ApprovedBy = append(transaction.ApprovedBy, adminAddress)
+			vault.TransactionQueue[i] = transaction
 
-			// Check if a majority approval is reached (e.g., 2 approvals)
-			if len(transaction.ApprovedBy) >= 2 {
+			// Check if a majority approval is reached (e.g., 2 approvals).
+			// Disbursements queued by the daily limit are only released by ProcessQueue.
+			if transaction.Status != vaultStatusQueued && len(transaction.ApprovedBy) >= 2 {
 				// Update transaction status
 				transaction.Status = "Approved"
 				vault.TransactionQueue[i] = transaction
@@ -130,44 +133,243 @@ func (vault *DAOFundVault) ApproveTransaction(transactionID, adminAddress string
 	return errors.New("transaction not found")
 }
 
-// EmergencyAccess triggers an emergency fund access request through Syn900.
+const (
+	disbursementWindow       = 24 * time.Hour // Length of the window the daily TransactionLimit applies to
+	defaultRequiredApprovals = 2              // Admin approvals needed to release a queued disbursement
+	vaultStatusQueued        = "Queued"       // Disbursement waiting for the limit window to reset
+	vaultStatusExecuted      = "Executed"     // Disbursement paid out of the vault
+	emergencyStatusPending   = "Pending"      // Emergency request awaiting admin confirmations
+	emergencyStatusApproved  = "Approved"     // Emergency request confirmed by a quorum of admins
+	emergencyAccessDuration  = 24 * time.Hour // How long an approved emergency bypasses TransactionLimit
+)
+
+// RequestDisbursement pays out a transaction from the vault if it fits within
+// the daily TransactionLimit. A transaction that would exceed the limit is
+// queued instead and released later by ProcessQueue.
+func (vault *DAOFundVault) RequestDisbursement(tx VaultTransaction) error {
+	vault.mutex.Lock()
+	defer vault.mutex.Unlock()
+
+	if tx.Amount <= 0 {
+		return errors.New("disbursement amount must be positive")
+	}
+	if tx.Amount > vault.Balance {
+		return errors.New("insufficient funds")
+	}
+	if tx.TransactionID == "" {
+		tx.TransactionID = generateUniqueID()
+	}
+
+	now := time.Now()
+	if tx.Timestamp.IsZero() {
+		tx.Timestamp = now
+	}
+	vault.rollWindow(now)
+
+	if !vault.withinLimit(tx.Amount, now) {
+		tx.Status = vaultStatusQueued
+		vault.TransactionQueue = append(vault.TransactionQueue, tx)
+		fmt.Printf("Disbursement %s of %.2f queued: daily limit of %.2f reached.\n", tx.TransactionID, tx.Amount, vault.TransactionLimit)
+		return nil
+	}
+
+	return vault.executeDisbursement(tx, now)
+}
+
+// ProcessQueue releases queued disbursements, oldest first, once they have the
+// required admin approvals and fit within the current window's limit. It stops
+// at the first approved disbursement that does not fit so later ones cannot
+// jump ahead of it.
+func (vault *DAOFundVault) ProcessQueue() error {
+	vault.mutex.Lock()
+	defer vault.mutex.Unlock()
+
+	now := time.Now()
+	vault.rollWindow(now)
+
+	remaining := make([]VaultTransaction, 0, len(vault.TransactionQueue))
+	blocked := false
+	for i, tx := range vault.TransactionQueue {
+		if blocked || tx.Status != vaultStatusQueued || vault.approvalCount(tx) < vault.RequiredApprovals {
+			remaining = append(remaining, tx)
+			continue
+		}
+		if !vault.withinLimit(tx.Amount, now) || tx.Amount > vault.Balance {
+			blocked = true
+			remaining = append(remaining, tx)
+			continue
+		}
+		if err := vault.executeDisbursement(tx, now); err != nil {
+			vault.TransactionQueue = append(remaining, vault.TransactionQueue[i:]...)
+			return err
+		}
+	}
+	vault.TransactionQueue = remaining
+	return nil
+}
+
+// rollWindow starts a new disbursement window once the current one has elapsed.
+func (vault *DAOFundVault) rollWindow(now time.Time) {
+	if vault.WindowStart.IsZero() || !now.Before(vault.WindowStart.Add(disbursementWindow)) {
+		vault.WindowStart = now
+	}
+}
+
+// disbursedInWindow sums the disbursements executed in the current window.
+func (vault *DAOFundVault) disbursedInWindow() float64 {
+	total := 0.0
+	for _, tx := range vault.Disbursements {
+		if !tx.Timestamp.Before(vault.WindowStart) {
+			total += tx.Amount
+		}
+	}
+	return total
+}
+
+// approvalCount counts the distinct current admins who approved the transaction.
+func (vault *DAOFundVault) approvalCount(tx VaultTransaction) int {
+	seen := make(map[string]bool)
+	for _, admin := range tx.ApprovedBy {
+		if vault.Admins[admin] {
+			seen[admin] = true
+		}
+	}
+	return len(seen)
+}
+
+// executeDisbursement records the disbursement in the ledger and pays it out.
+func (vault *DAOFundVault) executeDisbursement(tx VaultTransaction, now time.Time) error {
+	tx.Status = vaultStatusExecuted
+	tx.Timestamp = now
+
+	err := vault.Ledger.DAOLedger.RecordVaultTransaction(vault.DAOID, tx.TransactionID, ledger.TransactionRecord{
+		From:      vault.DAOID,
+		To:        tx.Recipient,
+		Amount:    tx.Amount,
+		Hash:      tx.TransactionID,
+		Status:    tx.Status,
+		Timestamp: now,
+		Action:    "VaultDisbursement",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record disbursement in ledger: %v", err)
+	}
+
+	vault.Balance -= tx.Amount
+	vault.LastTransactionAt = now
+	vault.Disbursements = append(vault.Disbursements, tx)
+
+	fmt.Printf("Disbursement %s executed. Amount: %.2f, Recipient: %s\n", tx.TransactionID, tx.Amount, tx.Recipient)
+	return nil
+}
+
+// EmergencyAccess triggers an emergency fund access request. The request must
+// still be confirmed by a quorum of admins through ApproveEmergencyRequest.
 func (vault *DAOFundVault) EmergencyAccess(requestedBy, reason string) (*EmergencyAccessRequest, error) {
+	return RaiseEmergencyRequest(vault, requestedBy, reason)
+}
+
+// RaiseEmergencyRequest opens an emergency access request against the vault
+// and records it in the ledger. The request stays Pending until a quorum of
+// the vault's admins confirm it.
+func RaiseEmergencyRequest(vault *DAOFundVault, requester, reason string) (*EmergencyAccessRequest, error) {
+	if vault == nil {
+		return nil, errors.New("vault is required")
+	}
+	if reason == "" {
+		return nil, errors.New("a reason is required for emergency access")
+	}
+
 	vault.mutex.Lock()
 	defer vault.mutex.Unlock()
 
-	// Create an emergency access request
 	request := &EmergencyAccessRequest{
 		RequestID:   GenerateUniqueID(),
-		RequestedBy: requestedBy,
+		RequestedBy: requester,
 		Reason:      reason,
 		Timestamp:   time.Now(),
-		Status:      "Pending",
+		Status:      emergencyStatusPending,
+		vault:       vault,
 	}
 
 	// Log the request in the ledger
-	err := vault.Ledger.DAOLedger.RecordEmergencyAccessRequest(request)
-	if err != nil {
+	if err := vault.Ledger.DAOLedger.RecordEmergencyAccessRequest(vault.DAOID, request.RequestID); err != nil {
 		return nil, fmt.Errorf("failed to record emergency access request: %v", err)
 	}
 
-	// Verify through Syn900 if emergency access can be granted
-	verified, err := vault.Syn900Verifier.VerifyEmergencyAccess(requestedBy, reason)
+	fmt.Printf("Emergency access request %s raised for DAO %s by %s for reason: %s\n", request.RequestID, vault.DAOID, requester, reason)
+	return request, nil
+}
+
+// ApproveEmergencyRequest adds an admin's confirmation to a pending emergency
+// request. The admin's identity is verified through Syn900. Once a majority of
+// the vault's admins have confirmed, the request is Approved and disbursements
+// bypass the daily TransactionLimit for emergencyAccessDuration.
+func ApproveEmergencyRequest(req *EmergencyAccessRequest, admin string) error {
+	if req == nil || req.vault == nil {
+		return errors.New("emergency request is not associated with a vault")
+	}
+	vault := req.vault
+
+	vault.mutex.Lock()
+	defer vault.mutex.Unlock()
+
+	if req.Status != emergencyStatusPending {
+		return fmt.Errorf("emergency request %s is %s, not pending", req.RequestID, req.Status)
+	}
+	if !vault.Admins[admin] {
+		return errors.New("only admins can approve emergency requests")
+	}
+	for _, confirmed := range req.ApprovalConfirm {
+		if confirmed == admin {
+			return errors.New("admin has already confirmed this emergency request")
+		}
+	}
+
+	// Verify the admin's identity through Syn900
+	verified, err := vault.Syn900Verifier.VerifyIdentity(admin)
 	if err != nil || !verified {
-		return nil, errors.New("emergency access denied by Syn900")
+		return errors.New("admin identity verification failed via Syn900")
 	}
 
-	// If verified, the request will be approved and the funds released
-	request.Status = "Approved"
-	request.ApprovalConfirm = append(request.ApprovalConfirm, requestedBy)
+	req.ApprovalConfirm = append(req.ApprovalConfirm, admin)
+	fmt.Printf("Emergency request %s confirmed by %s (%d/%d)\n", req.RequestID, admin, len(req.ApprovalConfirm), vault.emergencyQuorum())
 
-	// Log the approval in the ledger
-	err = vault.Ledger.DAOLedger.RecordEmergencyAccessApproval(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to record emergency access approval in ledger: %v", err)
+	if len(req.ApprovalConfirm) < vault.emergencyQuorum() {
+		return nil
 	}
 
-	fmt.Printf("Emergency access granted for DAO %s by %s for reason: %s\n", vault.DAOID, requestedBy, reason)
-	return request, nil
+	// Log the approval in the ledger before unlocking the bypass
+	if err := vault.Ledger.DAOLedger.RecordEmergencyAccessApproval(vault.DAOID, req.RequestID); err != nil {
+		return fmt.Errorf("failed to record emergency access approval in ledger: %v", err)
+	}
+
+	req.Status = emergencyStatusApproved
+	vault.EmergencyUntil = time.Now().Add(emergencyAccessDuration)
+
+	fmt.Printf("Emergency access granted for DAO %s until %s\n", vault.DAOID, vault.EmergencyUntil.Format(time.RFC3339))
+	return nil
+}
+
+// emergencyQuorum returns the number of admin confirmations an emergency
+// request needs: a strict majority of the vault's admins.
+func (vault *DAOFundVault) emergencyQuorum() int {
+	admins := 0
+	for _, active := range vault.Admins {
+		if active {
+			admins++
+		}
+	}
+	return admins/2 + 1
+}
+
+// withinLimit reports whether a disbursement of amount fits the current
+// window's TransactionLimit. An approved emergency lifts the limit.
+func (vault *DAOFundVault) withinLimit(amount float64, now time.Time) bool {
+	if now.Before(vault.EmergencyUntil) {
+		return true
+	}
+	return vault.disbursedInWindow()+amount <= vault.TransactionLimit
 }
 
 // RejectTransaction rejects a pending transaction.
@@ -1,6 +1,8 @@
 package dao
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync"
@@ -178,11 +180,22 @@ func (dm *DAOManagement) SubmitProposal(daoID string, proposal string, submitted
 	proposalID := generateUniqueID()
 
 	// Record the proposal submission in the ledger
-	err := dm.Ledger.DAOLedger.RecordDAOProposal(daoID, proposalID, proposal, submittedBy)
+	err := dm.Ledger.DAOLedger.RecordProposalCreation(daoID, proposalID, submittedBy, proposal)
 	if err != nil {
 		return "", fmt.Errorf("failed to record proposal in ledger: %v", err)
 	}
 
+	if dao.Proposals == nil {
+		dao.Proposals = make(map[string]*DAOProposal)
+	}
+	dao.Proposals[proposalID] = &DAOProposal{
+		ProposalID:   proposalID,
+		Description:  proposal,
+		Author:       submittedBy,
+		CreationTime: time.Now(),
+		Status:       "Pending",
+	}
+
 	fmt.Printf("Proposal %s submitted to DAO %s by %s\n", proposalID, daoID, submittedBy)
 	return proposalID, nil
 }
@@ -213,6 +226,112 @@ func (dm *DAOManagement) VoteOnProposal(daoID string, proposalID string, voterWa
 	return nil
 }
 
+// CastVote records an authorized member's vote on a pending proposal, weighted
+// by the member's voting power. Each member can vote once per proposal, and the
+// proposal is finalized as soon as the DAO's voting threshold is reached.
+func (dm *DAOManagement) CastVote(daoID, proposalID, memberWallet string, approve bool) error {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	dao, exists := dm.DAOs[daoID]
+	if !exists {
+		return errors.New("DAO not found")
+	}
+	if !dao.IsActive {
+		return errors.New("DAO is not active")
+	}
+
+	member, exists := dao.Members[memberWallet]
+	if !exists || !member.IsAuthorized {
+		return errors.New("unauthorized voter")
+	}
+	if member.VotingPower <= 0 {
+		return errors.New("member has no voting power")
+	}
+
+	proposal, exists := dao.Proposals[proposalID]
+	if !exists {
+		return errors.New("proposal not found")
+	}
+	if proposal.Status != "Pending" {
+		return fmt.Errorf("proposal is already %s", proposal.Status)
+	}
+	if _, voted := dao.ProposalVotes[proposalID][memberWallet]; voted {
+		return errors.New("member has already voted on this proposal")
+	}
+
+	// Record the vote in the ledger before counting it
+	err := dm.Ledger.DAOLedger.RecordWeightedProposalVote(daoID, proposalID, memberWallet, approve, member.VotingPower)
+	if err != nil {
+		return fmt.Errorf("failed to record vote in ledger: %v", err)
+	}
+
+	if dao.ProposalVotes == nil {
+		dao.ProposalVotes = make(map[string]map[string]bool)
+	}
+	if dao.ProposalVotes[proposalID] == nil {
+		dao.ProposalVotes[proposalID] = make(map[string]bool)
+	}
+	dao.ProposalVotes[proposalID][memberWallet] = approve
+
+	if approve {
+		proposal.ApproveCount += member.VotingPower
+	} else {
+		proposal.RejectCount += member.VotingPower
+	}
+	proposal.VoteCount += member.VotingPower
+
+	fmt.Printf("Member %s voted on proposal %s in DAO %s with weight %d\n", memberWallet, proposalID, daoID, member.VotingPower)
+
+	if proposal.VoteCount >= dao.VotingThreshold {
+		return dm.finalizeProposal(dao, proposal)
+	}
+	return nil
+}
+
+// FinalizeProposal settles a pending proposal once the DAO's voting threshold
+// has been met. It is approved if approvals outweigh rejections.
+func (dm *DAOManagement) FinalizeProposal(daoID, proposalID string) error {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	dao, exists := dm.DAOs[daoID]
+	if !exists {
+		return errors.New("DAO not found")
+	}
+
+	proposal, exists := dao.Proposals[proposalID]
+	if !exists {
+		return errors.New("proposal not found")
+	}
+	if proposal.Status != "Pending" {
+		return fmt.Errorf("proposal is already %s", proposal.Status)
+	}
+	if proposal.VoteCount < dao.VotingThreshold {
+		return fmt.Errorf("voting threshold not met: %d of %d votes", proposal.VoteCount, dao.VotingThreshold)
+	}
+
+	return dm.finalizeProposal(dao, proposal)
+}
+
+// finalizeProposal sets the proposal's outcome and records it in the ledger.
+// The caller must hold dm.mutex.
+func (dm *DAOManagement) finalizeProposal(dao *DAO, proposal *DAOProposal) error {
+	result := "Rejected"
+	if proposal.ApproveCount > proposal.RejectCount {
+		result = "Approved"
+	}
+
+	err := dm.Ledger.DAOLedger.RecordProposalFinalization(dao.DAOID, proposal.ProposalID, result)
+	if err != nil {
+		return fmt.Errorf("failed to record proposal finalization in ledger: %v", err)
+	}
+	proposal.Status = result
+
+	fmt.Printf("Proposal %s in DAO %s finalized as %s\n", proposal.ProposalID, dao.DAOID, result)
+	return nil
+}
+
 // DeactivateDAO deactivates the DAO, freezing its functions.
 func (dm *DAOManagement) DeactivateDAO(daoID string, requesterWallet string) error {
 	dm.mutex.Lock()
@@ -253,3 +372,10 @@ func (dm *DAOManagement) ViewDAO(daoID string) (*DAO, error) {
 
 	return dao, nil
 }
+
+// generateUniqueID creates a cryptographically secure unique ID
+func generateUniqueID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
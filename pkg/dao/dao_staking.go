@@ -51,8 +51,10 @@ func (sm *StakingManager) InitializeGovernanceStaking(daoID string, minStakeAmou
 	return stakingSystem, nil
 }
 
-// StakeTokensForGovernance allows a user to stake tokens for governance in the DAO.
-func (sm *StakingManager) StakeTokensForGovernance(daoID, stakerWallet string, amount float64) error {
+// Stake locks tokens for governance in the DAO. The stake must meet the
+// system's MinStakeAmount and cannot be withdrawn until StakingDuration has
+// elapsed; the staker's voting power is derived linearly from the amount.
+func (sm *StakingManager) Stake(daoID, stakerWallet string, amount float64) error {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
@@ -64,40 +66,45 @@ func (sm *StakingManager) StakeTokensForGovernance(daoID, stakerWallet string, a
 
 	// Ensure the amount to stake is above the minimum threshold
 	if amount < stakingSystem.MinStakeAmount {
-		return errors.New("staking amount is below the minimum required for governance participation")
+		return fmt.Errorf("staking amount %f is below the minimum of %f required for governance participation", amount, stakingSystem.MinStakeAmount)
 	}
 
-	// Create or update the staking record for the user
+	// Only one active stake is allowed per user
 	stakeRecord, exists := stakingSystem.StakingRecords[stakerWallet]
 	if exists && stakeRecord.IsActive {
 		return errors.New("user already has an active stake for governance")
 	}
 
-	votingPower := sm.calculateVotingPower(amount, stakingSystem.TotalStakedTokens)
+	// Record the staking transaction in the ledger before updating local state
+	if err := sm.Ledger.DAOLedger.RecordStakeTransaction(daoID, stakerWallet, amount); err != nil {
+		return fmt.Errorf("failed to record staking transaction in ledger: %v", err)
+	}
 
-	// Create a new staking record
-	stakeRecord = &GovernanceStake{
+	// Create a new staking record and add it to the staking system
+	stakingSystem.StakingRecords[stakerWallet] = &GovernanceStake{
 		StakerWallet:   stakerWallet,
 		Amount:         amount,
-		VotingPower:    votingPower,
+		VotingPower:    sm.calculateVotingPower(amount),
 		StakeTimestamp: time.Now(),
 		IsActive:       true,
 	}
-
-	// Add the record to the staking system
-	stakingSystem.StakingRecords[stakerWallet] = stakeRecord
 	stakingSystem.TotalStakedTokens += amount
 
-	// Record the staking transaction in the ledger
-	err := sm.Ledger.DAOLedger.RecordStakeTransaction(daoID, stakerWallet, amount, time.Now())
-	if err != nil {
-		return fmt.Errorf("failed to record staking transaction in ledger: %v", err)
-	}
-
 	fmt.Printf("User %s successfully staked %f tokens for governance in DAO %s\n", stakerWallet, amount, daoID)
 	return nil
 }
 
+// Unstake withdraws a user's governance stake once the lock-in period has elapsed.
+func (sm *StakingManager) Unstake(daoID, stakerWallet string) error {
+	_, err := sm.UnstakeTokensForGovernance(daoID, stakerWallet)
+	return err
+}
+
+// StakeTokensForGovernance allows a user to stake tokens for governance in the DAO.
+func (sm *StakingManager) StakeTokensForGovernance(daoID, stakerWallet string, amount float64) error {
+	return sm.Stake(daoID, stakerWallet, amount)
+}
+
 // UnstakeTokensForGovernance allows a user to withdraw their staked tokens after the lock-in period.
 func (sm *StakingManager) UnstakeTokensForGovernance(daoID, stakerWallet string) (float64, error) {
 	sm.mutex.Lock()
@@ -116,31 +123,30 @@ func (sm *StakingManager) UnstakeTokensForGovernance(daoID, stakerWallet string)
 	}
 
 	// Check if the lock-in period has expired
-	if time.Now().Before(stakeRecord.StakeTimestamp.Add(stakingSystem.StakingDuration)) {
-		return 0, errors.New("staked tokens are still locked and cannot be unstaked")
+	unlockTime := stakeRecord.StakeTimestamp.Add(stakingSystem.StakingDuration)
+	if time.Now().Before(unlockTime) {
+		return 0, fmt.Errorf("staked tokens are locked until %s and cannot be unstaked", unlockTime.Format(time.RFC3339))
 	}
 
-	// Remove the stake and update the total staked tokens
-	unstakeAmount := stakeRecord.Amount
-	stakeRecord.IsActive = false
-	stakingSystem.TotalStakedTokens -= unstakeAmount
-
 	// Record the unstaking transaction in the ledger
-	err := sm.Ledger.DAOLedger.RecordUnstakeTransaction(daoID, stakerWallet, unstakeAmount, time.Now())
-	if err != nil {
+	unstakeAmount := stakeRecord.Amount
+	if err := sm.Ledger.DAOLedger.RecordUnstakeTransaction(daoID, stakerWallet, unstakeAmount); err != nil {
 		return 0, fmt.Errorf("failed to record unstaking transaction in ledger: %v", err)
 	}
 
+	// Deactivate the stake and update the total staked tokens
+	stakeRecord.IsActive = false
+	stakeRecord.VotingPower = 0
+	stakingSystem.TotalStakedTokens -= unstakeAmount
+
 	fmt.Printf("User %s successfully unstaked %f tokens from governance in DAO %s\n", stakerWallet, unstakeAmount, daoID)
 	return unstakeAmount, nil
 }
 
-// Calculate the voting power based on the amount staked.
-func (sm *StakingManager) calculateVotingPower(stakedAmount, totalStaked float64) float64 {
-	if totalStaked == 0 {
-		return stakedAmount
-	}
-	return stakedAmount / totalStaked * 100 // Voting power as a percentage
+// calculateVotingPower derives voting power from the amount staked. Power is
+// linear in the stake so it does not depend on when other members staked.
+func (sm *StakingManager) calculateVotingPower(stakedAmount float64) float64 {
+	return stakedAmount
 }
 
 // GetVotingPower retrieves the voting power of a specific user in a DAO.
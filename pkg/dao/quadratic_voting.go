@@ -129,6 +129,74 @@ func (qv *QuadraticVotingSystem) VoteOnQuadraticProposal(voterWallet, proposalID
 	return nil
 }
 
+// Vote spends tokens on a proposal and credits the voter's side with votes
+// equal to the square root of the tokens spent. A voter may vote again on the
+// same side; votes are always sqrt of the voter's cumulative tokens, so each
+// additional vote costs quadratically more.
+func (qv *QuadraticVotingSystem) Vote(proposalID, voter string, tokens float64, support bool) error {
+	qv.mutex.Lock()
+	defer qv.mutex.Unlock()
+
+	proposal, exists := qv.Proposals[proposalID]
+	if !exists {
+		return errors.New("proposal not found")
+	}
+	if time.Now().After(proposal.Deadline) {
+		return errors.New("voting period for this proposal has ended")
+	}
+	if proposal.Status != "Open" {
+		return errors.New("voting on this proposal is closed")
+	}
+	if tokens <= 0 {
+		return errors.New("token amount must be positive")
+	}
+
+	if proposal.VoterRecords == nil {
+		proposal.VoterRecords = make(map[string]float64)
+	}
+	if proposal.VoterSupport == nil {
+		proposal.VoterSupport = make(map[string]bool)
+	}
+	previousTokens, voted := proposal.VoterRecords[voter]
+	if voted && proposal.VoterSupport[voter] != support {
+		return errors.New("voter has already voted on the other side of this proposal")
+	}
+
+	if !qv.Syn800Token.HasSufficientBalance(voter, tokens) {
+		return fmt.Errorf("insufficient token balance for wallet %s", voter)
+	}
+	if err := qv.Syn800Token.DeductTokens(voter, tokens); err != nil {
+		return fmt.Errorf("failed to deduct tokens from wallet %s: %v", voter, err)
+	}
+
+	// Credit only the votes bought by the new tokens.
+	totalTokens := previousTokens + tokens
+	votes := math.Sqrt(totalTokens) - math.Sqrt(previousTokens)
+	if support {
+		proposal.YesVotes += votes
+	} else {
+		proposal.NoVotes += votes
+	}
+	proposal.TotalVotes += votes
+	proposal.VoterRecords[voter] = totalTokens
+	proposal.VoterSupport[voter] = support
+
+	fmt.Printf("User %s spent %f tokens on proposal %s for %f votes (support: %t)\n", voter, tokens, proposalID, votes, support)
+	return nil
+}
+
+// Tally reports whether the proposal currently has more Yes votes than No votes.
+func (qv *QuadraticVotingSystem) Tally(proposalID string) (passed bool) {
+	qv.mutex.Lock()
+	defer qv.mutex.Unlock()
+
+	proposal, exists := qv.Proposals[proposalID]
+	if !exists {
+		return false
+	}
+	return proposal.YesVotes > proposal.NoVotes
+}
+
 // TallyQuadraticVotes checks if a quadratic proposal has met the deadline and calculates the final result.
 func (qv *QuadraticVotingSystem) TallyQuadraticVotes(proposalID string) (*QuadraticProposal, error) {
 	qv.mutex.Lock()
@@ -18,6 +18,9 @@ type InsurancePolicy struct {
 	ExpiryDate      time.Time     // Policy expiry date
 	Status          string        // Policy status ("Active", "Expired", "Claimed")
 	EncryptedHolder string        // Encrypted policyholder address for privacy
+	Frozen          bool          // Whether claims against the policy are suspended
+	ClaimFee        float64       // Processing fee deducted from each approved claim
+	ClaimedAmount   float64       // Total of approved claims paid against the coverage
 }
 
 // InsuranceClaim represents a claim made on an insurance policy
@@ -27,6 +30,7 @@ type InsuranceClaim struct {
 	ClaimAmount   float64   // The amount being claimed
 	ClaimDate     time.Time // The date the claim was made
 	ClaimStatus   string    // Claim status ("Pending", "Approved", "Rejected")
+	PayoutAmount  float64   // Amount paid to the insured after the claim fee
 	EncryptedData string    // Encrypted claim data for security
 }
 
@@ -104,17 +108,20 @@ type OracleManager struct {
 
 // Loan represents a loan given by a lender to a borrower
 type Loan struct {
-	LoanID         string    // Unique loan identifier
-	Lender         string    // Lender's wallet address
-	Borrower       string    // Borrower's wallet address
-	Amount         float64   // Loan amount
-	Collateral     float64   // Collateral deposited by the borrower
-	InterestRate   float64   // Interest rate applied to the loan
+	LoanID         string        // Unique loan identifier
+	Lender         string        // Lender's wallet address
+	Borrower       string        // Borrower's wallet address
+	Amount         float64       // Loan amount
+	Collateral     float64       // Collateral deposited by the borrower
+	InterestRate   float64       // Interest rate applied to the loan
 	Duration       time.Duration // Loan duration
-	StartDate      time.Time // When the loan started
-	ExpiryDate     time.Time // Loan expiry date
-	Status         string    // Loan status ("Active", "Repaid", "Defaulted")
-	EncryptedData  string    // Encrypted loan data for security
+	StartDate      time.Time     // When the loan started
+	ExpiryDate     time.Time     // Loan expiry date
+	Status         string        // Loan status ("Active", "Repaid", "Defaulted")
+	AmountRepaid   float64       // Total repaid towards principal, interest and penalties
+	PenaltyFees    float64       // Late payment penalties added to the amount owed
+	PenaltyCharged bool          // Whether the one-off late penalty has been charged
+	EncryptedData  string        // Encrypted loan data for security
 }
 
 // LendingPool represents a pool of assets available for lending
@@ -102,9 +102,15 @@ func (im *InsuranceManager) CreatePolicy(holder string, insuredAmount, premium f
 }
 
 // ClaimPolicy allows a user to make a claim on an active insurance policy.
-// Validates the policy and claim amount, encrypts sensitive data, and logs the claim in the ledger.
 func (im *InsuranceManager) ClaimPolicy(policyID string, claimAmount float64) (*InsuranceClaim, error) {
-	log.Printf("[INFO] Processing claim request. Policy ID: %s, Claim Amount: %.2f", policyID, claimAmount)
+	return im.FileClaim(policyID, claimAmount)
+}
+
+// FileClaim files a pending claim against an insurance policy.
+// Claims against frozen, inactive or expired policies are rejected, as are
+// claims for more than the policy's remaining coverage.
+func (im *InsuranceManager) FileClaim(policyID string, amount float64) (*InsuranceClaim, error) {
+	log.Printf("[INFO] Processing claim request. Policy ID: %s, Claim Amount: %.2f", policyID, amount)
 
 	// Step 1: Lock the manager to ensure thread safety.
 	im.mu.Lock()
@@ -114,7 +120,7 @@ func (im *InsuranceManager) ClaimPolicy(policyID string, claimAmount float64) (*
 	if policyID == "" {
 		return nil, fmt.Errorf("policy ID cannot be empty")
 	}
-	if claimAmount <= 0 {
+	if amount <= 0 {
 		return nil, fmt.Errorf("claim amount must be greater than zero")
 	}
 
@@ -126,24 +132,20 @@ func (im *InsuranceManager) ClaimPolicy(policyID string, claimAmount float64) (*
 	}
 
 	// Step 4: Validate policy status.
-	if policy.Status != "Active" {
-		log.Printf("[ERROR] Policy %s is not active. Current status: %s", policyID, policy.Status)
-		return nil, fmt.Errorf("policy %s is not active", policyID)
-	}
-	if time.Now().After(policy.ExpiryDate) {
-		log.Printf("[ERROR] Policy %s is expired. Expiry Date: %v", policyID, policy.ExpiryDate)
-		return nil, fmt.Errorf("policy %s is expired", policyID)
+	if err := claimablePolicy(policy, time.Now()); err != nil {
+		log.Printf("[ERROR] %v", err)
+		return nil, err
 	}
 
-	// Step 5: Validate claim amount.
-	if claimAmount > policy.InsuredAmount {
-		log.Printf("[ERROR] Claim amount exceeds insured amount for policy %s: Claim=%.2f, Insured=%.2f", policyID, claimAmount, policy.InsuredAmount)
-		return nil, fmt.Errorf("claim amount exceeds insured amount for policy %s", policyID)
+	// Step 5: Validate claim amount against the remaining coverage.
+	if remaining := policy.InsuredAmount - policy.ClaimedAmount; amount > remaining {
+		log.Printf("[ERROR] Claim amount exceeds remaining coverage for policy %s: Claim=%.2f, Remaining=%.2f", policyID, amount, remaining)
+		return nil, fmt.Errorf("claim amount %.2f exceeds remaining coverage %.2f for policy %s", amount, remaining, policyID)
 	}
 
 	// Step 6: Generate unique claim ID and encrypt claim data.
 	claimID := generateUniqueID()
-	claimData := fmt.Sprintf("PolicyID: %s, ClaimAmount: %.2f", policyID, claimAmount)
+	claimData := fmt.Sprintf("PolicyID: %s, ClaimAmount: %.2f", policyID, amount)
 	encryptedClaimData, err := im.EncryptionService.EncryptData("AES", []byte(claimData), common.EncryptionKey)
 	if err != nil {
 		log.Printf("[ERROR] Failed to encrypt claim data for policy %s: %v", policyID, err)
@@ -154,7 +156,7 @@ func (im *InsuranceManager) ClaimPolicy(policyID string, claimAmount float64) (*
 	claim := &InsuranceClaim{
 		ClaimID:       claimID,
 		PolicyID:      policyID,
-		ClaimAmount:   claimAmount,
+		ClaimAmount:   amount,
 		ClaimDate:     time.Now(),
 		ClaimStatus:   "Pending",
 		EncryptedData: string(encryptedClaimData),
@@ -163,7 +165,7 @@ func (im *InsuranceManager) ClaimPolicy(policyID string, claimAmount float64) (*
 
 	// Step 8: Record claim in the ledger.
 	log.Printf("[INFO] Recording claim submission in ledger. Claim ID: %s", claimID)
-	err = im.Ledger.DeFiLedger.RecordClaimSubmission(claimID, claimAmount)
+	err = im.Ledger.DeFiLedger.RecordClaimSubmission(claimID, policyID, amount)
 	if err != nil {
 		delete(im.Claims, claimID) // Rollback claim creation in case of failure.
 		log.Printf("[ERROR] Failed to record claim in ledger for claim %s: %v", claimID, err)
@@ -176,56 +178,91 @@ func (im *InsuranceManager) ClaimPolicy(policyID string, claimAmount float64) (*
 }
 
 
-// ApproveClaim approves a pending insurance claim and updates the associated policy status.
-// Validates the claim and policy, updates their statuses, and logs the approval in the ledger.
+// ApproveClaim approves a pending insurance claim and pays it out.
 func (im *InsuranceManager) ApproveClaim(claimID string) error {
+	return im.ProcessClaim(claimID, true)
+}
+
+// ProcessClaim approves or rejects a pending claim. On approval the policy's
+// ClaimFee is deducted from the claim amount, the remainder is paid to the
+// policyholder and the payout is recorded in the ledger. A policy whose
+// coverage is used up is marked Claimed.
+func (im *InsuranceManager) ProcessClaim(claimID string, approve bool) error {
+	if !approve {
+		return im.RejectClaim(claimID)
+	}
 	log.Printf("[INFO] Approving claim. Claim ID: %s", claimID)
 
 	// Step 1: Lock the manager to ensure thread safety.
 	im.mu.Lock()
 	defer im.mu.Unlock()
 
-	// Step 2: Validate claim ID.
-	if claimID == "" {
-		return fmt.Errorf("claim ID cannot be empty")
-	}
-
-	// Step 3: Retrieve the claim.
+	// Step 2: Retrieve and validate the claim.
 	claim, exists := im.Claims[claimID]
 	if !exists {
 		log.Printf("[ERROR] Claim not found: %s", claimID)
 		return fmt.Errorf("claim %s not found", claimID)
 	}
-
-	// Step 4: Validate claim status.
 	if claim.ClaimStatus != "Pending" {
 		log.Printf("[ERROR] Claim %s is not in a pending state. Current status: %s", claimID, claim.ClaimStatus)
 		return fmt.Errorf("claim %s is not in a pending state", claimID)
 	}
 
-	// Step 5: Retrieve the associated policy.
+	// Step 3: Retrieve and re-validate the associated policy.
 	policy, exists := im.Policies[claim.PolicyID]
 	if !exists {
 		log.Printf("[ERROR] Policy not found for claim %s: %s", claimID, claim.PolicyID)
 		return fmt.Errorf("policy %s not found for claim %s", claim.PolicyID, claimID)
 	}
+	if policy.Frozen {
+		return fmt.Errorf("policy %s is frozen", policy.PolicyID)
+	}
+	if remaining := policy.InsuredAmount - policy.ClaimedAmount; claim.ClaimAmount > remaining {
+		return fmt.Errorf("claim amount %.2f exceeds remaining coverage %.2f for policy %s", claim.ClaimAmount, remaining, policy.PolicyID)
+	}
 
-	// Step 6: Approve the claim and update policy status.
-	log.Printf("[INFO] Approving claim and updating policy. Claim ID: %s, Policy ID: %s", claimID, policy.PolicyID)
-	claim.ClaimStatus = "Approved"
-	policy.Status = "Claimed"
+	// Step 4: Deduct the claim fee.
+	if policy.ClaimFee >= claim.ClaimAmount {
+		return fmt.Errorf("claim fee %.2f leaves nothing to pay out on claim %s", policy.ClaimFee, claimID)
+	}
+	payout := claim.ClaimAmount - policy.ClaimFee
 
-	// Step 7: Record the claim approval in the ledger.
-	err := im.Ledger.DeFiLedger.RecordClaimApproval(claimID)
-	if err != nil {
-		log.Printf("[ERROR] Failed to record claim approval in ledger for claim %s: %v", claimID, err)
-		claim.ClaimStatus = "Pending" // Rollback claim status.
-		policy.Status = "Active"      // Rollback policy status.
-		return fmt.Errorf("failed to record claim approval in ledger: %w", err)
+	// Step 5: Credit the policyholder and record the payout in the ledger.
+	if err := im.Ledger.AccountsWalletLedger.CreditBalance(policy.Holder, payout); err != nil {
+		log.Printf("[ERROR] Failed to credit payout for claim %s to %s: %v", claimID, policy.Holder, err)
+		return fmt.Errorf("failed to credit claim payout: %w", err)
+	}
+	if err := im.Ledger.DeFiLedger.RecordClaimPayout(claimID, policy.PolicyID, policy.Holder, payout, policy.ClaimFee); err != nil {
+		if rollbackErr := im.Ledger.AccountsWalletLedger.DebitBalance(policy.Holder, payout); rollbackErr != nil {
+			log.Printf("[ERROR] Failed to roll back payout credit for claim %s: %v", claimID, rollbackErr)
+		}
+		log.Printf("[ERROR] Failed to record payout in ledger for claim %s: %v", claimID, err)
+		return fmt.Errorf("failed to record claim payout in ledger: %w", err)
+	}
+
+	// Step 6: Update the claim and policy.
+	claim.ClaimStatus = "Approved"
+	claim.PayoutAmount = payout
+	policy.ClaimedAmount += claim.ClaimAmount
+	if policy.ClaimedAmount >= policy.InsuredAmount {
+		policy.Status = "Claimed"
 	}
 
-	// Step 8: Log success.
-	log.Printf("[SUCCESS] Claim approved successfully. Claim ID: %s, Policy ID: %s", claimID, policy.PolicyID)
+	log.Printf("[SUCCESS] Claim approved. Claim ID: %s, Payout: %.2f to %s, Fee: %.2f", claimID, payout, policy.Holder, policy.ClaimFee)
+	return nil
+}
+
+// claimablePolicy returns an error if claims cannot be filed against the policy at now.
+func claimablePolicy(policy *InsurancePolicy, now time.Time) error {
+	if policy.Frozen {
+		return fmt.Errorf("policy %s is frozen", policy.PolicyID)
+	}
+	if policy.Status != "Active" {
+		return fmt.Errorf("policy %s is not active", policy.PolicyID)
+	}
+	if now.After(policy.ExpiryDate) {
+		return fmt.Errorf("policy %s is expired", policy.PolicyID)
+	}
 	return nil
 }
 
@@ -233,6 +233,73 @@ func SwapAssetsWithinPool(poolID, tokenIn string, amountIn float64, lpm *common.
 
 
 
+const (
+	SwapDirectionAToB = "AtoB" // Swap token A into the pool for token B
+	SwapDirectionBToA = "BtoA" // Swap token B into the pool for token A
+)
+
+// Swap trades amountIn against the pool on a constant-product (x*y=k) curve.
+// The pool's reserves are derived from TotalBalance (x+y) and TokenRatio, which
+// follows DeFiLedger.SwapTokens and GetTokenPrice in pricing token A in token
+// B: the B received per A, or y/x.
+// FeeRate is deducted from amountIn before pricing and the fee stays in the
+// pool. The swap is rejected when swaps are paused or when amountOut would be
+// below minOut. On success TokenRatio and TotalBalance reflect the new reserves.
+func Swap(pool *ledger.LiquidityPool, amountIn float64, direction string, minOut float64) (float64, float64, error) {
+	// Step 1: Validate inputs and pool state.
+	if pool == nil {
+		return 0, 0, errors.New("liquidity pool cannot be nil")
+	}
+	if amountIn <= 0 {
+		return 0, 0, errors.New("amountIn must be greater than zero")
+	}
+	if pool.IsSwapsPaused {
+		return 0, 0, fmt.Errorf("swaps are paused for liquidity pool %s", pool.PoolID)
+	}
+	if pool.FeeRate < 0 || pool.FeeRate >= 1 {
+		return 0, 0, fmt.Errorf("invalid fee rate %.4f for liquidity pool %s", pool.FeeRate, pool.PoolID)
+	}
+	if pool.TotalBalance <= 0 || pool.TokenRatio <= 0 {
+		return 0, 0, fmt.Errorf("liquidity pool %s has no liquidity", pool.PoolID)
+	}
+
+	// Step 2: Derive the reserves from the pool's balance and token ratio.
+	reserveA := pool.TotalBalance / (1 + pool.TokenRatio)
+	reserveB := pool.TotalBalance - reserveA
+
+	reserveIn, reserveOut := reserveA, reserveB
+	switch direction {
+	case SwapDirectionAToB:
+	case SwapDirectionBToA:
+		reserveIn, reserveOut = reserveB, reserveA
+	default:
+		return 0, 0, fmt.Errorf("invalid swap direction: %s", direction)
+	}
+
+	// Step 3: Price the swap on the constant-product curve after the fee.
+	fee := amountIn * pool.FeeRate
+	netIn := amountIn - fee
+	amountOut := reserveOut * netIn / (reserveIn + netIn)
+	if amountOut < minOut {
+		return 0, 0, fmt.Errorf("slippage exceeded: amountOut %.6f is below minimum %.6f", amountOut, minOut)
+	}
+
+	// Step 4: Update the reserves; the fee remains in the pool.
+	reserveIn += amountIn
+	reserveOut -= amountOut
+	if direction == SwapDirectionBToA {
+		reserveA, reserveB = reserveOut, reserveIn
+	} else {
+		reserveA, reserveB = reserveIn, reserveOut
+	}
+	pool.TotalBalance = reserveA + reserveB
+	pool.TokenRatio = reserveB / reserveA
+
+	log.Printf("[SUCCESS] Swap executed in pool %s. Direction: %s, AmountIn: %.2f, AmountOut: %.2f, Fee: %.2f", pool.PoolID, direction, amountIn, amountOut, fee)
+	return amountOut, fee, nil
+}
+
+
 // LiquidityPoolTrackBalance tracks the token balances of a liquidity pool.
 // Validates inputs, retrieves balances from the ledger, and logs the result.
 func LiquidityPoolTrackBalance(poolID string, ledgerInstance *ledger.Ledger) (float64, float64, error) {
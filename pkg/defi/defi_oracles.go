@@ -3,6 +3,10 @@ package defi
 import (
 	"fmt"
 	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"synnergy_network/pkg/common"
 	"synnergy_network/pkg/ledger"
@@ -179,6 +183,93 @@ func (om *OracleManager) MonitorOracleStatus(oracleID string) (string, error) {
 }
 
 
+const (
+	minFeedSubmissions  = 3                // Minimum usable submissions needed to aggregate a feed
+	feedOutlierStdDevs  = 2.0              // Values further than this many standard deviations from the mean are discarded
+	feedStalenessWindow = 10 * time.Minute // Submissions older than this no longer count towards a feed
+)
+
+// AggregateFeed returns the aggregated value of a data feed from its verified
+// numeric submissions. Only the latest submission from each handler node is
+// used, so one oracle cannot outvote the others by submitting repeatedly.
+// Submissions older than feedStalenessWindow are marked unverified and
+// ignored. Values more than feedOutlierStdDevs standard deviations from the
+// mean are discarded and the median of the remainder is returned. It errors
+// if fewer than minFeedSubmissions values remain.
+func (om *OracleManager) AggregateFeed(dataFeedID string) (float64, error) {
+	if dataFeedID == "" {
+		return 0, fmt.Errorf("dataFeedID cannot be empty")
+	}
+
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	// Step 1: Collect the latest fresh, verified submission from each handler node
+	now := time.Now()
+	latest := make(map[string]*OracleData)
+	for _, data := range om.OracleSubmissions {
+		if data.DataFeedID != dataFeedID || !data.Verified {
+			continue
+		}
+		if now.Sub(data.Timestamp) > feedStalenessWindow {
+			data.Verified = false
+			log.Printf("[WARNING] Oracle submission %s for feed %s is stale and has been marked unverified", data.OracleID, dataFeedID)
+			continue
+		}
+		if current, ok := latest[data.HandlerNode]; !ok || data.Timestamp.After(current.Timestamp) {
+			latest[data.HandlerNode] = data
+		}
+	}
+
+	// Step 2: Parse numeric payloads
+	values := make([]float64, 0, len(latest))
+	for _, data := range latest {
+		value, err := strconv.ParseFloat(strings.TrimSpace(data.DataPayload), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			log.Printf("[WARNING] Ignoring non-numeric payload from oracle submission %s for feed %s", data.OracleID, dataFeedID)
+			continue
+		}
+		values = append(values, value)
+	}
+	if len(values) < minFeedSubmissions {
+		return 0, fmt.Errorf("feed %s has %d usable submissions, need at least %d", dataFeedID, len(values), minFeedSubmissions)
+	}
+
+	// Step 3: Discard outliers
+	mean := 0.0
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(values)))
+
+	kept := values[:0]
+	for _, value := range values {
+		if math.Abs(value-mean) <= feedOutlierStdDevs*stdDev {
+			kept = append(kept, value)
+		}
+	}
+	if len(kept) < minFeedSubmissions {
+		return 0, fmt.Errorf("feed %s has %d submissions after outlier rejection, need at least %d", dataFeedID, len(kept), minFeedSubmissions)
+	}
+
+	// Step 4: Take the median of the remaining values
+	sort.Float64s(kept)
+	mid := len(kept) / 2
+	median := kept[mid]
+	if len(kept)%2 == 0 {
+		median = (kept[mid-1] + kept[mid]) / 2
+	}
+
+	log.Printf("[INFO] Feed %s aggregated to %f from %d of %d submissions", dataFeedID, median, len(kept), len(values))
+	return median, nil
+}
+
+
 // removePendingSubmission removes an oracle from the pending list
 // Ensures efficient removal of a specific oracle from the pending queue.
 func removePendingSubmission(pendingList []*OracleData, oracleID string) []*OracleData {
@@ -174,15 +174,32 @@ func (lm *LendingManager) RequestLoan(poolID, borrower string, amount, collatera
 }
 
 
-// RepayLoan allows the borrower to repay their loan and close it.
-func (lm *LendingManager) RepayLoan(loanID string) error {
-    log.Printf("[INFO] Processing loan repayment. LoanID: %s", loanID)
+const (
+    loanStatusActive       = "Active"    // Loan is outstanding
+    loanStatusRepaid       = "Repaid"    // Loan has been repaid in full
+    loanStatusDefaulted    = "Defaulted" // Loan expired unpaid and its collateral was seized
+    latePaymentPenaltyRate = 0.05        // One-off penalty charged on the outstanding amount once a loan is late
+)
+
+// RepayLoan applies a repayment towards a loan's principal, accrued interest and
+// any late penalty. The first payment made after the loan's expiry incurs a
+// penalty of latePaymentPenaltyRate on the outstanding amount; later payments
+// are not penalised again. When the loan is
+// paid in full it is marked Repaid and its collateral is returned. It returns
+// the amount still owed.
+func (lm *LendingManager) RepayLoan(loanID string, amount float64) (float64, error) {
+    log.Printf("[INFO] Processing loan repayment. LoanID: %s, Amount: %.2f", loanID, amount)
 
     // Step 1: Input Validation
     if loanID == "" {
         err := fmt.Errorf("loanID cannot be empty")
         log.Printf("[ERROR] %v", err)
-        return err
+        return 0, err
+    }
+    if amount <= 0 {
+        err := fmt.Errorf("repayment amount must be greater than zero")
+        log.Printf("[ERROR] %v", err)
+        return 0, err
     }
 
     // Step 2: Lock for Thread Safety
@@ -194,13 +211,13 @@ func (lm *LendingManager) RepayLoan(loanID string) error {
     if !exists {
         err := fmt.Errorf("loan %s not found", loanID)
         log.Printf("[ERROR] %v", err)
-        return err
+        return 0, err
     }
 
-    if loan.Status != "Active" {
+    if loan.Status != loanStatusActive {
         err := fmt.Errorf("loan %s is not active", loanID)
         log.Printf("[ERROR] %v", err)
-        return err
+        return 0, err
     }
 
     // Step 4: Retrieve Lending Pool
@@ -208,25 +225,134 @@ func (lm *LendingManager) RepayLoan(loanID string) error {
     if !exists {
         err := fmt.Errorf("lending pool %s not found", loan.Lender)
         log.Printf("[ERROR] %v", err)
-        return err
+        return 0, err
     }
 
-    // Step 5: Calculate Repayment Amount
-    repaymentAmount := loan.Amount * (1 + loan.InterestRate)
+    // Step 5: Charge the Late Penalty Once
+    now := time.Now()
+    if now.After(loan.ExpiryDate) && !loan.PenaltyCharged {
+        penalty := loanOutstanding(loan, now) * latePaymentPenaltyRate
+        record := ledger.LatePaymentRecord{
+            LoanID:     loanID,
+            Amount:     amount,
+            DueDate:    loan.ExpiryDate,
+            PaidDate:   now,
+            PenaltyFee: penalty,
+        }
+        if err := lm.Ledger.DeFiLedger.RecordLatePayment(record); err != nil {
+            log.Printf("[ERROR] Failed to log late payment in ledger: %v", err)
+            return 0, fmt.Errorf("failed to log late payment: %w", err)
+        }
+        loan.PenaltyFees += penalty
+        loan.PenaltyCharged = true
+        log.Printf("[WARNING] Late payment on loan %s. Penalty fee: %.2f", loanID, penalty)
+    }
 
-    // Step 6: Update Pool and Loan Status
-    pool.AvailableFunds += repaymentAmount
-    loan.Status = "Repaid"
+    // Step 6: Apply Repayment to the Outstanding Amount
+    outstanding := loanOutstanding(loan, now)
+    applied := amount
+    if applied > outstanding {
+        applied = outstanding
+    }
+    loan.AmountRepaid += applied
+    pool.AvailableFunds += applied
+    remaining := outstanding - applied
+
+    if remaining > 0 {
+        log.Printf("[INFO] Loan %s partially repaid. Applied: %.2f, Remaining: %.2f", loanID, applied, remaining)
+        return remaining, nil
+    }
 
-    // Step 7: Log Repayment in Ledger
-    if err := lm.Ledger.DeFiLedger.RecordLoanRepayment(loanID, loan.Borrower); err != nil {
+    // Step 7: Close the Loan and Return Collateral
+    if err := lm.Ledger.DeFiLedger.RecordLoanRepayment(loan.Lender, loanID); err != nil {
         log.Printf("[ERROR] Failed to log repayment in ledger: %v", err)
-        return fmt.Errorf("failed to log repayment in ledger: %w", err)
+        return 0, fmt.Errorf("failed to log repayment in ledger: %w", err)
     }
 
-    // Step 8: Log Success
-    log.Printf("[SUCCESS] Loan %s repaid by borrower %s. Repayment amount: %.2f", loanID, loan.Borrower, repaymentAmount)
-    return nil
+    loan.Status = loanStatusRepaid
+    removeActiveLoan(pool, loanID)
+    log.Printf("[SUCCESS] Loan %s repaid by borrower %s. Collateral of %.2f returned", loanID, loan.Borrower, loan.Collateral)
+    loan.Collateral = 0
+    return 0, nil
+}
+
+// MarkDefaults marks every active loan past its ExpiryDate as Defaulted, records
+// a late payment penalty for the unpaid amount unless one was already charged by
+// RepayLoan, and seizes the loan's collateral into its lending pool.
+func (lm *LendingManager) MarkDefaults(now time.Time) {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+
+    for loanID, loan := range lm.Loans {
+        if loan.Status != loanStatusActive || !now.After(loan.ExpiryDate) {
+            continue
+        }
+
+        pool, exists := lm.LendingPools[loan.Lender]
+        if !exists {
+            log.Printf("[ERROR] Lending pool %s not found for defaulted loan %s", loan.Lender, loanID)
+            continue
+        }
+
+        penalty := 0.0
+        if !loan.PenaltyCharged {
+            outstanding := loanOutstanding(loan, now)
+            record := ledger.LatePaymentRecord{
+                LoanID:     loanID,
+                Amount:     outstanding,
+                DueDate:    loan.ExpiryDate,
+                PenaltyFee: outstanding * latePaymentPenaltyRate,
+            }
+            if err := lm.Ledger.DeFiLedger.RecordLatePayment(record); err != nil {
+                log.Printf("[ERROR] Failed to log late payment for loan %s: %v", loanID, err)
+                continue
+            }
+            penalty = record.PenaltyFee
+        }
+        if err := lm.Ledger.DeFiLedger.RecordLoanDefault(loan.Lender, loanID, loan.Collateral); err != nil {
+            log.Printf("[ERROR] Failed to log default of loan %s: %v", loanID, err)
+            continue
+        }
+
+        loan.PenaltyFees += penalty
+        loan.PenaltyCharged = true
+        loan.Status = loanStatusDefaulted
+        pool.TotalLiquidity += loan.Collateral
+        pool.AvailableFunds += loan.Collateral
+        removeActiveLoan(pool, loanID)
+
+        log.Printf("[WARNING] Loan %s defaulted. Collateral of %.2f seized into pool %s", loanID, loan.Collateral, pool.PoolID)
+        loan.Collateral = 0
+    }
+}
+
+// loanOutstanding returns the amount still owed on a loan at now. Interest
+// accrues linearly over the loan's duration, so the full InterestRate is owed
+// once the loan reaches its expiry.
+func loanOutstanding(loan *Loan, now time.Time) float64 {
+    elapsed := now.Sub(loan.StartDate)
+    if elapsed > loan.Duration {
+        elapsed = loan.Duration
+    }
+    accrued := 0.0
+    if loan.Duration > 0 && elapsed > 0 {
+        accrued = loan.Amount * loan.InterestRate * float64(elapsed) / float64(loan.Duration)
+    }
+    outstanding := loan.Amount + accrued + loan.PenaltyFees - loan.AmountRepaid
+    if outstanding < 0 {
+        return 0
+    }
+    return outstanding
+}
+
+// removeActiveLoan drops a loan from the pool's list of active loans.
+func removeActiveLoan(pool *LendingPool, loanID string) {
+    for i, active := range pool.ActiveLoans {
+        if active.LoanID == loanID {
+            pool.ActiveLoans = append(pool.ActiveLoans[:i], pool.ActiveLoans[i+1:]...)
+            return
+        }
+    }
 }
 
 
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"sort"
 	"synnergy_network/pkg/common"
 	"synnergy_network/pkg/ledger"
 	"time"
@@ -543,6 +544,95 @@ func YieldFarmHarvestRewards(poolID, userID string, ledgerInstance *ledger.Ledge
 }
 
 
+const secondsPerYear = 365 * 24 * 60 * 60 // Seconds in the year APY is quoted over
+
+// AccrueRewards distributes rewards earned since the pool's LastDistributed
+// time to each staker in proportion to their stake. APY is a percentage, so a
+// stake earns stake * APY/100 per year, pro-rated by the elapsed time. If the
+// pool's RewardBalance cannot cover the full accrual, it is shared out pro rata.
+// Stakers are processed in sorted order so every node computes identical
+// balances from the same pool state and time.
+func AccrueRewards(pool *ledger.YieldFarmPool, now time.Time) {
+    if pool == nil {
+        return
+    }
+    if pool.LastDistributed.IsZero() {
+        pool.LastDistributed = now
+        return
+    }
+    if !now.After(pool.LastDistributed) {
+        return
+    }
+
+    elapsed := float64(now.Sub(pool.LastDistributed)) / float64(time.Second)
+    users := make([]string, 0, len(pool.StakedTokens))
+    for userID, staked := range pool.StakedTokens {
+        if staked > 0 {
+            users = append(users, userID)
+        }
+    }
+    sort.Strings(users)
+
+    owed := make([]float64, len(users))
+    total := 0.0
+    for i, userID := range users {
+        owed[i] = pool.StakedTokens[userID] * (pool.APY / 100) * elapsed / secondsPerYear
+        total += owed[i]
+    }
+
+    scale := 1.0
+    if total > pool.RewardBalance {
+        if total > 0 {
+            scale = pool.RewardBalance / total
+        }
+        log.Printf("[WARNING] Reward balance %.6f of pool %s cannot cover accrual of %.6f; distributing pro rata", pool.RewardBalance, pool.PoolID, total)
+    }
+
+    if pool.Earnings == nil {
+        pool.Earnings = make(map[string]*ledger.YieldFarmEarning)
+    }
+    distributed := 0.0
+    for i, userID := range users {
+        earning, exists := pool.Earnings[userID]
+        if !exists {
+            earning = &ledger.YieldFarmEarning{UserID: userID, PoolID: pool.PoolID}
+            pool.Earnings[userID] = earning
+        }
+        reward := owed[i] * scale
+        earning.EarnedRewards += reward
+        distributed += reward
+    }
+
+    pool.RewardBalance -= distributed
+    if pool.RewardBalance < 0 {
+        pool.RewardBalance = 0
+    }
+    pool.LastDistributed = now
+}
+
+// Harvest pays out a user's accrued rewards from the pool and resets them.
+func Harvest(pool *ledger.YieldFarmPool, userID string) (float64, error) {
+    if pool == nil {
+        return 0, fmt.Errorf("yield farming pool cannot be nil")
+    }
+    if pool.IsLocked {
+        return 0, fmt.Errorf("yield farming pool %s is locked", pool.PoolID)
+    }
+
+    earning, exists := pool.Earnings[userID]
+    if !exists || earning.EarnedRewards <= 0 {
+        return 0, fmt.Errorf("no rewards to harvest for user %s in pool %s", userID, pool.PoolID)
+    }
+
+    payout := earning.EarnedRewards
+    earning.EarnedRewards = 0
+    earning.LastHarvest = time.Now()
+
+    log.Printf("[SUCCESS] User %s harvested %.6f rewards from pool %s", userID, payout, pool.PoolID)
+    return payout, nil
+}
+
+
 // YieldFarmDistributeRewards distributes rewards to participants in a yield farming pool.
 func YieldFarmDistributeRewards(poolID string, ledgerInstance *ledger.Ledger) error {
     log.Printf("[INFO] Request to distribute rewards: PoolID=%s", poolID)
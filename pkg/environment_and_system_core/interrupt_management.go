@@ -325,7 +325,7 @@ func defineTrapTimeout(trapID string, timeout time.Duration) error {
 	ledger := &ledger.Ledger{}
 
 	// Set the trap timeout
-	if err := ledger.EnvironmentSystemCoreLedger.TrapManager.SetTrapTimeout(trapID, timeout); err != nil {
+	if err := ledger.EnvironmentSystemCoreLedger.SetTrapTimeout(trapID, timeout, time.Now()); err != nil {
 		return fmt.Errorf("failed to set trap timeout for %s: %w", trapID, err)
 	}
 
@@ -248,16 +248,10 @@ func initiateContextHandover(contextID, targetNodeID string) error {
 
 	// Initiate the handover process in the system
 	ledger := &ledger.Ledger{}
-	if err := ledger.EnvironmentSystemCoreLedger.HandoverManager.InitiateHandover(contextID, targetNodeID); err != nil {
+	if _, err := ledger.EnvironmentSystemCoreLedger.InitiateHandover(contextID, targetNodeID, time.Now()); err != nil {
 		return fmt.Errorf("failed to initiate handover for context %s to node %s: %w", contextID, targetNodeID, err)
 	}
 
-	// Record the handover initiation event in the ledger
-	
-	if err := ledger.EnvironmentSystemCoreLedger.RecordHandoverEvent("Initiated", contextID, targetNodeID); err != nil {
-		return fmt.Errorf("failed to record handover initiation in ledger: %w", err)
-	}
-
 	// Log success
 	log.Printf("Handover initiated for context %s to node %s and recorded in ledger.", contextID, targetNodeID)
 	return nil
@@ -273,16 +267,10 @@ func completeContextHandover(contextID, targetNodeID string) error {
 
 	// Complete the handover process in the system
 	ledger := &ledger.Ledger{}
-	if err := ledger.EnvironmentSystemCoreLedger.HandoverManager.CompleteHandover(contextID, targetNodeID); err != nil {
+	if err := ledger.EnvironmentSystemCoreLedger.CompleteHandover(contextID); err != nil {
 		return fmt.Errorf("failed to complete handover for context %s to node %s: %w", contextID, targetNodeID, err)
 	}
 
-	// Record the handover completion event in the ledger
-	
-	if err := ledger.EnvironmentSystemCoreLedger.RecordHandoverEvent("Completed", contextID, targetNodeID); err != nil {
-		return fmt.Errorf("failed to record handover completion in ledger: %w", err)
-	}
-
 	// Log success
 	log.Printf("Handover completed for context %s to node %s and recorded in ledger.", contextID, targetNodeID)
 	return nil
@@ -393,6 +393,128 @@ func (fm *ChainForkManager) ValidateChain(forkedChain []common.Block) bool {
 }
 
 
+// validateCandidateChain checks a candidate chain from its fork point
+// onwards. Blocks before forkPoint are shared with the current chain and
+// were validated when they were accepted; from forkPoint the indexes must be
+// contiguous, each block must link to its predecessor's hash and carry a
+// hash that matches its recomputed value.
+func validateCandidateChain(chain []common.Block, forkPoint int) error {
+    if len(chain) == 0 {
+        return fmt.Errorf("chain is empty")
+    }
+    if forkPoint < 0 {
+        forkPoint = 0
+    }
+
+    for i := forkPoint; i < len(chain); i++ {
+        block := chain[i]
+        if block.Index != i {
+            return fmt.Errorf("block at position %d has index %d", i, block.Index)
+        }
+        if i > 0 && block.PrevHash != chain[i-1].Hash {
+            return fmt.Errorf("block %d does not link to the hash of block %d", block.Index, i-1)
+        }
+        if block.Hash != block.ComputeHash() {
+            return fmt.Errorf("block %d has an invalid hash", block.Index)
+        }
+    }
+    return nil
+}
+
+// forkPoint returns the first position at which candidate differs from current.
+func forkPoint(current, candidate []common.Block) int {
+    point := 0
+    for point < len(current) && point < len(candidate) && current[point].Hash == candidate[point].Hash {
+        point++
+    }
+    return point
+}
+
+// cumulativeDifficulty sums the PoW difficulty of every block in the chain.
+func cumulativeDifficulty(chain []common.Block) int {
+    total := 0
+    for _, block := range chain {
+        total += block.Difficulty
+    }
+    return total
+}
+
+// ResolveForks applies the longest-valid-chain rule across the current chain
+// and all stored forks. Ties in length are broken by higher cumulative
+// difficulty, with the current chain kept on a full tie. Forks that diverge
+// below the finalized height are discarded. The ledger is
+// reconciled to the winning chain and the blocks it no longer contains are
+// returned so their transactions can be re-queued.
+func (fm *ChainForkManager) ResolveForks() ([]common.Block, error) {
+    fm.mutex.Lock()
+    defer fm.mutex.Unlock()
+
+    ledgerBlocks := fm.LedgerInstance.BlockchainConsensusCoinLedger.GetBlocks()
+    currentChain := make([]common.Block, 0, len(ledgerBlocks))
+    for _, blk := range ledgerBlocks {
+        currentChain = append(currentChain, ConvertToCommonBlock(blk))
+    }
+
+    finalizedHeight := fm.LedgerInstance.BlockchainConsensusCoinLedger.FinalizedHeight()
+
+    var best []common.Block
+    bestIsCurrent := false
+    if len(currentChain) > 0 {
+        if err := validateCandidateChain(currentChain, 0); err != nil {
+            fmt.Printf("Current chain is invalid and will not be preferred: %v\n", err)
+        } else {
+            best = currentChain
+            bestIsCurrent = true
+        }
+    }
+
+    for i, forkedChain := range fm.ForkedChains {
+        point := forkPoint(currentChain, forkedChain)
+        if point < finalizedHeight {
+            fmt.Printf("Discarding forked chain %d: diverges at height %d, below finalized height %d\n", i, point, finalizedHeight)
+            continue
+        }
+        if err := validateCandidateChain(forkedChain, point); err != nil {
+            fmt.Printf("Discarding forked chain %d: %v\n", i, err)
+            continue
+        }
+        if best == nil ||
+            len(forkedChain) > len(best) ||
+            (len(forkedChain) == len(best) && cumulativeDifficulty(forkedChain) > cumulativeDifficulty(best)) {
+            best = forkedChain
+            bestIsCurrent = false
+        }
+    }
+
+    // The forks have either been adopted or rejected at this point.
+    fm.ForkedChains = nil
+
+    if best == nil {
+        return nil, fmt.Errorf("no valid chain available to resolve forks")
+    }
+    if bestIsCurrent {
+        fmt.Println("Current chain is already the longest valid chain. No fork resolution needed.")
+        return nil, nil
+    }
+
+    winner := make([]ledger.Block, len(best))
+    for i := range best {
+        winner[i] = *ConvertCommonBlockToLedger(&best[i])
+    }
+    replaced, err := fm.LedgerInstance.BlockchainConsensusCoinLedger.ReconcileChain(winner)
+    if err != nil {
+        return nil, fmt.Errorf("failed to adopt winning chain: %v", err)
+    }
+
+    discarded := make([]common.Block, 0, len(replaced))
+    for _, blk := range replaced {
+        discarded = append(discarded, ConvertToCommonBlock(blk))
+    }
+
+    fmt.Printf("Ledger switched to chain with %d blocks; %d blocks discarded.\n", len(best), len(discarded))
+    return discarded, nil
+}
+
 // ForkRecovery handles recovery from forks, switching to the longest valid chain
 func (fm *ChainForkManager) ForkRecovery() {
     fmt.Println("Attempting to recover from chain fork...")
@@ -402,13 +524,11 @@ func (fm *ChainForkManager) ForkRecovery() {
         return
     }
 
-    // Validate all forked chains and resolve
-    for _, forkedChain := range fm.ForkedChains {
-        if fm.ValidateChain(forkedChain) {
-            fm.ResolveFork()
-            return
-        }
+    discarded, err := fm.ResolveForks()
+    if err != nil {
+        fmt.Printf("Fork recovery failed: %v\n", err)
+        return
     }
 
-    fmt.Println("No valid forks found. Retaining the current chain.")
+    fmt.Printf("Fork recovery complete. %d blocks discarded.\n", len(discarded))
 }
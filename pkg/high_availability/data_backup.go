@@ -56,6 +56,16 @@ func (dbm *DataBackupManager) BackupLedger() error {
         return fmt.Errorf("failed to write backup file: %v", err)
     }
 
+    // Record a hashed backup of the chain so its integrity can be verified later
+    blocks := dbm.LedgerInstance.BlockchainConsensusCoinLedger.GetBlocks()
+    if len(blocks) > 0 {
+        backup, err := dbm.LedgerInstance.HighAvailabilityLedger.RecordBlockchainBackup(dbm.NodeID, blocks, time.Now())
+        if err != nil {
+            return fmt.Errorf("failed to record blockchain backup: %v", err)
+        }
+        fmt.Printf("Blockchain backup %s recorded with hash %s.\n", backup.BackupID, backup.BackupHash)
+    }
+
     fmt.Printf("Ledger backup created at %s.\n", backupFilePath)
     return nil
 }
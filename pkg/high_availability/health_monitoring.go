@@ -15,6 +15,8 @@ func NewHealthMonitoringManager(nodes []string, ledgerInstance *ledger.Ledger) *
         NodeHealthStatus: make(map[string]bool),
         SubBlockLatency:  make(map[int]time.Duration),
         BlockLatency:     make(map[int]time.Duration),
+        NodeLatency:      make(map[string]map[string]time.Duration),
+        MissedHeartbeats: make(map[string]int),
         LedgerInstance:   ledgerInstance,
     }
 }
@@ -98,3 +100,81 @@ func (hm *HealthMonitoringManager) GetBlockLatency(blockIndex int) time.Duration
 
     return hm.BlockLatency[blockIndex]
 }
+
+// Latency kinds accepted by RecordLatency.
+const (
+    LatencySubBlock = "sub_block"
+    LatencyBlock    = "block"
+)
+
+// RecordLatency records the latest sub-block or block validation latency observed for a node.
+func (hm *HealthMonitoringManager) RecordLatency(nodeID string, kind string, d time.Duration) {
+    hm.mutex.Lock()
+    defer hm.mutex.Unlock()
+
+    if hm.NodeLatency == nil {
+        hm.NodeLatency = make(map[string]map[string]time.Duration)
+    }
+    if hm.NodeLatency[nodeID] == nil {
+        hm.NodeLatency[nodeID] = make(map[string]time.Duration)
+    }
+    hm.NodeLatency[nodeID][kind] = d
+}
+
+// RecordHeartbeatResult counts a missed heartbeat for a node, or clears its
+// missed count when the heartbeat arrived.
+func (hm *HealthMonitoringManager) RecordHeartbeatResult(nodeID string, missed bool) {
+    hm.mutex.Lock()
+    defer hm.mutex.Unlock()
+
+    if hm.MissedHeartbeats == nil {
+        hm.MissedHeartbeats = make(map[string]int)
+    }
+    if missed {
+        hm.MissedHeartbeats[nodeID]++
+    } else {
+        delete(hm.MissedHeartbeats, nodeID)
+    }
+}
+
+// EvaluateHealth marks a monitored node unhealthy when its sub-block or block
+// latency exceeds maxLatency or it has missed more than maxMissed heartbeats,
+// and healthy otherwise. Nodes that have just become unhealthy are recorded in
+// the ledger and returned.
+func (hm *HealthMonitoringManager) EvaluateHealth(maxLatency time.Duration, maxMissed int) []string {
+    hm.mutex.Lock()
+    var newlyUnhealthy []string
+    for _, node := range hm.Nodes {
+        healthy := hm.MissedHeartbeats[node] <= maxMissed
+        for _, kind := range []string{LatencySubBlock, LatencyBlock} {
+            if hm.NodeLatency[node][kind] > maxLatency {
+                healthy = false
+            }
+        }
+
+        wasHealthy, known := hm.NodeHealthStatus[node]
+        hm.NodeHealthStatus[node] = healthy
+        if !healthy && (!known || wasHealthy) {
+            newlyUnhealthy = append(newlyUnhealthy, node)
+        }
+    }
+    hm.mutex.Unlock()
+
+    for _, node := range newlyUnhealthy {
+        hm.TriggerNodeAlert(node)
+        if hm.LedgerInstance == nil {
+            continue
+        }
+        if err := hm.LedgerInstance.EnvironmentSystemCoreLedger.UpdateNodeStatus(node, "unhealthy"); err != nil {
+            fmt.Printf("Failed to record unhealthy status for node %s: %v\n", node, err)
+        }
+        if err := hm.LedgerInstance.EnvironmentSystemCoreLedger.RecordNodeHealth(ledger.NodeHealthLog{
+            NodeID:      node,
+            HealthScore: 0,
+            Timestamp:   time.Now(),
+        }); err != nil {
+            fmt.Printf("Failed to record health log for node %s: %v\n", node, err)
+        }
+    }
+    return newlyUnhealthy
+}
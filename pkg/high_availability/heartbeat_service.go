@@ -7,12 +7,20 @@ import (
 )
 
 
-// NewHeartbeatService initializes a new HeartbeatService
+// NewHeartbeatService initializes a new HeartbeatService. Every monitored node
+// starts with a heartbeat logged now, so one that never responds is detected
+// as dead once its grace period runs out.
 func NewHeartbeatService(nodes []string, interval time.Duration, ledger *ledger.Ledger) *HeartbeatService {
+    heartbeatLogs := make(map[string]time.Time, len(nodes))
+    now := time.Now()
+    for _, node := range nodes {
+        heartbeatLogs[node] = now
+    }
     return &HeartbeatService{
         Nodes:         nodes,
-        HeartbeatLogs: make(map[string]time.Time),
+        HeartbeatLogs: heartbeatLogs,
         Interval:      interval,
+        DeadNodes:     make(map[string]bool),
         LedgerInstance: ledger,
     }
 }
@@ -36,9 +44,7 @@ func (hb *HeartbeatService) sendHeartbeat(node string) {
     fmt.Printf("Sending heartbeat to node: %s\n", node)
 
     // Simulating heartbeat response from node (In a real-world system, this would be an actual network request)
-    hb.mutex.Lock()
-    hb.HeartbeatLogs[node] = time.Now()
-    hb.mutex.Unlock()
+    hb.RecordHeartbeat(node, time.Now())
 }
 
 // monitorHeartbeats checks if any node has missed its heartbeat within the allowed time frame
@@ -82,3 +88,74 @@ func (hb *HeartbeatService) IsNodeResponsive(node string) bool {
     }
     return true
 }
+
+// RecordHeartbeat records a heartbeat received from a node at t. A node
+// previously detected as dead is considered alive again, and the failover
+// manager, if one is set, may fail over to it once more.
+func (hb *HeartbeatService) RecordHeartbeat(nodeID string, t time.Time) {
+    hb.mutex.Lock()
+    if hb.HeartbeatLogs == nil {
+        hb.HeartbeatLogs = make(map[string]time.Time)
+    }
+    if last, exists := hb.HeartbeatLogs[nodeID]; !exists || t.After(last) {
+        hb.HeartbeatLogs[nodeID] = t
+    }
+    revived := hb.DeadNodes[nodeID]
+    delete(hb.DeadNodes, nodeID)
+    hb.mutex.Unlock()
+
+    if !revived {
+        return
+    }
+    fmt.Printf("Node %s is responsive again.\n", nodeID)
+    if hb.FailoverManager != nil {
+        hb.FailoverManager.ReportNodeUp(nodeID)
+    }
+}
+
+// DetectDead returns the nodes whose last heartbeat is older than graceFactor
+// intervals at now, along with monitored nodes that have never sent one. Nodes that have only just been found dead are recorded in
+// the ledger and reported to the failover manager, if one is set.
+func (hb *HeartbeatService) DetectDead(now time.Time, graceFactor int) []string {
+    if graceFactor < 1 {
+        graceFactor = 1
+    }
+    deadline := hb.Interval * time.Duration(graceFactor)
+
+    hb.mutex.Lock()
+    if hb.DeadNodes == nil {
+        hb.DeadNodes = make(map[string]bool)
+    }
+    var dead, newlyDead []string
+    markDead := func(node string) {
+        dead = append(dead, node)
+        if !hb.DeadNodes[node] {
+            hb.DeadNodes[node] = true
+            newlyDead = append(newlyDead, node)
+        }
+    }
+    for _, node := range hb.Nodes {
+        if _, seen := hb.HeartbeatLogs[node]; !seen {
+            markDead(node)
+        }
+    }
+    for node, last := range hb.HeartbeatLogs {
+        if now.Sub(last) > deadline {
+            markDead(node)
+        }
+    }
+    hb.mutex.Unlock()
+
+    for _, node := range newlyDead {
+        fmt.Printf("ALERT: Node %s missed heartbeats for over %s.\n", node, deadline)
+        if hb.LedgerInstance != nil {
+            if err := hb.LedgerInstance.EnvironmentSystemCoreLedger.UpdateNodeStatus(node, "dead"); err != nil {
+                fmt.Printf("Failed to record dead status for node %s: %v\n", node, err)
+            }
+        }
+        if hb.FailoverManager != nil {
+            hb.FailoverManager.ReportNodeDown(node)
+        }
+    }
+    return dead
+}
@@ -13,6 +13,7 @@ type DataBackupManager struct {
 	Backups map[string][]*BlockchainBackup // A map of node IDs to their list of backups
 	BackupInterval time.Duration  // Interval for automatic backups
 	BackupLocation string         // Directory where backups are stored
+	NodeID         string         // Node recorded as the creator of blockchain backups
 	mutex          sync.Mutex     // Mutex for thread-safe backup operations
 }
 
@@ -73,6 +74,8 @@ type HealthMonitoringManager struct {
 	NodeHealthStatus   map[string]bool      // Health status of each node
 	SubBlockLatency    map[int]time.Duration // Sub-block validation latency
 	BlockLatency       map[int]time.Duration // Block validation latency
+	NodeLatency        map[string]map[string]time.Duration // Node ID -> latency kind -> latest latency
+	MissedHeartbeats   map[string]int       // Consecutive heartbeats missed by each node
 	LedgerInstance     *ledger.Ledger       // Instance of the ledger for validation tracking
 	mutex              sync.Mutex           // Mutex for thread-safe operations
 }
@@ -82,8 +85,10 @@ type HeartbeatService struct {
 	Nodes         []string             // List of nodes to monitor
 	HeartbeatLogs map[string]time.Time // Records of last heartbeat received from each node
 	Interval      time.Duration        // Interval between heartbeat checks
+	DeadNodes     map[string]bool      // Nodes currently considered dead
 	mutex         sync.Mutex           // Mutex for thread-safe operations
 	LedgerInstance *ledger.Ledger      // Ledger instance for storing heartbeat data
+	FailoverManager *NodeFailoverManager // Optional; told about nodes detected as dead
 }
 
 // NodeFailoverManager handles the failover mechanism in case a node goes down
@@ -49,6 +49,9 @@ func (fm *NodeFailoverManager) checkNodeHealth(node string) bool {
 // failover switches to the next available backup node
 func (fm *NodeFailoverManager) failover() {
     for _, backupNode := range fm.BackupNodes {
+        if fm.isMarkedDown(backupNode) {
+            continue
+        }
         if fm.checkNodeHealth(backupNode) {
             fm.mutex.Lock()
             fm.CurrentPrimary = backupNode
@@ -93,3 +96,39 @@ func (fm *NodeFailoverManager) GetCurrentPrimaryNode() string {
 
     return fm.CurrentPrimary
 }
+
+// ReportNodeDown marks a node as down and fails over if it is the current primary.
+func (fm *NodeFailoverManager) ReportNodeDown(node string) {
+    fm.mutex.Lock()
+    if fm.NodeHealthStatus == nil {
+        fm.NodeHealthStatus = make(map[string]bool)
+    }
+    fm.NodeHealthStatus[node] = false
+    isPrimary := node == fm.CurrentPrimary
+    fm.mutex.Unlock()
+
+    fmt.Printf("Node %s reported down.\n", node)
+    if isPrimary {
+        fm.failover()
+    }
+}
+
+// ReportNodeUp clears a node's down flag so failover may select it again.
+func (fm *NodeFailoverManager) ReportNodeUp(node string) {
+    fm.mutex.Lock()
+    defer fm.mutex.Unlock()
+
+    if healthy, known := fm.NodeHealthStatus[node]; known && !healthy {
+        fm.NodeHealthStatus[node] = true
+        fmt.Printf("Node %s reported up.\n", node)
+    }
+}
+
+// isMarkedDown reports whether a node has been reported down.
+func (fm *NodeFailoverManager) isMarkedDown(node string) bool {
+    fm.mutex.Lock()
+    defer fm.mutex.Unlock()
+
+    healthy, known := fm.NodeHealthStatus[node]
+    return known && !healthy
+}
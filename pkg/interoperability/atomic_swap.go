@@ -34,8 +34,14 @@ func (asm *AtomicSwapManager) InitiateSwap(tokenA syn1200.SYN1200Token, amountA
 	// Hash the secret
 	secretHash := asm.generateSecretHash(secret)
 
+	// Lock the initiator's funds until the swap is claimed or refunded
+	if err := asm.LedgerInstance.AccountsWalletLedger.DebitBalance(initiator, amountA); err != nil {
+		return "", fmt.Errorf("failed to lock swap funds: %v", err)
+	}
+
 	// Create the atomic swap
 	swap := &AtomicSwap{
+		SwapID:         swapID,
 		TokenA:         tokenA,
 		AmountA:        amountA,
 		ChainAAddress:  chainAAddress,
@@ -53,6 +59,7 @@ func (asm *AtomicSwapManager) InitiateSwap(tokenA syn1200.SYN1200Token, amountA
 	// Record the swap in the ledger
 	err := asm.recordSwapToLedger(swap)
 	if err != nil {
+		asm.LedgerInstance.AccountsWalletLedger.CreditBalance(initiator, amountA)
 		return "", fmt.Errorf("failed to record swap in the ledger: %v", err)
 	}
 
@@ -62,8 +69,10 @@ func (asm *AtomicSwapManager) InitiateSwap(tokenA syn1200.SYN1200Token, amountA
 	return swapID, nil
 }
 
-// CompleteSwap completes the atomic swap by providing the correct secret for validation
-func (asm *AtomicSwapManager) CompleteSwap(swapID string, secret string, responder string) error {
+// AcceptSwap binds responder as the counterparty that receives the locked
+// funds when the swap is claimed. A swap can only be accepted once, while it
+// is still pending and unexpired.
+func (asm *AtomicSwapManager) AcceptSwap(swapID string, responder string) error {
 	asm.mutex.Lock()
 	defer asm.mutex.Unlock()
 
@@ -71,24 +80,120 @@ func (asm *AtomicSwapManager) CompleteSwap(swapID string, secret string, respond
 	if !exists {
 		return errors.New("swap not found")
 	}
-
+	if responder == "" {
+		return errors.New("responder address is required")
+	}
 	if swap.Status != "pending" {
 		return errors.New("swap is not in a pending state")
 	}
-
 	if time.Now().After(swap.ExpirationTime) {
-		swap.Status = "expired"
 		return errors.New("swap has expired")
 	}
+	if swap.SwapResponder != "" {
+		return errors.New("swap has already been accepted")
+	}
+
+	swap.SwapResponder = responder
+	fmt.Printf("Atomic swap %s accepted by %s\n", swapID, responder)
+	return nil
+}
+
+// CompleteSwap completes the atomic swap on behalf of responder by providing
+// the correct secret for validation. The responder must be the one that
+// accepted the swap; a claim attempt never changes who receives the funds.
+func (asm *AtomicSwapManager) CompleteSwap(swapID string, secret string, responder string) error {
+	asm.mutex.Lock()
+	defer asm.mutex.Unlock()
+
+	swap, exists := asm.ActiveSwaps[swapID]
+	if !exists {
+		return errors.New("swap not found")
+	}
+
+	if swap.SwapResponder == "" {
+		return errors.New("swap has not been accepted by a responder")
+	}
+	if swap.SwapResponder != responder {
+		return errors.New("responder does not match the accepted counterparty")
+	}
+	return asm.claim(swap, secret, time.Now())
+}
+
+// Claim releases the swap's locked funds to the responder once the secret
+// whose SHA-256 hash matches SecretHash is revealed before expiry. Claiming
+// an already completed swap with the same secret is a no-op.
+func (asm *AtomicSwapManager) Claim(swapID, secret string) error {
+	asm.mutex.Lock()
+	defer asm.mutex.Unlock()
+
+	swap, exists := asm.ActiveSwaps[swapID]
+	if !exists {
+		return errors.New("swap not found")
+	}
+	return asm.claim(swap, secret, time.Now())
+}
+
+// Refund returns the swap's locked funds to the initiator once now is past
+// ExpirationTime and the swap has not been claimed. Refunding an already
+// refunded swap is a no-op.
+func (asm *AtomicSwapManager) Refund(swapID string, now time.Time) error {
+	asm.mutex.Lock()
+	defer asm.mutex.Unlock()
+
+	swap, exists := asm.ActiveSwaps[swapID]
+	if !exists {
+		return errors.New("swap not found")
+	}
+
+	switch swap.Status {
+	case "refunded":
+		return nil
+	case "completed":
+		return errors.New("swap has already been claimed")
+	}
+
+	if !now.After(swap.ExpirationTime) {
+		return errors.New("swap has not yet expired")
+	}
 
-	// Validate the secret
+	if err := asm.LedgerInstance.AccountsWalletLedger.CreditBalance(swap.SwapInitiator, swap.AmountA); err != nil {
+		return fmt.Errorf("failed to refund swap funds: %v", err)
+	}
+	swap.Status = "refunded"
+	asm.LedgerInstance.InteroperabilityLedger.RecordAtomicSwapRefund(swap.SwapID, swap.SwapInitiator, swap.AmountA)
+
+	fmt.Printf("Atomic swap refunded. Swap ID: %s\n", swapID)
+	return nil
+}
+
+// claim verifies the secret and releases the locked funds to the responder.
+// The caller must hold the mutex.
+func (asm *AtomicSwapManager) claim(swap *AtomicSwap, secret string, now time.Time) error {
 	if asm.generateSecretHash(secret) != swap.SecretHash {
 		return errors.New("invalid secret")
 	}
 
+	switch swap.Status {
+	case "completed":
+		return nil
+	case "refunded":
+		return errors.New("swap has already been refunded")
+	}
+
+	if now.After(swap.ExpirationTime) {
+		swap.Status = "expired"
+		return errors.New("swap has expired")
+	}
+	if swap.SwapResponder == "" {
+		return errors.New("swap has no responder to release funds to")
+	}
+
+	if err := asm.LedgerInstance.AccountsWalletLedger.CreditBalance(swap.SwapResponder, swap.AmountA); err != nil {
+		return fmt.Errorf("failed to release swap funds: %v", err)
+	}
+
 	// Complete the swap
 	swap.Secret = secret
-	swap.SwapResponder = responder
 	swap.Status = "completed"
 
 	// Log the swap completion to the ledger
@@ -97,7 +202,7 @@ func (asm *AtomicSwapManager) CompleteSwap(swapID string, secret string, respond
 		return fmt.Errorf("failed to log swap completion to ledger: %v", err)
 	}
 
-	fmt.Printf("Atomic swap completed. Swap ID: %s\n", swapID)
+	fmt.Printf("Atomic swap completed. Swap ID: %s\n", swap.SwapID)
 	return nil
 }
 
@@ -210,7 +315,7 @@ func (asm *AtomicSwapManager) logSwapCompletionToLedger(swap *AtomicSwap) error
     }
 
     // Record the atomic swap completion in the ledger using only the swapID
-    asm.LedgerInstance.RecordAtomicSwapCompletion(swap.SwapID)
+    asm.LedgerInstance.InteroperabilityLedger.RecordAtomicSwapCompletion(swap.SwapID)
 
     return nil
 }
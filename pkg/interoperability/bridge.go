@@ -1,10 +1,12 @@
 package interoperability
 
 import (
+    "crypto/ecdsa"
     "crypto/sha256"
     "encoding/hex"
     "errors"
     "fmt"
+    "sort"
     "time"
     "synnergy_network/pkg/common"
     "synnergy_network/pkg/ledger"
@@ -17,63 +19,68 @@ func NewBridge(supportedChains []string, validators []common.Validator, ledgerIn
         Validators:      validators,
         LedgerInstance:  ledgerInstance,
         BridgeBalance:   make(map[string]float64),
+        Transfers:       make(map[string]*CrossChainTransfer),
     }
 }
 
-// InitiateTransfer initializes a cross-chain transfer using the bridge
-func (b *Bridge) InitiateTransfer(fromChain, toChain string, amount float64, tokenSymbol, fromAddress, toAddress string) (string, error) {
+// DefaultTransferTimeout is how long a transfer may wait for a validator quorum
+// before it is refunded, when the bridge sets no TransferTimeout.
+const DefaultTransferTimeout = 24 * time.Hour
+
+// InitiateTransfer starts a cross-chain transfer: it locks the amount from
+// the sender on the source chain, computes the ValidationHash validators sign,
+// and marks the transfer pending. A transfer ID can only be used once.
+func (b *Bridge) InitiateTransfer(t *CrossChainTransfer) error {
     b.mutex.Lock()
     defer b.mutex.Unlock()
 
-    // Check if both chains are supported by the bridge
-    if !b.isChainSupported(fromChain) || !b.isChainSupported(toChain) {
-        return "", errors.New("unsupported blockchain networks")
+    if t == nil || t.Amount <= 0 {
+        return errors.New("transfer must have a positive amount")
     }
 
-    // Check if the bridge has sufficient balance for the token
-    if b.BridgeBalance[tokenSymbol] < amount {
-        return "", fmt.Errorf("insufficient bridge balance for token %s", tokenSymbol)
+    // Check if both chains are supported by the bridge
+    if !b.isChainSupported(t.FromChain) || !b.isChainSupported(t.ToChain) {
+        return errors.New("unsupported blockchain networks")
     }
 
-    // Generate a unique transfer ID
-    transferID := b.generateTransferID(fromChain, toChain)
-
-    // Create a cross-chain transfer
-    transfer := &CrossChainTransfer{
-        TransferID:     transferID,
-        FromChain:      fromChain,
-        ToChain:        toChain,
-        Amount:         amount,
-        TokenSymbol:    tokenSymbol,
-        FromAddress:    fromAddress,
-        ToAddress:      toAddress,
-        Timestamp:      time.Now(),
-        Status:         "pending",
+    if t.TransferID == "" {
+        t.TransferID = b.generateTransferID(t.FromChain, t.ToChain)
     }
-
-    // Validate the transfer
-    validationHash, err := b.validateTransfer(transfer)
-    if err != nil {
-        return "", fmt.Errorf("transfer validation failed: %v", err)
+    if b.Transfers == nil {
+        b.Transfers = make(map[string]*CrossChainTransfer)
+    }
+    if _, seen := b.Transfers[t.TransferID]; seen {
+        return fmt.Errorf("transfer %s has already been submitted", t.TransferID)
     }
 
-    transfer.ValidationHash = validationHash
+    // Lock the sender's funds on the source chain
+    if err := b.LedgerInstance.AccountsWalletLedger.DebitBalance(t.FromAddress, t.Amount); err != nil {
+        return fmt.Errorf("failed to lock transfer funds: %v", err)
+    }
 
-    // Deduct balance from the bridge for the token
-    b.BridgeBalance[tokenSymbol] -= amount
+    t.Timestamp = time.Now()
+    t.ValidationHash = transferValidationHash(t)
+    t.Status = "pending"
 
-    // Log the transfer initiation in the ledger
-    err = b.logTransferToLedger(transfer)
-    if err != nil {
-        return "", fmt.Errorf("failed to log transfer in the ledger: %v", err)
+    // Log the transfer initiation in the ledger, returning the locked funds if it fails
+    if err := b.logTransferToLedger(t); err != nil {
+        if refundErr := b.LedgerInstance.AccountsWalletLedger.CreditBalance(t.FromAddress, t.Amount); refundErr != nil {
+            return fmt.Errorf("failed to log transfer in the ledger: %v (refund to %s also failed: %v)", err, t.FromAddress, refundErr)
+        }
+        t.Status = "failed"
+        return fmt.Errorf("failed to log transfer in the ledger: %v", err)
     }
+    b.Transfers[t.TransferID] = t
 
-    fmt.Printf("Cross-chain transfer initiated. Transfer ID: %s\n", transferID)
-    return transferID, nil
+    fmt.Printf("Cross-chain transfer initiated. Transfer ID: %s\n", t.TransferID)
+    return nil
 }
 
-// CompleteTransfer completes the cross-chain transfer after validation
-func (b *Bridge) CompleteTransfer(transferID string) error {
+// FinalizeTransfer completes a pending transfer once a quorum of the bridge's
+// validators have signed its ValidationHash. validatorSigs maps a validator
+// address to its ASN.1 ECDSA signature. The destination address is credited
+// from the bridge balance for the token.
+func (b *Bridge) FinalizeTransfer(transferID string, validatorSigs map[string][]byte) error {
     b.mutex.Lock()
     defer b.mutex.Unlock()
 
@@ -83,15 +90,42 @@ func (b *Bridge) CompleteTransfer(transferID string) error {
     }
 
     if transfer.Status != "pending" {
-        return fmt.Errorf("transfer is not in a pending state")
+        return fmt.Errorf("transfer %s is not in a pending state", transferID)
+    }
+    if b.transferExpired(transfer, time.Now()) {
+        return fmt.Errorf("transfer %s timed out waiting for validator signatures", transferID)
+    }
+
+    digest, err := hex.DecodeString(transfer.ValidationHash)
+    if err != nil {
+        return fmt.Errorf("invalid validation hash for transfer %s: %v", transferID, err)
+    }
+
+    signed := 0
+    for _, validator := range b.Validators {
+        sig, ok := validatorSigs[validator.Address]
+        if ok && validator.PublicKey != nil && ecdsa.VerifyASN1(validator.PublicKey, digest, sig) {
+            signed++
+        }
+    }
+    if quorum := b.validatorQuorum(); signed < quorum {
+        return fmt.Errorf("transfer %s has %d valid validator signatures, %d required", transferID, signed, quorum)
     }
 
-    // Mark the transfer as completed
+    // Check if the bridge has sufficient balance for the token
+    if b.BridgeBalance[transfer.TokenSymbol] < transfer.Amount {
+        return fmt.Errorf("insufficient bridge balance for token %s", transfer.TokenSymbol)
+    }
+
+    // Credit the recipient on the destination chain
+    if err := b.LedgerInstance.AccountsWalletLedger.CreditBalance(transfer.ToAddress, transfer.Amount); err != nil {
+        return fmt.Errorf("failed to credit transfer recipient: %v", err)
+    }
+    b.BridgeBalance[transfer.TokenSymbol] -= transfer.Amount
     transfer.Status = "completed"
 
     // Log transfer completion to the ledger
-    err = b.logTransferCompletionToLedger(transfer)
-    if err != nil {
+    if err := b.logTransferCompletionToLedger(transfer); err != nil {
         return fmt.Errorf("failed to log transfer completion: %v", err)
     }
 
@@ -99,6 +133,39 @@ func (b *Bridge) CompleteTransfer(transferID string) error {
     return nil
 }
 
+// RefundExpiredTransfers returns the locked funds of every pending transfer that
+// has not reached a validator quorum within the bridge's transfer timeout, marks
+// those transfers refunded and returns their IDs.
+func (b *Bridge) RefundExpiredTransfers(now time.Time) ([]string, error) {
+    b.mutex.Lock()
+    defer b.mutex.Unlock()
+
+    var refunded []string
+    for id, transfer := range b.Transfers {
+        if transfer.Status != "pending" || !b.transferExpired(transfer, now) {
+            continue
+        }
+        if err := b.LedgerInstance.AccountsWalletLedger.CreditBalance(transfer.FromAddress, transfer.Amount); err != nil {
+            return refunded, fmt.Errorf("failed to refund transfer %s: %v", id, err)
+        }
+        transfer.Status = "refunded"
+        refunded = append(refunded, id)
+        fmt.Printf("Cross-chain transfer %s timed out and was refunded to %s.\n", id, transfer.FromAddress)
+    }
+    sort.Strings(refunded)
+    return refunded, nil
+}
+
+// transferExpired reports whether a transfer has waited longer than the bridge's
+// transfer timeout.
+func (b *Bridge) transferExpired(transfer *CrossChainTransfer, now time.Time) bool {
+    timeout := b.TransferTimeout
+    if timeout <= 0 {
+        timeout = DefaultTransferTimeout
+    }
+    return !now.Before(transfer.Timestamp.Add(timeout))
+}
+
 // AddFundsToBridge allows adding tokens to the bridge balance
 func (b *Bridge) AddFundsToBridge(tokenSymbol string, amount float64) {
     b.mutex.Lock()
@@ -108,19 +175,18 @@ func (b *Bridge) AddFundsToBridge(tokenSymbol string, amount float64) {
     fmt.Printf("Added %.2f %s to bridge balance. New balance: %.2f %s\n", amount, tokenSymbol, b.BridgeBalance[tokenSymbol], tokenSymbol)
 }
 
-// validateTransfer validates the transfer across validators
-func (b *Bridge) validateTransfer(transfer *CrossChainTransfer) (string, error) {
-    // Select first validator (simplified for demonstration)
-    validator := b.Validators[0]
+// validatorQuorum returns the number of validator signatures required to
+// finalize a transfer: more than two thirds of the bridge's validators.
+func (b *Bridge) validatorQuorum() int {
+    return len(b.Validators)*2/3 + 1
+}
 
-    // Generate validation hash
-    validationData := fmt.Sprintf("%s%s%f%s%s%d", transfer.FromChain, transfer.ToChain, transfer.Amount, transfer.FromAddress, transfer.ToAddress, transfer.Timestamp.UnixNano())
+// transferValidationHash computes the hash validators sign to approve a transfer
+func transferValidationHash(transfer *CrossChainTransfer) string {
+    validationData := fmt.Sprintf("%s%s%s%f%s%s%s%d", transfer.TransferID, transfer.FromChain, transfer.ToChain, transfer.Amount, transfer.TokenSymbol, transfer.FromAddress, transfer.ToAddress, transfer.Timestamp.UnixNano())
     hash := sha256.New()
     hash.Write([]byte(validationData))
-    validationHash := hex.EncodeToString(hash.Sum(nil))
-
-    fmt.Printf("Transfer validated by %s with hash: %s\n", validator.Address, validationHash)
-    return validationHash, nil
+    return hex.EncodeToString(hash.Sum(nil))
 }
 
 // generateTransferID creates a unique ID for the cross-chain transfer
@@ -195,7 +261,9 @@ func (b *Bridge) isChainSupported(chain string) bool {
 
 // getTransferByID retrieves a transfer by its ID
 func (b *Bridge) getTransferByID(transferID string) (*CrossChainTransfer, error) {
-    // In practice, this would look up the transfer in a data store.
-    // Placeholder function for demo purposes.
-    return nil, fmt.Errorf("transfer ID %s not found", transferID)
+    transfer, exists := b.Transfers[transferID]
+    if !exists {
+        return nil, fmt.Errorf("transfer ID %s not found", transferID)
+    }
+    return transfer, nil
 }
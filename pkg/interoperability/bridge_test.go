@@ -0,0 +1,72 @@
+package interoperability
+
+import (
+	"testing"
+	"time"
+
+	"synnergy_network/pkg/ledger"
+)
+
+func newRefundTestBridge(now time.Time) *Bridge {
+	l := &ledger.Ledger{}
+	l.AccountsWalletLedger.Balances = map[string]ledger.Account{"alice": {Address: "alice", Balance: 90}}
+
+	b := NewBridge([]string{"chain-a", "chain-b"}, nil, l)
+	b.TransferTimeout = time.Hour
+	b.Transfers["transfer-1"] = &CrossChainTransfer{
+		TransferID:  "transfer-1",
+		FromChain:   "chain-a",
+		ToChain:     "chain-b",
+		Amount:      10,
+		FromAddress: "alice",
+		ToAddress:   "bob",
+		Timestamp:   now,
+		Status:      "pending",
+	}
+	return b
+}
+
+func TestRefundExpiredTransfersReturnsLockedFunds(t *testing.T) {
+	now := time.Now()
+	b := newRefundTestBridge(now)
+
+	refunded, err := b.RefundExpiredTransfers(now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("RefundExpiredTransfers: %v", err)
+	}
+	if len(refunded) != 1 || refunded[0] != "transfer-1" {
+		t.Fatalf("refunded = %v, want [transfer-1]", refunded)
+	}
+	if got := b.LedgerInstance.AccountsWalletLedger.Balances["alice"].Balance; got != 100 {
+		t.Fatalf("alice balance = %v, want 100", got)
+	}
+	if status := b.Transfers["transfer-1"].Status; status != "refunded" {
+		t.Fatalf("status = %q, want refunded", status)
+	}
+
+	// A second sweep must not refund the transfer again.
+	if refunded, _ := b.RefundExpiredTransfers(now.Add(2 * time.Hour)); len(refunded) != 0 {
+		t.Fatalf("second sweep refunded %v, want nothing", refunded)
+	}
+}
+
+func TestRefundExpiredTransfersKeepsTransfersWithinTimeout(t *testing.T) {
+	now := time.Now()
+	b := newRefundTestBridge(now)
+
+	refunded, err := b.RefundExpiredTransfers(now.Add(30 * time.Minute))
+	if err != nil {
+		t.Fatalf("RefundExpiredTransfers: %v", err)
+	}
+	if len(refunded) != 0 || b.Transfers["transfer-1"].Status != "pending" {
+		t.Fatalf("refunded = %v, status %q; want the transfer left pending", refunded, b.Transfers["transfer-1"].Status)
+	}
+}
+
+func TestFinalizeTransferRejectsExpiredTransfer(t *testing.T) {
+	b := newRefundTestBridge(time.Now().Add(-2 * time.Hour))
+
+	if err := b.FinalizeTransfer("transfer-1", nil); err == nil {
+		t.Fatal("expected a timed-out transfer to be rejected")
+	}
+}
@@ -1,6 +1,7 @@
 package interoperability
 
 import (
+	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
@@ -20,66 +21,63 @@ func NewCrossChainCommunication(supportedChains []string, validators []common.Va
     }
 }
 
-// SendMessage sends a message from one blockchain to another.
-func (cc *CrossChainCommunication) SendMessage(fromChain, toChain, payload string) (string, error) {
+// SendMessage encrypts the message payload, computes its ValidationHash and
+// places it in the message pool with status "sent" until the destination
+// chain proves receipt with ReceiveMessage.
+func (cc *CrossChainCommunication) SendMessage(m CrossChainMessage) error {
     cc.mutex.Lock()
     defer cc.mutex.Unlock()
 
     // Check if both chains are supported by the cross-chain communication system.
-    if !cc.isChainSupported(fromChain) || !cc.isChainSupported(toChain) {
-        return "", fmt.Errorf("unsupported blockchain networks")
+    if !cc.isChainSupported(m.FromChain) || !cc.isChainSupported(m.ToChain) {
+        return fmt.Errorf("unsupported blockchain networks")
+    }
+
+    if m.MessageID == "" {
+        m.MessageID = cc.generateMessageID(m.FromChain, m.ToChain)
+    }
+    if cc.MessagePool == nil {
+        cc.MessagePool = make(map[string]CrossChainMessage)
+    }
+    if _, exists := cc.MessagePool[m.MessageID]; exists {
+        return fmt.Errorf("message ID %s has already been sent", m.MessageID)
     }
 
     // Create an encryption instance and encrypt the payload.
     encryptInstance, err := common.NewEncryption(256) // Adjust key size as needed
     if err != nil {
-        return "", fmt.Errorf("failed to create encryption instance: %v", err)
+        return fmt.Errorf("failed to create encryption instance: %v", err)
     }
 
-    encryptedPayload, err := encryptInstance.EncryptData(payload, common.EncryptionKey, nil)
+    encryptedPayload, err := encryptInstance.EncryptData(m.Payload, common.EncryptionKey, nil)
     if err != nil {
-        return "", fmt.Errorf("failed to encrypt message payload: %v", err)
+        return fmt.Errorf("failed to encrypt message payload: %v", err)
     }
 
     // Convert encryptedPayload to a base64-encoded string.
-    encodedPayload := base64.StdEncoding.EncodeToString(encryptedPayload)
-
-    // Generate a unique message ID.
-    messageID := cc.generateMessageID(fromChain, toChain)
-
-    // Create the cross-chain message.
-    message := CrossChainMessage{
-        MessageID: messageID,
-        FromChain: fromChain,
-        ToChain:   toChain,
-        Payload:   encodedPayload,
-        Timestamp: time.Now(),
-        Status:    "sent",
-    }
-
-    // Validate the message before sending.
-    validationHash, err := cc.validateMessage(message)
-    if err != nil {
-        return "", fmt.Errorf("message validation failed: %v", err)
-    }
-
-    message.ValidationHash = validationHash
-
-    // Store the message in the pool for future confirmation.
-    cc.MessagePool[messageID] = message
+    m.Payload = base64.StdEncoding.EncodeToString(encryptedPayload)
+    m.Timestamp = time.Now()
+    m.Status = "sent"
+    m.ValidationHash = messageValidationHash(m)
 
     // Log the message sending to the ledger.
-    err = cc.logMessageToLedger(message)
+    err = cc.logMessageToLedger(m)
     if err != nil {
-        return "", fmt.Errorf("failed to log message to ledger: %v", err)
+        return fmt.Errorf("failed to log message to ledger: %v", err)
     }
 
-    fmt.Printf("Cross-chain message sent. Message ID: %s\n", messageID)
-    return messageID, nil
+    // Store the message in the pool for future confirmation only once it has
+    // been logged, so a failed send can be retried with the same ID.
+    cc.MessagePool[m.MessageID] = m
+
+    fmt.Printf("Cross-chain message sent. Message ID: %s\n", m.MessageID)
+    return nil
 }
 
-// ConfirmMessage confirms that a message was received and processed by the destination chain
-func (cc *CrossChainCommunication) ConfirmMessage(messageID string) error {
+// ReceiveMessage confirms a pooled message once its ValidationHash still
+// matches its contents and proof is a valid ASN.1 ECDSA signature over that
+// hash by one of the validators. Messages failing either check stay unconfirmed.
+func (cc *CrossChainCommunication) ReceiveMessage(messageID string, proof []byte) error {
     cc.mutex.Lock()
     defer cc.mutex.Unlock()
 
@@ -87,40 +85,58 @@ func (cc *CrossChainCommunication) ConfirmMessage(messageID string) error {
     if !exists {
         return fmt.Errorf("message ID %s not found", messageID)
     }
+    if message.Status == "confirmed" {
+        return fmt.Errorf("message ID %s has already been confirmed", messageID)
+    }
+
+    // Verify the message has not been altered since it was sent
+    if messageValidationHash(message) != message.ValidationHash {
+        return fmt.Errorf("validation hash mismatch for message %s", messageID)
+    }
+
+    // Verify a validator attested to the message
+    digest, err := hex.DecodeString(message.ValidationHash)
+    if err != nil {
+        return fmt.Errorf("invalid validation hash for message %s: %v", messageID, err)
+    }
+    validator, ok := cc.verifyValidatorProof(digest, proof)
+    if !ok {
+        return fmt.Errorf("no validator proof found for message %s", messageID)
+    }
 
     // Mark the message as confirmed
     message.Status = "confirmed"
+    cc.MessagePool[messageID] = message
 
     // Log the confirmation to the ledger
-    err := cc.logMessageConfirmationToLedger(message)
+    err = cc.logMessageConfirmationToLedger(message)
     if err != nil {
         return fmt.Errorf("failed to log message confirmation: %v", err)
     }
 
-    // Remove the message from the pool after confirmation
-    delete(cc.MessagePool, messageID)
-
-    fmt.Printf("Cross-chain message confirmed. Message ID: %s\n", messageID)
+    fmt.Printf("Cross-chain message confirmed by validator %s. Message ID: %s\n", validator, messageID)
     return nil
 }
 
-// validateMessage validates the message across validators
-func (cc *CrossChainCommunication) validateMessage(message CrossChainMessage) (string, error) {
-    // Select the first validator for simplicity
-    validator := cc.Validators[0]
+// verifyValidatorProof returns the address of the validator whose key
+// verifies proof over digest.
+func (cc *CrossChainCommunication) verifyValidatorProof(digest, proof []byte) (string, bool) {
+    for _, validator := range cc.Validators {
+        if validator.PublicKey != nil && ecdsa.VerifyASN1(validator.PublicKey, digest, proof) {
+            return validator.Address, true
+        }
+    }
+    return "", false
+}
 
-    // Create the validation hash
-    validationData := fmt.Sprintf("%s%s%s%d", message.FromChain, message.ToChain, message.Payload, message.Timestamp.UnixNano())
+// messageValidationHash computes the hash that authenticates a message
+func messageValidationHash(message CrossChainMessage) string {
+    validationData := fmt.Sprintf("%s%s%s%s%d", message.MessageID, message.FromChain, message.ToChain, message.Payload, message.Timestamp.UnixNano())
     hash := sha256.New()
     hash.Write([]byte(validationData))
-    validationHash := hex.EncodeToString(hash.Sum(nil))
-
-    fmt.Printf("Message validated by %s with hash: %s\n", validator.Address, validationHash)
-    return validationHash, nil
+    return hex.EncodeToString(hash.Sum(nil))
 }
 
-
-
 // logMessageToLedger logs the sending of a message to the ledger.
 func (cc *CrossChainCommunication) logMessageToLedger(message CrossChainMessage) error {
     // Serialize message data for encryption (optional for audit purposes).
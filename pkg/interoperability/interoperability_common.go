@@ -68,11 +68,13 @@ type BlockchainAgnosticManager struct {
 
 // Bridge represents the structure for a cross-chain bridge
 type Bridge struct {
-	SupportedChains  []string             // List of supported blockchain networks for the bridge
-	Validators       []common.Validator   // Validators for cross-chain transactions
-	LedgerInstance   *ledger.Ledger       // Ledger instance for logging bridge operations
-	BridgeBalance    map[string]float64   // Bridge balance for each supported token
-	mutex            sync.Mutex           // Mutex for thread-safe operations
+	SupportedChains []string                       // List of supported blockchain networks for the bridge
+	Validators      []common.Validator             // Validators for cross-chain transactions
+	LedgerInstance  *ledger.Ledger                 // Ledger instance for logging bridge operations
+	BridgeBalance   map[string]float64             // Bridge balance for each supported token
+	Transfers       map[string]*CrossChainTransfer // Every transfer seen by the bridge, by transfer ID
+	TransferTimeout time.Duration                  // Pending transfers older than this are refunded; zero uses DefaultTransferTimeout
+	mutex           sync.Mutex                     // Mutex for thread-safe operations
 }
 
 // CrossChainTransfer represents a transfer processed by the bridge.
@@ -1,9 +1,12 @@
 package ledger
 
 import (
+	"crypto/ecdsa"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/big"
 	"strings"
 	"sync"
@@ -291,6 +294,10 @@ func (l *AccountsWalletLedger) RecordMultiSigWallet(walletID string, owners []st
         return fmt.Errorf("requiredSigs must be between 1 and the number of owners")
     }
 
+    if l.MultiSigWallets == nil {
+        l.MultiSigWallets = make(map[string]MultiSigWallet)
+    }
+
     // Check if the wallet already exists
     if _, exists := l.MultiSigWallets[walletID]; exists {
         return fmt.Errorf("multi-signature wallet %s already exists", walletID)
@@ -408,6 +415,194 @@ func (l *AccountsWalletLedger) UpdateMultiSigWallet(walletID string, owners []st
 }
 
 
+// ProposeTx opens a transaction proposal on a multi-signature wallet. Only
+// wallet owners may propose.
+func (l *AccountsWalletLedger) ProposeTx(walletID string, tx Transaction, proposer string) (string, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    wallet, exists := l.MultiSigWallets[walletID]
+    if !exists {
+        return "", fmt.Errorf("multi-signature wallet %s not found", walletID)
+    }
+    if !isMultiSigOwner(wallet, proposer) {
+        return "", fmt.Errorf("proposer %s is not an owner of wallet %s", proposer, walletID)
+    }
+    if tx.Amount <= 0 || tx.ToAddress == "" {
+        return "", fmt.Errorf("transaction must have a recipient and a positive amount")
+    }
+
+    if l.MultiSigProposals == nil {
+        l.MultiSigProposals = make(map[string]*MultiSigProposal)
+    }
+    proposalID := generateUniqueID()
+    l.MultiSigProposals[proposalID] = &MultiSigProposal{
+        ProposalID: proposalID,
+        WalletID:   walletID,
+        Tx:         tx,
+        Proposer:   proposer,
+        Signatures: make(map[string][]byte),
+        CreatedAt:  time.Now(),
+    }
+
+    log.Printf("[INFO] MultiSig proposal %s opened on wallet %s by %s.", proposalID, walletID, proposer)
+    return proposalID, nil
+}
+
+// RegisterMultiSigOwnerKey registers the PEM-encoded ECDSA public key that
+// owner signs multi-signature proposals with.
+func (l *AccountsWalletLedger) RegisterMultiSigOwnerKey(walletID, owner, publicKeyPEM string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    wallet, exists := l.MultiSigWallets[walletID]
+    if !exists {
+        return fmt.Errorf("multi-signature wallet %s not found", walletID)
+    }
+    if !isMultiSigOwner(wallet, owner) {
+        return fmt.Errorf("%s is not an owner of wallet %s", owner, walletID)
+    }
+    publicKey, err := decodePublicKey(publicKeyPEM)
+    if err != nil {
+        return fmt.Errorf("invalid public key for owner %s: %v", owner, err)
+    }
+
+    if wallet.OwnerKeys == nil {
+        wallet.OwnerKeys = make(map[string]*ecdsa.PublicKey)
+    }
+    wallet.OwnerKeys[owner] = publicKey
+    l.MultiSigWallets[walletID] = wallet
+
+    log.Printf("[INFO] Public key registered for owner %s of MultiSig wallet %s.", owner, walletID)
+    return nil
+}
+
+// MultiSigDigest returns the SHA-256 digest an owner signs to approve a
+// proposal. It binds the wallet, the proposal and the transaction fields
+// that determine where funds move.
+func MultiSigDigest(walletID, proposalID string, tx Transaction) []byte {
+    canonical := fmt.Sprintf("%s|%s|%s|%s|%s|%.8f|%.8f|%s|%s",
+        walletID, proposalID, tx.TransactionID, tx.FromAddress, tx.ToAddress,
+        tx.Amount, tx.Fee, tx.TokenStandard, tx.TokenID)
+    digest := sha256.Sum256([]byte(canonical))
+    return digest[:]
+}
+
+// SignTx adds an owner's signature to a pending proposal and records it in
+// the ledger. The signature must be an ASN.1 ECDSA signature over
+// MultiSigDigest made with the owner's registered key. Non-owners, invalid
+// signatures and repeat signatures from the same owner are rejected.
+func (l *AccountsWalletLedger) SignTx(walletID, proposalID, owner string, sig []byte) error {
+    l.Lock()
+    defer l.Unlock()
+
+    wallet, proposal, err := l.pendingMultiSigProposal(walletID, proposalID)
+    if err != nil {
+        return err
+    }
+    if !isMultiSigOwner(wallet, owner) {
+        return fmt.Errorf("signer %s is not an owner of wallet %s", owner, walletID)
+    }
+    if _, signed := proposal.Signatures[owner]; signed {
+        return fmt.Errorf("owner %s has already signed proposal %s", owner, proposalID)
+    }
+    publicKey, registered := wallet.OwnerKeys[owner]
+    if !registered {
+        return fmt.Errorf("owner %s has no registered public key on wallet %s", owner, walletID)
+    }
+    digest := MultiSigDigest(walletID, proposalID, proposal.Tx)
+    if !ecdsa.VerifyASN1(publicKey, digest, sig) {
+        return fmt.Errorf("invalid signature from owner %s on proposal %s", owner, proposalID)
+    }
+
+    now := time.Now()
+    proposal.Signatures[owner] = sig
+    l.MultiSigSignatures = append(l.MultiSigSignatures, MultiSigSignature{
+        WalletID:   walletID,
+        ProposalID: proposalID,
+        Owner:      owner,
+        Signature:  sig,
+        SignedAt:   now,
+    })
+
+    log.Printf("[INFO] Owner %s signed MultiSig proposal %s (%d/%d signatures).", owner, proposalID, len(proposal.Signatures), wallet.RequiredSigs)
+    return nil
+}
+
+// ExecuteTx transfers the proposal's amount from the wallet to the
+// recipient once RequiredSigs distinct current owners have signed. A
+// proposal executes at most once.
+func (l *AccountsWalletLedger) ExecuteTx(walletID, proposalID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    wallet, proposal, err := l.pendingMultiSigProposal(walletID, proposalID)
+    if err != nil {
+        return err
+    }
+
+    signatures := 0
+    for owner := range proposal.Signatures {
+        if isMultiSigOwner(wallet, owner) {
+            signatures++
+        }
+    }
+    if signatures < wallet.RequiredSigs {
+        return fmt.Errorf("proposal %s has %d of %d required signatures", proposalID, signatures, wallet.RequiredSigs)
+    }
+
+    tx := proposal.Tx
+    source, exists := l.Balances[walletID]
+    if !exists {
+        return fmt.Errorf("account %s does not exist", walletID)
+    }
+    recipient, exists := l.Balances[tx.ToAddress]
+    if !exists {
+        return fmt.Errorf("account %s does not exist", tx.ToAddress)
+    }
+    if source.Balance < tx.Amount {
+        return fmt.Errorf("insufficient funds in wallet %s. Available: %.2f, Requested: %.2f", walletID, source.Balance, tx.Amount)
+    }
+
+    source.Balance -= tx.Amount
+    recipient.Balance += tx.Amount
+    l.Balances[walletID] = source
+    l.Balances[tx.ToAddress] = recipient
+
+    proposal.Executed = true
+    proposal.ExecutedAt = time.Now()
+
+    log.Printf("[SUCCESS] MultiSig proposal %s executed: %.2f transferred from wallet %s to %s.", proposalID, tx.Amount, walletID, tx.ToAddress)
+    return nil
+}
+
+// pendingMultiSigProposal returns the wallet and its unexecuted proposal.
+func (l *AccountsWalletLedger) pendingMultiSigProposal(walletID, proposalID string) (MultiSigWallet, *MultiSigProposal, error) {
+    wallet, exists := l.MultiSigWallets[walletID]
+    if !exists {
+        return MultiSigWallet{}, nil, fmt.Errorf("multi-signature wallet %s not found", walletID)
+    }
+    proposal, exists := l.MultiSigProposals[proposalID]
+    if !exists || proposal.WalletID != walletID {
+        return MultiSigWallet{}, nil, fmt.Errorf("proposal %s not found on wallet %s", proposalID, walletID)
+    }
+    if proposal.Executed {
+        return MultiSigWallet{}, nil, fmt.Errorf("proposal %s has already been executed", proposalID)
+    }
+    return wallet, proposal, nil
+}
+
+// isMultiSigOwner reports whether id is one of the wallet's owners.
+func isMultiSigOwner(wallet MultiSigWallet, id string) bool {
+    for _, owner := range wallet.Owners {
+        if owner == id {
+            return true
+        }
+    }
+    return false
+}
+
+
 // GetAccount retrieves an account by its ID from the ledger.
 func (l *AccountsWalletLedger) GetAccount(accountID string) (*Account, error) {
     l.Lock()
@@ -424,6 +619,13 @@ func (l *AccountsWalletLedger) GetAccount(accountID string) (*Account, error) {
         return nil, fmt.Errorf("account %s not found", accountID)
     }
 
+    // Release expired locks so every reader sees them returned to the balance
+    lockCount := len(account.LockedBalances)
+    ReleaseExpiredLocks(&account, time.Now())
+    if len(account.LockedBalances) != lockCount {
+        l.AccountsWalletLedgerState.Accounts[accountID] = account
+    }
+
     log.Printf("[INFO] Account %s retrieved successfully.", accountID)
     return &account, nil
 }
@@ -451,6 +653,45 @@ func (l *AccountsWalletLedger) UpdateAccount(accountID string, updatedAccount Ac
 }
 
 
+// TakeExpiredLock removes an expired lock from an account and takes its amount out of the
+// account, so the caller can pay it to another account. The lock is read before GetAccount
+// would release it back to the owner.
+func (l *AccountsWalletLedger) TakeExpiredLock(accountID, lockID string, now time.Time) (BalanceLock, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return BalanceLock{}, fmt.Errorf("account %s not found", accountID)
+    }
+
+    for i, lock := range account.LockedBalances {
+        if lock.ID != lockID {
+            continue
+        }
+        if lock.UnlockAt.After(now) {
+            return BalanceLock{}, fmt.Errorf("lock %s on account %s has not expired", lockID, accountID)
+        }
+
+        if lock.Escrowed {
+            account.LockedBalance -= lock.Amount
+            if account.LockedBalance < 0 {
+                account.LockedBalance = 0
+            }
+        } else if err := account.Debit(big.NewFloat(lock.Amount)); err != nil {
+            // Legacy locks were credited to Balance when they were placed
+            return BalanceLock{}, fmt.Errorf("failed to take lock %s from account %s: %v", lockID, accountID, err)
+        }
+        account.LockedBalances = append(account.LockedBalances[:i:i], account.LockedBalances[i+1:]...)
+        account.LastUpdated = now
+        l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+        log.Printf("[INFO] Lock %s of %.8f taken from account %s", lockID, lock.Amount, accountID)
+        return lock, nil
+    }
+    return BalanceLock{}, fmt.Errorf("lock %s not found on account %s", lockID, accountID)
+}
+
 // SaveBalanceSnapshot creates a snapshot of the current balance of an account.
 func (l *AccountsWalletLedger) SaveBalanceSnapshot(accountID string) error {
     l.Lock()
@@ -710,7 +951,7 @@ func (l *AccountsWalletLedger) RecordConnectionEvent(walletID string, event Conn
     if walletID == "" {
         return fmt.Errorf("walletID cannot be empty")
     }
-    if event.Timestamp.IsZero() {
+    if event.EventTime.IsZero() {
         return fmt.Errorf("event timestamp must be valid")
     }
 
@@ -730,6 +971,120 @@ func (l *AccountsWalletLedger) RecordConnectionEvent(walletID string, event Conn
 
 
 
+// RecordConnection logs a connection to an account from the given IP address and device.
+func (l *AccountsWalletLedger) RecordConnection(accountID, ip, device string, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if accountID == "" {
+        return fmt.Errorf("accountID cannot be empty")
+    }
+    if ip == "" {
+        return fmt.Errorf("ip address cannot be empty")
+    }
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return fmt.Errorf("account %s not found", accountID)
+    }
+
+    account.ConnectionEvents = append(account.ConnectionEvents, ConnectionEvent{
+        EventID:   generateUniqueID(),
+        WalletID:  accountID,
+        EventType: "connection",
+        EventTime: now,
+        IPAddress: ip,
+        Device:    device,
+    })
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    log.Printf("[INFO] Connection recorded for account %s from %s (%s)", accountID, ip, device)
+    return nil
+}
+
+// RecentConnections returns the account's connection events recorded within the given window.
+func (l *AccountsWalletLedger) RecentConnections(accountID string, within time.Duration, now time.Time) []ConnectionEvent {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return nil
+    }
+
+    cutoff := now.Add(-within)
+    var recent []ConnectionEvent
+    for _, event := range account.ConnectionEvents {
+        if event.IPAddress == "" {
+            continue
+        }
+        if !event.EventTime.Before(cutoff) && !event.EventTime.After(now) {
+            recent = append(recent, event)
+        }
+    }
+    return recent
+}
+
+// DetectUnusualConnection checks the latest connection from ip against the account's
+// earlier connections and flags the account as suspicious if the IP address or the
+// device has not been seen before. The first connection to an account sets the
+// baseline and is never flagged.
+func (l *AccountsWalletLedger) DetectUnusualConnection(accountID, ip string) (suspicious bool, reason string) {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return false, fmt.Sprintf("account %s not found", accountID)
+    }
+
+    var connections []ConnectionEvent
+    for _, event := range account.ConnectionEvents {
+        if event.IPAddress != "" {
+            connections = append(connections, event)
+        }
+    }
+
+    // Compare against everything before the latest connection from ip; if ip has
+    // not connected yet the whole history is the baseline.
+    prior := connections
+    device := ""
+    for i := len(connections) - 1; i >= 0; i-- {
+        if connections[i].IPAddress == ip {
+            prior = connections[:i]
+            device = connections[i].Device
+            break
+        }
+    }
+    if len(prior) == 0 {
+        return false, ""
+    }
+
+    knownIP, knownDevice := false, device == ""
+    for _, event := range prior {
+        if event.IPAddress == ip {
+            knownIP = true
+        }
+        if event.Device == device {
+            knownDevice = true
+        }
+    }
+
+    switch {
+    case !knownIP:
+        reason = fmt.Sprintf("connection from new IP address %s", ip)
+    case !knownDevice:
+        reason = fmt.Sprintf("connection from new device %s", device)
+    default:
+        return false, ""
+    }
+
+    account.IsSuspicious = true
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+    log.Printf("[WARNING] Unusual connection on account %s: %s", accountID, reason)
+    return true, reason
+}
+
 // GetContractExecutionHistory retrieves the contract execution history for a wallet.
 func (l *AccountsWalletLedger) GetContractExecutionHistory(walletID string) ([]ContractExecutionLog, error) {
     l.Lock()
@@ -754,6 +1109,74 @@ func (l *AccountsWalletLedger) GetContractExecutionHistory(walletID string) ([]C
     return account.ContractExecutionLogs, nil
 }
 
+// RecordContractExecution appends a contract execution log to the account.
+func (l *AccountsWalletLedger) RecordContractExecution(accountID string, execLog ContractExecutionLog) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if accountID == "" {
+        return fmt.Errorf("accountID cannot be empty")
+    }
+    if execLog.GasUsed < 0 {
+        return fmt.Errorf("gas used cannot be negative")
+    }
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return fmt.Errorf("account %s not found", accountID)
+    }
+
+    if execLog.LogID == "" {
+        execLog.LogID = generateUniqueID()
+    }
+    account.ContractExecutionLogs = append(account.ContractExecutionLogs, execLog)
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    log.Printf("[INFO] Contract execution %s recorded for account %s (gas %d)", execLog.LogID, accountID, execLog.GasUsed)
+    return nil
+}
+
+// ContractExecutions returns the account's contract executions with an execution
+// time in [from, to]. A zero from or to leaves that end of the range open.
+func (l *AccountsWalletLedger) ContractExecutions(accountID string, from, to time.Time) []ContractExecutionLog {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return nil
+    }
+
+    var executions []ContractExecutionLog
+    for _, execLog := range account.ContractExecutionLogs {
+        if !from.IsZero() && execLog.ExecutionTime.Before(from) {
+            continue
+        }
+        if !to.IsZero() && execLog.ExecutionTime.After(to) {
+            continue
+        }
+        executions = append(executions, execLog)
+    }
+    return executions
+}
+
+// ExecutionGasSummary returns the total gas used and the number of contract
+// executions recorded for the account.
+func (l *AccountsWalletLedger) ExecutionGasSummary(accountID string) (total, count int) {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return 0, 0
+    }
+
+    for _, execLog := range account.ContractExecutionLogs {
+        total += execLog.GasUsed
+    }
+    return total, len(account.ContractExecutionLogs)
+}
+
 
 
 // GetTokenMintHistory retrieves the minting history of a wallet.
@@ -865,6 +1288,167 @@ func (l *AccountsWalletLedger) RecordTokenMint(walletID string, mintRecord MintR
 
 
 
+// PlaceVerificationHold moves funds from the account's available balance into
+// its verification hold and flags the account for review.
+func (l *AccountsWalletLedger) PlaceVerificationHold(accountID string, amount float64, reason string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if amount <= 0 {
+        return fmt.Errorf("hold amount must be greater than zero")
+    }
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return fmt.Errorf("account %s not found", accountID)
+    }
+    if err := account.Debit(big.NewFloat(amount)); err != nil {
+        return fmt.Errorf("failed to place verification hold on account %s: %v", accountID, err)
+    }
+
+    account.VerificationHold += amount
+    account.VerificationReason = reason
+    account.RequiresReview = true
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    log.Printf("[INFO] Verification hold of %.8f placed on account %s: %s", amount, accountID, reason)
+    return nil
+}
+
+// ReleaseVerificationHold ends the account's review. An approved hold is
+// returned to the available balance; a rejected hold is forfeited.
+func (l *AccountsWalletLedger) ReleaseVerificationHold(accountID string, approve bool) error {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return fmt.Errorf("account %s not found", accountID)
+    }
+    if !account.RequiresReview && account.VerificationHold == 0 {
+        return fmt.Errorf("account %s has no verification hold", accountID)
+    }
+
+    held := account.VerificationHold
+    if approve && held > 0 {
+        if err := account.Credit(big.NewFloat(held)); err != nil {
+            return fmt.Errorf("failed to release verification hold on account %s: %v", accountID, err)
+        }
+    }
+
+    account.VerificationHold = 0
+    account.VerificationReason = ""
+    account.RequiresReview = false
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    if approve {
+        log.Printf("[INFO] Verification hold of %.8f released to account %s", held, accountID)
+    } else {
+        log.Printf("[WARNING] Verification hold of %.8f forfeited by account %s", held, accountID)
+    }
+    return nil
+}
+
+// mintBurnTolerance is the largest difference between issued funds and holdings
+// that ReconcileMintBurn still treats as matching.
+const mintBurnTolerance = 1e-9
+
+// RecordMint credits newly issued funds to an account and records the mint.
+func (l *AccountsWalletLedger) RecordMint(accountID string, amount float64, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return fmt.Errorf("account %s not found", accountID)
+    }
+    if err := account.Credit(big.NewFloat(amount)); err != nil {
+        return fmt.Errorf("failed to mint to account %s: %v", accountID, err)
+    }
+
+    minted, _ := big.NewFloat(amount).Int(nil)
+    account.MintRecords = append(account.MintRecords, MintRecord{
+        RecordID:  generateUniqueID(),
+        Amount:    minted,
+        MintedBy:  accountID,
+        Timestamp: now,
+        Quantity:  amount,
+    })
+    account.LastUpdated = now
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    log.Printf("[INFO] Minted %.8f to account %s", amount, accountID)
+    return nil
+}
+
+// RecordBurn debits burned funds from an account and records the burn.
+func (l *AccountsWalletLedger) RecordBurn(accountID string, amount float64, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return fmt.Errorf("account %s not found", accountID)
+    }
+    if err := account.Debit(big.NewFloat(amount)); err != nil {
+        return fmt.Errorf("failed to burn from account %s: %v", accountID, err)
+    }
+
+    burned, _ := big.NewFloat(amount).Int(nil)
+    account.BurnRecords = append(account.BurnRecords, BurnRecord{
+        RecordID:  generateUniqueID(),
+        Amount:    burned,
+        BurnedBy:  accountID,
+        Timestamp: now,
+        Quantity:  amount,
+    })
+    account.LastUpdated = now
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    log.Printf("[INFO] Burned %.8f from account %s", amount, accountID)
+    return nil
+}
+
+// ReconcileMintBurn checks that the account's net issuance (mints minus burns)
+// plus its net transfers (deposits minus withdrawals) equals everything the
+// account holds, including held, reserved, locked and verification-held funds. Records without a
+// Quantity fall back to their integer Amount.
+func (l *AccountsWalletLedger) ReconcileMintBurn(accountID string) (netIssued float64, matchesBalance bool, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return 0, false, fmt.Errorf("account %s not found", accountID)
+    }
+
+    for _, record := range account.MintRecords {
+        netIssued += recordQuantity(record.Quantity, record.Amount)
+    }
+    for _, record := range account.BurnRecords {
+        netIssued -= recordQuantity(record.Quantity, record.Amount)
+    }
+
+    expected := netIssued + account.TotalDeposited - account.TotalWithdrawn
+    holdings := account.Balance + account.HeldBalance + account.ReservedBalance + account.LockedBalance + account.VerificationHold
+    matchesBalance = math.Abs(expected-holdings) <= mintBurnTolerance*math.Max(1, math.Abs(holdings))
+
+    if !matchesBalance {
+        log.Printf("[WARNING] Mint/burn reconciliation mismatch for account %s: expected %.8f, holdings %.8f", accountID, expected, holdings)
+    }
+    return netIssued, matchesBalance, nil
+}
+
+// recordQuantity returns the exact quantity of a mint or burn record, falling
+// back to its integer amount for records created without one.
+func recordQuantity(quantity float64, amount *big.Int) float64 {
+    if quantity != 0 || amount == nil {
+        return quantity
+    }
+    value, _ := new(big.Float).SetInt(amount).Float64()
+    return value
+}
+
 // RecordCurrencyExchange logs a currency exchange event between tokens.
 func (l *AccountsWalletLedger) RecordCurrencyExchange(walletID string, exchange CurrencyExchange) error {
     l.Lock()
@@ -892,6 +1476,112 @@ func (l *AccountsWalletLedger) RecordCurrencyExchange(walletID string, exchange
     return nil
 }
 
+// nativeCurrency is the currency held in Account.Balance; all other currencies
+// are held in Account.CurrencyBalances.
+const nativeCurrency = "SYNN"
+
+// SetExchangeFeeRate sets the fraction of each converted amount charged as a fee.
+func (l *AccountsWalletLedger) SetExchangeFeeRate(rate float64) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if rate < 0 || rate >= 1 {
+        return fmt.Errorf("exchange fee rate must be in [0, 1)")
+    }
+    l.ExchangeFeeRate = rate
+    return nil
+}
+
+// ExchangeCurrency converts amount of fromCur held by the account into toCur at
+// the given rate. The configured fee is deducted from the converted amount and
+// the exchange is recorded on the account.
+func (l *AccountsWalletLedger) ExchangeCurrency(accountID, fromCur, toCur string, amount, rate float64, now time.Time) (*CurrencyExchange, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    if fromCur == "" || toCur == "" || fromCur == toCur {
+        return nil, fmt.Errorf("source and target currencies must be set and differ")
+    }
+    if amount <= 0 {
+        return nil, fmt.Errorf("exchange amount must be greater than zero")
+    }
+    if rate <= 0 {
+        return nil, fmt.Errorf("exchange rate must be greater than zero")
+    }
+
+    account, exists := l.AccountsWalletLedgerState.Accounts[accountID]
+    if !exists {
+        return nil, fmt.Errorf("account %s not found", accountID)
+    }
+
+    // Work on a copy of the currency balances so a failed exchange leaves the stored account untouched.
+    balances := make(map[string]float64, len(account.CurrencyBalances)+1)
+    for cur, balance := range account.CurrencyBalances {
+        balances[cur] = balance
+    }
+    account.CurrencyBalances = balances
+
+    converted := amount * rate
+    fee := converted * l.ExchangeFeeRate
+    received := converted - fee
+
+    if err := debitCurrency(&account, fromCur, amount); err != nil {
+        return nil, err
+    }
+    if err := creditCurrency(&account, toCur, received); err != nil {
+        return nil, err
+    }
+
+    exchangedFrom, _ := big.NewFloat(amount).Int(nil)
+    exchangedTo, _ := big.NewFloat(received).Int(nil)
+    exchange := CurrencyExchange{
+        ExchangeID:      generateUniqueID(),
+        FromCurrency:    fromCur,
+        ToCurrency:      toCur,
+        Amount:          exchangedFrom,
+        ExchangedAmount: exchangedTo,
+        ExchangeRate:    rate,
+        ExecutedAt:      now,
+        Quantity:        amount,
+        Received:        received,
+        Fee:             fee,
+    }
+    account.CurrencyExchanges = append(account.CurrencyExchanges, exchange)
+    account.LastUpdated = now
+    l.AccountsWalletLedgerState.Accounts[accountID] = account
+
+    log.Printf("[INFO] Account %s exchanged %.8f %s for %.8f %s (fee %.8f)", accountID, amount, fromCur, received, toCur, fee)
+    return &exchange, nil
+}
+
+// debitCurrency removes amount of the given currency from the account.
+func debitCurrency(account *Account, currency string, amount float64) error {
+    if currency == nativeCurrency {
+        return account.Debit(big.NewFloat(amount))
+    }
+
+    available := account.CurrencyBalances[currency]
+    if available < amount {
+        return fmt.Errorf("insufficient %s balance in account %s. Available: %.8f, Requested: %.8f",
+            currency, account.Address, available, amount)
+    }
+    account.CurrencyBalances[currency] = available - amount
+    return nil
+}
+
+// creditCurrency adds amount of the given currency to the account.
+func creditCurrency(account *Account, currency string, amount float64) error {
+    if currency == nativeCurrency {
+        return account.Credit(big.NewFloat(amount))
+    }
+
+    if account.CurrencyBalances == nil {
+        account.CurrencyBalances = make(map[string]float64)
+    }
+    account.CurrencyBalances[currency] += amount
+    return nil
+}
+
 
 // RecordWalletNaming logs a custom name for a wallet.
 func (l *AccountsWalletLedger) RecordWalletNaming(walletID, customName string) error {
@@ -1064,3 +1754,102 @@ func RegisterLedgerInstance(walletID string, ledger *Ledger) error {
 }
 
 
+
+
+// preciseBalancePrec is the mantissa precision, in bits, used for Account.PreciseBalance.
+const preciseBalancePrec = 256
+
+// preciseBalance returns the account's high-precision balance. It is reseeded from Balance
+// when it has never been set, or when Balance was written directly since the last Credit or
+// Debit, so a debit is never approved against a stale value.
+func (a *Account) preciseBalance() *big.Float {
+	if a.PreciseBalance == nil || a.Balance != a.preciseBalanceOf {
+		a.PreciseBalance = new(big.Float).SetPrec(preciseBalancePrec).SetFloat64(a.Balance)
+		a.preciseBalanceOf = a.Balance
+	}
+	return a.PreciseBalance
+}
+
+// setPreciseBalance stores balance as the account's high-precision balance and mirrors it
+// into Balance.
+func (a *Account) setPreciseBalance(balance *big.Float) {
+	a.PreciseBalance = balance
+	a.Balance, _ = balance.Float64()
+	a.preciseBalanceOf = a.Balance
+	a.LastUpdated = time.Now()
+}
+
+// Credit adds a high-precision amount to the account balance. It is not safe for concurrent
+// use: callers change the ledger-held account under the ledger lock, or write their copy
+// back with UpdateAccount.
+func (a *Account) Credit(amount *big.Float) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("credit amount must be greater than zero")
+	}
+
+	a.setPreciseBalance(new(big.Float).SetPrec(preciseBalancePrec).Add(a.preciseBalance(), amount))
+	return nil
+}
+
+// Debit subtracts a high-precision amount from the account balance, rejecting debits that
+// would leave it negative. Like Credit, it is not safe for concurrent use.
+func (a *Account) Debit(amount *big.Float) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("debit amount must be greater than zero")
+	}
+
+	current := a.preciseBalance()
+	if current.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient funds in account %s. Available: %s, Requested: %s",
+			a.Address, current.Text('f', -1), amount.Text('f', -1))
+	}
+
+	a.setPreciseBalance(new(big.Float).SetPrec(preciseBalancePrec).Sub(current, amount))
+	return nil
+}
+
+// ReleaseExpiredLocks removes the locks whose UnlockAt has passed and returns the total
+// moved back into the available balance. Only escrowed locks hold their amount outside
+// Balance; legacy locks were credited to Balance when they were placed, so they are
+// dropped without crediting it again.
+func ReleaseExpiredLocks(account *Account, now time.Time) (released float64) {
+	if account == nil || len(account.LockedBalances) == 0 {
+		return 0
+	}
+
+	var remaining []BalanceLock
+	expired := false
+	for _, lock := range account.LockedBalances {
+		if lock.UnlockAt.After(now) {
+			remaining = append(remaining, lock)
+			continue
+		}
+		expired = true
+		if lock.Escrowed {
+			released += lock.Amount
+		}
+	}
+	if !expired {
+		return 0
+	}
+	if released == 0 {
+		account.LockedBalances = remaining
+		account.LastUpdated = now
+		return 0
+	}
+
+	if err := account.Credit(big.NewFloat(released)); err != nil {
+		// Keep the expired escrowed locks so the funds can be released on a later sweep.
+		log.Printf("[ERROR] Failed to release expired locks for account %s: %v", account.Address, err)
+		return 0
+	}
+	account.LockedBalances = remaining
+	account.LockedBalance -= released
+	if account.LockedBalance < 0 {
+		account.LockedBalance = 0
+	}
+	account.LastUpdated = now
+
+	log.Printf("[INFO] Released %.2f in expired locks for account %s", released, account.Address)
+	return released
+}
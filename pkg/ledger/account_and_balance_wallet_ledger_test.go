@@ -0,0 +1,339 @@
+package ledger
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDebitSeesDirectBalanceWrites(t *testing.T) {
+	account := Account{Address: "acct-stale", Balance: 100}
+	if err := account.Credit(big.NewFloat(1)); err != nil {
+		t.Fatalf("Credit: %v", err)
+	}
+
+	// A writer outside Credit/Debit lowers the balance.
+	account.Balance = 10
+
+	if err := account.Debit(big.NewFloat(50)); err == nil {
+		t.Fatal("expected the debit to be checked against the current balance, not a stale one")
+	}
+	if err := account.Debit(big.NewFloat(4)); err != nil {
+		t.Fatalf("Debit: %v", err)
+	}
+	if account.Balance != 6 {
+		t.Fatalf("Balance = %v, want 6", account.Balance)
+	}
+}
+
+func TestConcurrentMintBurnSerialisedByLedger(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-concurrent", Balance: 1000})
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			l.RecordMint("acct-concurrent", 1, now)
+		}()
+		go func() {
+			defer wg.Done()
+			l.RecordBurn("acct-concurrent", 1, now)
+		}()
+	}
+	wg.Wait()
+
+	if got := l.AccountsWalletLedgerState.Accounts["acct-concurrent"].Balance; got != 1000 {
+		t.Fatalf("Balance = %v, want 1000", got)
+	}
+}
+
+func newAccountTestLedger(account Account) *AccountsWalletLedger {
+	l := &AccountsWalletLedger{}
+	l.AccountsWalletLedgerState.Accounts = map[string]Account{account.Address: account}
+	return l
+}
+
+func TestGetAccountReleasesExpiredEscrowedLock(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	l := newAccountTestLedger(Account{
+		Address:        "acct-escrow",
+		Balance:        10,
+		LockedBalance:  5,
+		LockedBalances: []BalanceLock{{ID: "lock-1", Amount: 5, UnlockAt: past, Escrowed: true}},
+	})
+
+	account, err := l.GetAccount("acct-escrow")
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if account.Balance != 15 || account.LockedBalance != 0 || len(account.LockedBalances) != 0 {
+		t.Fatalf("account = balance %v, locked %v, locks %d; want 15, 0, 0",
+			account.Balance, account.LockedBalance, len(account.LockedBalances))
+	}
+	if stored := l.AccountsWalletLedgerState.Accounts["acct-escrow"]; stored.Balance != 15 {
+		t.Fatalf("stored Balance = %v, want the release persisted as 15", stored.Balance)
+	}
+}
+
+func TestGetAccountDropsExpiredLegacyLockWithoutCrediting(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	l := newAccountTestLedger(Account{
+		Address:        "acct-legacy",
+		Balance:        10,
+		LockedBalances: []BalanceLock{{ID: "lock-1", Amount: 5, UnlockAt: past}},
+	})
+
+	account, err := l.GetAccount("acct-legacy")
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if account.Balance != 10 || len(account.LockedBalances) != 0 {
+		t.Fatalf("account = balance %v, locks %d; want 10, 0", account.Balance, len(account.LockedBalances))
+	}
+}
+
+func TestReleaseExpiredLocksKeepsLocksWhenCreditFails(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	account := &Account{
+		Address:        "acct-bad-lock",
+		Balance:        10,
+		LockedBalances: []BalanceLock{{ID: "lock-1", Amount: -5, UnlockAt: past, Escrowed: true}},
+	}
+
+	if released := ReleaseExpiredLocks(account, time.Now()); released != 0 {
+		t.Fatalf("released = %v, want 0 when the credit fails", released)
+	}
+	if len(account.LockedBalances) != 1 || account.Balance != 10 {
+		t.Fatalf("account = balance %v, locks %d; want 10 with the lock kept", account.Balance, len(account.LockedBalances))
+	}
+}
+
+func TestTakeExpiredLockRequiresExpiry(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	l := newAccountTestLedger(Account{
+		Address:        "acct-take",
+		LockedBalance:  5,
+		LockedBalances: []BalanceLock{{ID: "lock-1", Amount: 5, UnlockAt: future, Escrowed: true}},
+	})
+
+	if _, err := l.TakeExpiredLock("acct-take", "lock-1", time.Now()); err == nil {
+		t.Fatal("expected an error taking a lock that has not expired")
+	}
+	lock, err := l.TakeExpiredLock("acct-take", "lock-1", future)
+	if err != nil {
+		t.Fatalf("TakeExpiredLock: %v", err)
+	}
+	stored := l.AccountsWalletLedgerState.Accounts["acct-take"]
+	if lock.Amount != 5 || stored.LockedBalance != 0 || stored.Balance != 0 {
+		t.Fatalf("lock %v, stored locked %v balance %v; want 5 taken and nothing left", lock.Amount, stored.LockedBalance, stored.Balance)
+	}
+}
+
+func TestDetectUnusualConnectionFlagsNewIP(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-conn"})
+	now := time.Now()
+
+	if err := l.RecordConnection("acct-conn", "10.0.0.1", "laptop", now.Add(-time.Hour)); err != nil {
+		t.Fatalf("RecordConnection: %v", err)
+	}
+	if err := l.RecordConnection("acct-conn", "203.0.113.9", "laptop", now); err != nil {
+		t.Fatalf("RecordConnection: %v", err)
+	}
+
+	suspicious, reason := l.DetectUnusualConnection("acct-conn", "203.0.113.9")
+	if !suspicious || reason == "" {
+		t.Fatalf("DetectUnusualConnection = %v, %q; want flagged with a reason", suspicious, reason)
+	}
+	if !l.AccountsWalletLedgerState.Accounts["acct-conn"].IsSuspicious {
+		t.Fatal("expected the account to be marked suspicious")
+	}
+}
+
+func TestDetectUnusualConnectionAcceptsKnownIP(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-conn"})
+	now := time.Now()
+
+	for i, at := range []time.Time{now.Add(-2 * time.Hour), now} {
+		if err := l.RecordConnection("acct-conn", "10.0.0.1", "laptop", at); err != nil {
+			t.Fatalf("RecordConnection %d: %v", i, err)
+		}
+	}
+
+	if suspicious, reason := l.DetectUnusualConnection("acct-conn", "10.0.0.1"); suspicious {
+		t.Fatalf("known IP flagged as suspicious: %s", reason)
+	}
+	if l.AccountsWalletLedgerState.Accounts["acct-conn"].IsSuspicious {
+		t.Fatal("account should not be marked suspicious")
+	}
+	if recent := l.RecentConnections("acct-conn", time.Hour, now); len(recent) != 1 {
+		t.Fatalf("RecentConnections = %d events, want 1 within the hour", len(recent))
+	}
+}
+
+func newContractExecutionLedger(t *testing.T, base time.Time) *AccountsWalletLedger {
+	t.Helper()
+	l := newAccountTestLedger(Account{Address: "acct-exec"})
+	for i, gas := range []int{100, 250, 400} {
+		execLog := ContractExecutionLog{
+			ContractID:    "contract-1",
+			ExecutionTime: base.Add(time.Duration(i) * time.Hour),
+			GasUsed:       gas,
+		}
+		if err := l.RecordContractExecution("acct-exec", execLog); err != nil {
+			t.Fatalf("RecordContractExecution: %v", err)
+		}
+	}
+	return l
+}
+
+func TestContractExecutionsFiltersByTime(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newContractExecutionLedger(t, base)
+
+	executions := l.ContractExecutions("acct-exec", base.Add(30*time.Minute), base.Add(2*time.Hour))
+	if len(executions) != 2 || executions[0].GasUsed != 250 || executions[1].GasUsed != 400 {
+		t.Fatalf("ContractExecutions = %+v, want the last two executions", executions)
+	}
+	if all := l.ContractExecutions("acct-exec", time.Time{}, time.Time{}); len(all) != 3 {
+		t.Fatalf("open range returned %d executions, want 3", len(all))
+	}
+	if executions[0].LogID == "" {
+		t.Fatal("expected a log ID to be assigned")
+	}
+}
+
+func TestExecutionGasSummaryAggregatesGas(t *testing.T) {
+	l := newContractExecutionLedger(t, time.Now())
+
+	total, count := l.ExecutionGasSummary("acct-exec")
+	if total != 750 || count != 3 {
+		t.Fatalf("ExecutionGasSummary = %d, %d; want 750, 3", total, count)
+	}
+	if err := l.RecordContractExecution("acct-exec", ContractExecutionLog{GasUsed: -1}); err == nil {
+		t.Fatal("expected negative gas to be rejected")
+	}
+}
+
+func TestReconcileMintBurnMatchesBalance(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-mint", Balance: 20, TotalDeposited: 20})
+	now := time.Now()
+
+	if err := l.RecordMint("acct-mint", 100.5, now); err != nil {
+		t.Fatalf("RecordMint: %v", err)
+	}
+	if err := l.RecordBurn("acct-mint", 30.25, now); err != nil {
+		t.Fatalf("RecordBurn: %v", err)
+	}
+
+	netIssued, matches, err := l.ReconcileMintBurn("acct-mint")
+	if err != nil {
+		t.Fatalf("ReconcileMintBurn: %v", err)
+	}
+	if netIssued != 70.25 || !matches {
+		t.Fatalf("ReconcileMintBurn = %v, %v; want 70.25, true", netIssued, matches)
+	}
+}
+
+func TestReconcileMintBurnDetectsDiscrepancy(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-mint"})
+	if err := l.RecordMint("acct-mint", 50, time.Now()); err != nil {
+		t.Fatalf("RecordMint: %v", err)
+	}
+
+	// Funds appear on the account without a matching mint or deposit.
+	account := l.AccountsWalletLedgerState.Accounts["acct-mint"]
+	account.Balance += 5
+	l.AccountsWalletLedgerState.Accounts["acct-mint"] = account
+
+	if _, matches, err := l.ReconcileMintBurn("acct-mint"); err != nil || matches {
+		t.Fatalf("ReconcileMintBurn matches = %v, err = %v; want a mismatch", matches, err)
+	}
+}
+
+func TestExchangeCurrencyAppliesRateAndFee(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-fx", Balance: 100})
+	if err := l.SetExchangeFeeRate(0.01); err != nil {
+		t.Fatalf("SetExchangeFeeRate: %v", err)
+	}
+
+	exchange, err := l.ExchangeCurrency("acct-fx", nativeCurrency, "USD", 50, 2, time.Now())
+	if err != nil {
+		t.Fatalf("ExchangeCurrency: %v", err)
+	}
+	if exchange.Received != 99 || exchange.Fee != 1 {
+		t.Fatalf("exchange received %v with fee %v, want 99 with fee 1", exchange.Received, exchange.Fee)
+	}
+
+	account := l.AccountsWalletLedgerState.Accounts["acct-fx"]
+	if account.Balance != 50 || account.CurrencyBalances["USD"] != 99 {
+		t.Fatalf("balances = %v SYNN, %v USD; want 50, 99", account.Balance, account.CurrencyBalances["USD"])
+	}
+	if len(account.CurrencyExchanges) != 1 {
+		t.Fatalf("recorded %d exchanges, want 1", len(account.CurrencyExchanges))
+	}
+}
+
+func TestExchangeCurrencyRejectsInsufficientBalance(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-fx", Balance: 10, CurrencyBalances: map[string]float64{"USD": 5}})
+
+	if _, err := l.ExchangeCurrency("acct-fx", "USD", nativeCurrency, 6, 0.5, time.Now()); err == nil {
+		t.Fatal("expected an exchange above the USD balance to be rejected")
+	}
+	if _, err := l.ExchangeCurrency("acct-fx", nativeCurrency, "USD", 5, 0, time.Now()); err == nil {
+		t.Fatal("expected a non-positive rate to be rejected")
+	}
+
+	account := l.AccountsWalletLedgerState.Accounts["acct-fx"]
+	if account.Balance != 10 || account.CurrencyBalances["USD"] != 5 || len(account.CurrencyExchanges) != 0 {
+		t.Fatalf("account changed by rejected exchanges: %+v", account)
+	}
+}
+
+func TestPlaceVerificationHoldReducesAvailableBalance(t *testing.T) {
+	l := newAccountTestLedger(Account{Address: "acct-hold", Balance: 100})
+
+	if err := l.PlaceVerificationHold("acct-hold", 40, "large inbound transfer"); err != nil {
+		t.Fatalf("PlaceVerificationHold: %v", err)
+	}
+	account := l.AccountsWalletLedgerState.Accounts["acct-hold"]
+	if account.Balance != 60 || account.VerificationHold != 40 || !account.RequiresReview {
+		t.Fatalf("account = balance %v, hold %v, review %v; want 60, 40, true", account.Balance, account.VerificationHold, account.RequiresReview)
+	}
+	if err := l.PlaceVerificationHold("acct-hold", 100, "too much"); err == nil {
+		t.Fatal("expected a hold above the available balance to be rejected")
+	}
+}
+
+func TestReleaseVerificationHold(t *testing.T) {
+	tests := []struct {
+		name        string
+		approve     bool
+		wantBalance float64
+	}{
+		{"approved hold is restored", true, 100},
+		{"rejected hold is forfeited", false, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newAccountTestLedger(Account{Address: "acct-hold", Balance: 100})
+			if err := l.PlaceVerificationHold("acct-hold", 40, "review"); err != nil {
+				t.Fatalf("PlaceVerificationHold: %v", err)
+			}
+
+			if err := l.ReleaseVerificationHold("acct-hold", tt.approve); err != nil {
+				t.Fatalf("ReleaseVerificationHold: %v", err)
+			}
+			account := l.AccountsWalletLedgerState.Accounts["acct-hold"]
+			if account.Balance != tt.wantBalance || account.VerificationHold != 0 || account.RequiresReview {
+				t.Fatalf("account = balance %v, hold %v, review %v; want %v, 0, false",
+					account.Balance, account.VerificationHold, account.RequiresReview, tt.wantBalance)
+			}
+			if err := l.ReleaseVerificationHold("acct-hold", tt.approve); err == nil {
+				t.Fatal("expected releasing an already released hold to fail")
+			}
+		})
+	}
+}
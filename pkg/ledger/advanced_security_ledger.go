@@ -6,6 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -416,6 +421,55 @@ func (l *AdvancedSecurityLedger) RecordEventMonitoringStatus(eventID, status str
 	return nil
 }
 
+// RecordMonitoredEvent records an event seen by a monitor, activating the monitor if needed.
+func (l *AdvancedSecurityLedger) RecordMonitoredEvent(monitorID, lastEvent string, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.EventMonitoringStatus == nil {
+		l.EventMonitoringStatus = make(map[string]EventMonitoringStatus)
+	}
+
+	status := l.EventMonitoringStatus[monitorID]
+	status.MonitorID = monitorID
+	status.Active = true
+	status.LastEvent = lastEvent
+	status.LastUpdated = now
+	status.EventCount++
+	l.EventMonitoringStatus[monitorID] = status
+}
+
+// StaleMonitors returns the IDs of active monitors that have not recorded an event within maxAge.
+func (l *AdvancedSecurityLedger) StaleMonitors(maxAge time.Duration, now time.Time) []string {
+	l.Lock()
+	defer l.Unlock()
+
+	var stale []string
+	for id, status := range l.EventMonitoringStatus {
+		if status.Active && now.Sub(status.LastUpdated) > maxAge {
+			stale = append(stale, id)
+		}
+	}
+	sort.Strings(stale)
+
+	return stale
+}
+
+// MonitoringSummary counts the active and inactive event monitors.
+func (l *AdvancedSecurityLedger) MonitoringSummary() (active, inactive int) {
+	l.Lock()
+	defer l.Unlock()
+
+	for _, status := range l.EventMonitoringStatus {
+		if status.Active {
+			active++
+		} else {
+			inactive++
+		}
+	}
+	return active, inactive
+}
+
 
 
 // RecordNodeAccessLimit logs the access frequency limit set for a specific node.
@@ -461,6 +515,71 @@ func (l *AdvancedSecurityLedger) RecordAccessFrequency(nodeID string, frequency
 	return nil
 }
 
+// SetNodeAccessLimit sets the maximum number of accesses a node may make within AccessLimitWindow.
+func (l *AdvancedSecurityLedger) SetNodeAccessLimit(nodeID string, maxAccesses int, now time.Time) error {
+	if nodeID == "" {
+		return fmt.Errorf("node ID cannot be empty")
+	}
+	if maxAccesses <= 0 {
+		return fmt.Errorf("max accesses must be greater than zero")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.NodeAccessQuotas == nil {
+		l.NodeAccessQuotas = make(map[string]AccessLimit)
+	}
+	l.NodeAccessQuotas[nodeID] = AccessLimit{
+		NodeID:      nodeID,
+		MaxAccesses: maxAccesses,
+		SetAt:       now,
+	}
+
+	log.Printf("[INFO] Access limit for node %s set to %d", nodeID, maxAccesses)
+	return nil
+}
+
+// CheckNodeAccess counts an access by a node and rejects it once the node has reached its
+// MaxAccesses within the current window. Nodes without a limit are always allowed.
+func (l *AdvancedSecurityLedger) CheckNodeAccess(nodeID string, now time.Time) (allowed bool, err error) {
+	if nodeID == "" {
+		return false, fmt.Errorf("node ID cannot be empty")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	limit, limited := l.NodeAccessQuotas[nodeID]
+	if !limited {
+		return true, nil
+	}
+
+	window := l.AccessLimitWindow
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	if l.NodeAccessCounts == nil {
+		l.NodeAccessCounts = make(map[string]AccessFrequency)
+	}
+	frequency, exists := l.NodeAccessCounts[nodeID]
+	if !exists || now.Sub(frequency.WindowStart) >= window {
+		frequency = AccessFrequency{AccessID: nodeID, WindowStart: now}
+	}
+
+	if frequency.Frequency >= limit.MaxAccesses {
+		l.NodeAccessCounts[nodeID] = frequency
+		log.Printf("[WARNING] Access rejected for node %s: %d accesses within %s", nodeID, frequency.Frequency, window)
+		return false, fmt.Errorf("node %s exceeded its access limit of %d per %s", nodeID, limit.MaxAccesses, window)
+	}
+
+	frequency.Frequency++
+	frequency.LastAccess = now
+	l.NodeAccessCounts[nodeID] = frequency
+	return true, nil
+}
+
 
 // RecordSecurityLevelPolicy records the security policy and timestamp in the ledger.
 func (l *AdvancedSecurityLedger) RecordSecurityLevelPolicy(policy string, timestamp string) error {
@@ -486,6 +605,78 @@ func (l *AdvancedSecurityLedger) RecordSecurityLevelPolicy(policy string, timest
 	return nil
 }
 
+// securityLevelRestrictions lists the operations disabled at each allowed security level.
+var securityLevelRestrictions = map[string][]string{
+	"low":      {},
+	"medium":   {"bulk-export"},
+	"high":     {"bulk-export", "contract-deployment", "remote-administration"},
+	"critical": {"bulk-export", "contract-deployment", "remote-administration", "token-transfer"},
+}
+
+// SetSecurityLevel moves a policy to a new security level, records the change for audit and
+// disables the operations restricted at that level.
+func (l *AdvancedSecurityLedger) SetSecurityLevel(policyID, level string, by string, now time.Time) (*SecurityPolicyRecord, error) {
+	if policyID == "" {
+		return nil, fmt.Errorf("policy ID cannot be empty")
+	}
+	if by == "" {
+		return nil, fmt.Errorf("security level change must name who made it")
+	}
+	if _, allowed := securityLevelRestrictions[level]; !allowed {
+		return nil, fmt.Errorf("invalid security level %s", level)
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.SecurityLevels == nil {
+		l.SecurityLevels = make(map[string]SecurityLevelPolicy)
+	}
+
+	previous := l.SecurityLevels[policyID].Level
+	l.SecurityLevels[policyID] = SecurityLevelPolicy{
+		PolicyID: policyID,
+		Level:    level,
+		SetAt:    now,
+	}
+
+	// Rebuild the disabled operations so lowering one policy cannot lift another's restrictions
+	policyIDs := make([]string, 0, len(l.SecurityLevels))
+	for id := range l.SecurityLevels {
+		policyIDs = append(policyIDs, id)
+	}
+	sort.Strings(policyIDs)
+	l.DisabledOperations = make(map[string]string)
+	for _, id := range policyIDs {
+		for _, operation := range securityLevelRestrictions[l.SecurityLevels[id].Level] {
+			if _, disabled := l.DisabledOperations[operation]; !disabled {
+				l.DisabledOperations[operation] = id
+			}
+		}
+	}
+
+	record := SecurityPolicyRecord{
+		Policy:        policyID,
+		Timestamp:     now.Format(time.RFC3339),
+		PreviousLevel: previous,
+		Level:         level,
+		ChangedBy:     by,
+	}
+	l.SecurityPolicies = append(l.SecurityPolicies, record)
+
+	log.Printf("[INFO] Security level of policy %s changed from %q to %q by %s", policyID, previous, level, by)
+	return &record, nil
+}
+
+// IsOperationDisabled reports whether an operation is disabled by the current security levels.
+func (l *AdvancedSecurityLedger) IsOperationDisabled(operation string) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	_, disabled := l.DisabledOperations[operation]
+	return disabled
+}
+
 
 
 
@@ -543,6 +734,76 @@ func (l *AdvancedSecurityLedger) RecordFirmwareCheckStatus(deviceID, status stri
 }
 
 
+// defaultAnomalyCorrelationThreshold is used when AnomalyCorrelationThreshold has not been configured.
+const defaultAnomalyCorrelationThreshold = 3
+
+// CorrelateAnomalies groups consensus anomalies with the same description detected within
+// the window and raises an incident event for each group that reaches the correlation threshold.
+// Anomalies already correlated into an incident are not counted again.
+func (l *AdvancedSecurityLedger) CorrelateAnomalies(window time.Duration, now time.Time) ([]IncidentEvent, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("correlation window must be positive")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	threshold := l.AnomalyCorrelationThreshold
+	if threshold <= 0 {
+		threshold = defaultAnomalyCorrelationThreshold
+	}
+
+	clusters := make(map[string][]string)
+	for id, anomaly := range l.ConsensusAnomalies {
+		if _, correlated := l.CorrelatedAnomalies[id]; correlated {
+			continue
+		}
+		if anomaly.DetectedAt.After(now) || now.Sub(anomaly.DetectedAt) > window {
+			continue
+		}
+		clusters[anomaly.Description] = append(clusters[anomaly.Description], id)
+	}
+
+	descriptions := make([]string, 0, len(clusters))
+	for description := range clusters {
+		descriptions = append(descriptions, description)
+	}
+	sort.Strings(descriptions)
+
+	var incidents []IncidentEvent
+	for _, description := range descriptions {
+		ids := clusters[description]
+		if len(ids) < threshold {
+			continue
+		}
+
+		if l.IncidentEvents == nil {
+			l.IncidentEvents = make(map[string]IncidentEvent)
+		}
+		if l.CorrelatedAnomalies == nil {
+			l.CorrelatedAnomalies = make(map[string]string)
+		}
+
+		event := IncidentEvent{
+			EventID:     generateUniqueID(),
+			Description: fmt.Sprintf("%d correlated consensus anomalies within %s: %s", len(ids), window, description),
+			RecordedAt:  now,
+		}
+		event.IncidentID = event.EventID
+		l.IncidentEvents[event.EventID] = event
+
+		for _, id := range ids {
+			l.CorrelatedAnomalies[id] = event.EventID
+		}
+		incidents = append(incidents, event)
+
+		log.Printf("[WARNING] Incident %s raised from %d correlated consensus anomalies", event.EventID, len(ids))
+	}
+
+	return incidents, nil
+}
+
+
 // RecordConsensusAnomalyDetectionStatus logs the consensus anomaly detection status with a timestamp.
 func (l *AdvancedSecurityLedger) RecordConsensusAnomalyDetectionStatus(status, timestamp string) error {
 	// Input validation
@@ -713,28 +974,180 @@ func (l *AdvancedSecurityLedger) RecordAlertStatus(alert Alert, loggedAt time.Ti
 }
 
 
-// RecordHealthEvent logs a significant health-related event with a timestamp.
-func (l *AdvancedSecurityLedger) RecordHealthEvent(event string, timestamp time.Time) error {
+// Health event severities accepted by RecordHealthEvent.
+const (
+	HealthSeverityHealthy  = "healthy"
+	HealthSeverityDegraded = "degraded"
+	HealthSeverityCritical = "critical"
+)
+
+// healthSeverityScores maps each health event severity to its health score.
+var healthSeverityScores = map[string]float64{
+	HealthSeverityHealthy:  1,
+	HealthSeverityDegraded: 0.5,
+	HealthSeverityCritical: 0,
+}
+
+// RecordHealthEvent logs a significant health-related event with its severity and a
+// timestamp. The event is scored from the severity, never from the description.
+func (l *AdvancedSecurityLedger) RecordHealthEvent(event, severity string, timestamp time.Time) error {
 	// Validate input
 	if event == "" {
 		return fmt.Errorf("health event description cannot be empty")
 	}
+	score, known := healthSeverityScores[severity]
+	if !known {
+		return fmt.Errorf("unknown health event severity %q", severity)
+	}
 
 	l.Lock()
 	defer l.Unlock()
 
 	// Create and store the health event log
 	newEvent := HealthEvent{
-		Event:     event,
-		Timestamp: timestamp,
+		EventID:     generateUniqueID(),
+		Description: event,
+		RecordedAt:  timestamp,
+		Severity:    severity,
+		Score:       score,
 	}
 	l.HealthEvents = append(l.HealthEvents, newEvent)
 
 	// Log the operation for traceability
-	log.Printf("[INFO] Health event recorded: %s at %s", event, timestamp.Format(time.RFC3339))
+	log.Printf("[INFO] Health event recorded: %s (%s) at %s", event, severity, timestamp.Format(time.RFC3339))
 	return nil
 }
 
+// HealthTrend averages the scores of health events recorded within the window ending at now.
+// The trend is improving when the later half of the window scores at least as well as the earlier half.
+func (l *AdvancedSecurityLedger) HealthTrend(window time.Duration, now time.Time) (improving bool, score float64) {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.healthTrend(window, now)
+}
+
+// healthTrend computes the health trend over a window. The caller must hold the lock.
+func (l *AdvancedSecurityLedger) healthTrend(window time.Duration, now time.Time) (bool, float64) {
+	start := now.Add(-window)
+	midpoint := now.Add(-window / 2)
+
+	var total, earlyTotal, lateTotal float64
+	var count, earlyCount, lateCount int
+	for _, event := range l.HealthEvents {
+		if event.RecordedAt.Before(start) || event.RecordedAt.After(now) {
+			continue
+		}
+		total += event.Score
+		count++
+		if event.RecordedAt.Before(midpoint) {
+			earlyTotal += event.Score
+			earlyCount++
+		} else {
+			lateTotal += event.Score
+			lateCount++
+		}
+	}
+
+	// Without any events there is nothing indicating degradation
+	if count == 0 {
+		return true, 1
+	}
+	if earlyCount == 0 || lateCount == 0 {
+		return true, total / float64(count)
+	}
+
+	return lateTotal/float64(lateCount) >= earlyTotal/float64(earlyCount), total / float64(count)
+}
+
+// DegradationAlert raises a system alert when the health score over HealthTrendWindow falls
+// below the threshold while not improving. It returns nil when health is acceptable.
+func (l *AdvancedSecurityLedger) DegradationAlert(threshold float64, now time.Time) (*SystemAlert, error) {
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("health threshold must be between 0 and 1")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	window := l.HealthTrendWindow
+	if window <= 0 {
+		window = time.Hour
+	}
+
+	improving, score := l.healthTrend(window, now)
+	if improving || score >= threshold {
+		return nil, nil
+	}
+
+	alert := l.raiseSystemAlert("health-degradation",
+		fmt.Sprintf("health score %.2f over the last %s is below threshold %.2f", score, window, threshold), now)
+	return &alert, nil
+}
+
+
+// VerifyHealth runs health checks against a system, scoring it 0-100 by the share of checks
+// passed, and records the verification with each check's outcome.
+func (l *AdvancedSecurityLedger) VerifyHealth(systemID string, checks []func() (bool, string)) (*HealthVerification, error) {
+	if systemID == "" {
+		return nil, fmt.Errorf("system ID cannot be empty")
+	}
+	if len(checks) == 0 {
+		return nil, fmt.Errorf("at least one health check is required")
+	}
+
+	// Run the checks without holding the lock
+	verification := HealthVerification{
+		SystemID:     systemID,
+		LastVerified: time.Now(),
+	}
+	passed := 0
+	for i, check := range checks {
+		ok, detail := check()
+		outcome := "FAIL"
+		if ok {
+			outcome = "PASS"
+			passed++
+		}
+		verification.VerificationLog = append(verification.VerificationLog, fmt.Sprintf("check %d %s: %s", i+1, outcome, detail))
+	}
+
+	verification.VerificationScore = passed * 100 / len(checks)
+	switch {
+	case verification.VerificationScore >= 80:
+		verification.HealthStatus = "Healthy"
+	case verification.VerificationScore >= 50:
+		verification.HealthStatus = "Degraded"
+	default:
+		verification.HealthStatus = "Unhealthy"
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.HealthStatusVerifications == nil {
+		l.HealthStatusVerifications = make(map[string]HealthVerification)
+	}
+	l.HealthStatusVerifications[systemID] = verification
+
+	log.Printf("[INFO] Health of system %s verified: %s with score %d (%d/%d checks passed)",
+		systemID, verification.HealthStatus, verification.VerificationScore, passed, len(checks))
+	return &verification, nil
+}
+
+// RequiresRemediation reports whether a system's last health verification scored below the threshold.
+// Systems that have never been verified require remediation.
+func (l *AdvancedSecurityLedger) RequiresRemediation(systemID string, threshold int) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	verification, exists := l.HealthStatusVerifications[systemID]
+	if !exists {
+		return true
+	}
+	return verification.VerificationScore < threshold
+}
+
 
 // RecordHealthStatusVerification records a health status verification log in the ledger.
 func (l *AdvancedSecurityLedger) RecordHealthStatusVerification(status string, timestamp time.Time) error {
@@ -811,6 +1224,119 @@ func (l *Ledger) RecordAPIUsage(apiID string, usageCount int) error {
 }
 
 
+// defaultAPIUsageWindow is used when APIUsageWindow has not been configured.
+const defaultAPIUsageWindow = time.Minute
+
+// SetAPIQuota sets the maximum number of calls allowed for an API within the usage window.
+func (l *AdvancedSecurityLedger) SetAPIQuota(apiID string, quota int) error {
+	if apiID == "" {
+		return fmt.Errorf("API ID cannot be empty")
+	}
+	if quota <= 0 {
+		return fmt.Errorf("quota must be positive")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.APILimits == nil {
+		l.APILimits = make(map[string]int)
+	}
+	l.APILimits[apiID] = quota
+
+	log.Printf("[INFO] API quota for %s set to %d.", apiID, quota)
+	return nil
+}
+
+// RecordAPIUsage counts a call to an API and reports whether it is allowed under the
+// API's configured quota. Usage is measured over a sliding window: the calls in the
+// current fixed window are added to the previous window's calls, weighted by how much
+// of the previous window still overlaps the sliding one, so a burst straddling a window
+// boundary cannot get twice the quota through.
+func (l *AdvancedSecurityLedger) RecordAPIUsage(apiID string, now time.Time) (allowed bool, err error) {
+	if apiID == "" {
+		return false, fmt.Errorf("API ID cannot be empty")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.APILog == nil {
+		l.APILog = make(map[string]APIUsageRecord)
+	}
+
+	window := l.apiUsageWindow()
+	record, exists := l.APILog[apiID]
+	if !exists {
+		record = APIUsageRecord{
+			APIID:       apiID,
+			WindowStart: now,
+		}
+	}
+	record = advanceAPIUsageWindow(record, now, window)
+
+	if quota, limited := l.APILimits[apiID]; limited && slidingAPIUsage(record, now, window) >= float64(quota) {
+		log.Printf("[WARNING] API %s exceeded quota of %d calls per %s.", apiID, quota, window)
+		l.APILog[apiID] = record
+		return false, fmt.Errorf("API %s quota of %d calls exceeded", apiID, quota)
+	}
+
+	record.UsageCount++
+	record.LastUsedAt = now
+	l.APILog[apiID] = record
+
+	return true, nil
+}
+
+// APIUsageStats returns the sliding-window usage count as of the API's last call, and
+// the time of that call.
+func (l *AdvancedSecurityLedger) APIUsageStats(apiID string) (count int, lastUsed time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	record, exists := l.APILog[apiID]
+	if !exists {
+		return 0, time.Time{}
+	}
+	usage := slidingAPIUsage(record, record.LastUsedAt, l.apiUsageWindow())
+	return int(math.Ceil(usage)), record.LastUsedAt
+}
+
+// apiUsageWindow returns the configured API usage window. The caller must hold the lock.
+func (l *AdvancedSecurityLedger) apiUsageWindow() time.Duration {
+	if l.APIUsageWindow <= 0 {
+		return defaultAPIUsageWindow
+	}
+	return l.APIUsageWindow
+}
+
+// advanceAPIUsageWindow rolls a usage record forward so that now falls inside its
+// current window, carrying the last full window's count into PreviousCount.
+func advanceAPIUsageWindow(record APIUsageRecord, now time.Time, window time.Duration) APIUsageRecord {
+	elapsed := now.Sub(record.WindowStart)
+	switch {
+	case elapsed >= 2*window:
+		record.PreviousCount = 0
+		record.UsageCount = 0
+		record.WindowStart = now
+	case elapsed >= window:
+		record.PreviousCount = record.UsageCount
+		record.UsageCount = 0
+		record.WindowStart = record.WindowStart.Add(window)
+	}
+	return record
+}
+
+// slidingAPIUsage estimates the calls made in the window ending at now.
+func slidingAPIUsage(record APIUsageRecord, now time.Time, window time.Duration) float64 {
+	overlap := 1 - float64(now.Sub(record.WindowStart))/float64(window)
+	if overlap < 0 {
+		overlap = 0
+	}
+	return float64(record.PreviousCount)*overlap + float64(record.UsageCount)
+}
+
+
 // RecordAnomalyDetectionStatus logs the detection status of anomalies
 func (l *AdvancedSecurityLedger) RecordAnomalyDetectionStatus(detectionID, status string) error {
 	// Validate inputs
@@ -924,6 +1450,76 @@ func (l *AdvancedSecurityLedger) RecordProtocolDeviation(deviationID, descriptio
 
 
 
+// suspiciousDeviationThreshold is the number of protocol deviations from a single node
+// after which the node is logged as suspicious.
+const suspiciousDeviationThreshold = 3
+
+// CheckProtocolConformance compares a node's observed behaviour with the expected protocol
+// specification. It returns nil when the node conforms, otherwise the recorded deviation.
+func (l *AdvancedSecurityLedger) CheckProtocolConformance(nodeID string, observed map[string]interface{}, expected map[string]interface{}) (*ProtocolDeviation, error) {
+	if nodeID == "" {
+		return nil, fmt.Errorf("node ID cannot be empty")
+	}
+	if len(expected) == 0 {
+		return nil, fmt.Errorf("expected protocol specification cannot be empty")
+	}
+
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var mismatches []string
+	for _, key := range keys {
+		value, present := observed[key]
+		if !present {
+			mismatches = append(mismatches, fmt.Sprintf("%s missing (expected %v)", key, expected[key]))
+			continue
+		}
+		if !reflect.DeepEqual(value, expected[key]) {
+			mismatches = append(mismatches, fmt.Sprintf("%s=%v (expected %v)", key, value, expected[key]))
+		}
+	}
+
+	if len(mismatches) == 0 {
+		return nil, nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.ProtocolDeviations == nil {
+		l.ProtocolDeviations = make(map[string]ProtocolDeviation)
+	}
+	if l.NodeDeviationCounts == nil {
+		l.NodeDeviationCounts = make(map[string]int)
+	}
+
+	now := time.Now()
+	deviation := ProtocolDeviation{
+		DeviationID: generateUniqueID(),
+		NodeID:      nodeID,
+		Description: fmt.Sprintf("node %s deviated from protocol: %s", nodeID, strings.Join(mismatches, "; ")),
+		DetectedAt:  now,
+	}
+	l.ProtocolDeviations[deviation.DeviationID] = deviation
+
+	l.NodeDeviationCounts[nodeID]++
+	if count := l.NodeDeviationCounts[nodeID]; count >= suspiciousDeviationThreshold {
+		l.SuspiciousActivityLog = append(l.SuspiciousActivityLog, SuspiciousActivityRecord{
+			ActivityID:  generateUniqueID(),
+			Description: fmt.Sprintf("node %s has deviated from protocol %d times", nodeID, count),
+			DetectedAt:  now,
+		})
+		log.Printf("[WARNING] Node %s flagged as suspicious after %d protocol deviations", nodeID, count)
+	}
+
+	log.Printf("[INFO] Protocol deviation %s recorded for node %s", deviation.DeviationID, nodeID)
+	return &deviation, nil
+}
+
+
 // RecordAnomalyAlertLevelSet logs the anomaly alert level and timestamp in the ledger
 func (l *AdvancedSecurityLedger) RecordAnomalyAlertLevelSet(level int, timestamp string) error {
 	// Input validation
@@ -992,20 +1588,95 @@ func (l *AdvancedSecurityLedger) LogAlertResponse(alertID, response string) erro
 	return nil
 }
 
+// RegisterAlertAction registers the handler executed for a named alert response action.
+func (l *AdvancedSecurityLedger) RegisterAlertAction(action string, handler AlertActionHandler) error {
+	if action == "" {
+		return fmt.Errorf("action name cannot be empty")
+	}
+	if handler == nil {
+		return fmt.Errorf("handler for action %s cannot be nil", action)
+	}
 
-// RecordIntegrityViolation logs a detected integrity violation
-func (l *AdvancedSecurityLedger) RecordIntegrityViolation(violationID, details string) error {
-    // Input validation
-    if violationID == "" {
-        return fmt.Errorf("violation ID cannot be empty")
-    }
-    if details == "" {
-        return fmt.Errorf("details cannot be empty")
-    }
+	l.Lock()
+	defer l.Unlock()
 
-    // Ensure thread safety
-    l.Lock()
-    defer l.Unlock()
+	if l.AlertActionHandlers == nil {
+		l.AlertActionHandlers = make(map[string]AlertActionHandler)
+	}
+	l.AlertActionHandlers[action] = handler
+	return nil
+}
+
+// RespondToAlert executes each response action for an alert through its registered handler.
+// The response records the actions that succeeded and is Resolved only if every action
+// succeeded; otherwise it is Escalated.
+func (l *AdvancedSecurityLedger) RespondToAlert(alertID string, actions []string, responder string, now time.Time) (*AlertResponse, error) {
+	if alertID == "" {
+		return nil, fmt.Errorf("alert ID cannot be empty")
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("at least one response action is required")
+	}
+	if responder == "" {
+		return nil, fmt.Errorf("responder cannot be empty")
+	}
+
+	l.Lock()
+	handlers := make(map[string]AlertActionHandler, len(actions))
+	for _, action := range actions {
+		handlers[action] = l.AlertActionHandlers[action]
+	}
+	l.Unlock()
+
+	// Execute the actions without holding the lock
+	response := AlertResponse{
+		AlertID:     alertID,
+		RespondedAt: now,
+		Responder:   responder,
+		Status:      "Resolved",
+	}
+	for _, action := range actions {
+		handler := handlers[action]
+		if handler == nil {
+			log.Printf("[WARNING] No handler registered for alert action %s", action)
+			response.Status = "Escalated"
+			continue
+		}
+		if err := handler(alertID); err != nil {
+			log.Printf("[WARNING] Alert action %s failed for alert %s: %v", action, alertID, err)
+			response.Status = "Escalated"
+			continue
+		}
+		response.ResponseActions = append(response.ResponseActions, action)
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.AlertResponses == nil {
+		l.AlertResponses = make(map[string]AlertResponse)
+	}
+	l.AlertResponses[alertID] = response
+
+	log.Printf("[INFO] Alert %s responded to by %s: %d/%d actions succeeded, status %s",
+		alertID, responder, len(response.ResponseActions), len(actions), response.Status)
+	return &response, nil
+}
+
+
+// RecordIntegrityViolation logs a detected integrity violation
+func (l *AdvancedSecurityLedger) RecordIntegrityViolation(violationID, details string) error {
+    // Input validation
+    if violationID == "" {
+        return fmt.Errorf("violation ID cannot be empty")
+    }
+    if details == "" {
+        return fmt.Errorf("details cannot be empty")
+    }
+
+    // Ensure thread safety
+    l.Lock()
+    defer l.Unlock()
 
     // Record the integrity violation
     l.IntegrityViolations[violationID] = details
@@ -1016,6 +1687,69 @@ func (l *AdvancedSecurityLedger) RecordIntegrityViolation(violationID, details s
 }
 
 
+// RegisterDetectionProbe registers the probe used to check a detection system.
+func (l *AdvancedSecurityLedger) RegisterDetectionProbe(systemID string, probe DetectionProbe) error {
+    if systemID == "" {
+        return fmt.Errorf("system ID cannot be empty")
+    }
+    if probe == nil {
+        return fmt.Errorf("detection probe cannot be nil")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if l.DetectionProbes == nil {
+        l.DetectionProbes = make(map[string]DetectionProbe)
+    }
+    l.DetectionProbes[systemID] = probe
+    return nil
+}
+
+// RunDetectionCheck probes a detection system and updates its status. A system that was
+// operational and fails the probe raises a system alert.
+func (l *AdvancedSecurityLedger) RunDetectionCheck(systemID string, now time.Time) (*DetectionStatus, error) {
+    l.Lock()
+    probe, exists := l.DetectionProbes[systemID]
+    l.Unlock()
+    if !exists {
+        return nil, fmt.Errorf("no detection probe registered for system %s", systemID)
+    }
+
+    // Probe without holding the lock so probes may consult the ledger
+    anomalies, probeErr := probe()
+
+    l.Lock()
+    defer l.Unlock()
+
+    if l.DetectionSystems == nil {
+        l.DetectionSystems = make(map[string]DetectionStatus)
+    }
+    previous, checkedBefore := l.DetectionSystems[systemID]
+
+    status := DetectionStatus{
+        SystemID:          systemID,
+        LastChecked:       now,
+        DetectedAnomalies: previous.DetectedAnomalies,
+    }
+
+    if probeErr != nil {
+        status.Operational = false
+        if checkedBefore && previous.Operational {
+            l.raiseSystemAlert("detection-"+systemID,
+                fmt.Sprintf("detection system %s is no longer operational: %v", systemID, probeErr), now)
+        }
+        log.Printf("[WARNING] Detection system %s failed its check: %v", systemID, probeErr)
+    } else {
+        status.Operational = true
+        status.DetectedAnomalies += anomalies
+    }
+    l.DetectionSystems[systemID] = status
+
+    return &status, nil
+}
+
+
 // RecordThreatDetectionStatus sets the status of threat detection for a specific detection ID
 func (l *AdvancedSecurityLedger) RecordThreatDetectionStatus(detectionID, status string) error {
     // Input validation
@@ -1069,6 +1803,75 @@ func (l *AdvancedSecurityLedger) RecordAnomalyEvent(event, timestamp string) err
     return nil
 }
 
+// anomalySubscriberBuffer is the number of anomaly events buffered per subscriber before the oldest is dropped.
+const anomalySubscriberBuffer = 64
+
+// anomalySubscription is a subscriber to the anomaly event stream.
+type anomalySubscription struct {
+    filter func(AnomalyEvent) bool
+    events chan AnomalyEvent
+}
+
+// PublishAnomaly delivers an anomaly event to every subscriber whose filter matches. Publishing
+// never blocks: when a subscriber's buffer is full its oldest pending event is dropped.
+func (l *AdvancedSecurityLedger) PublishAnomaly(event AnomalyEvent) {
+    l.Lock()
+    defer l.Unlock()
+
+    for _, sub := range l.anomalySubscribers {
+        if sub.filter != nil && !sub.filter(event) {
+            continue
+        }
+        select {
+        case sub.events <- event:
+            continue
+        default:
+        }
+
+        // Drop the oldest pending event to make room
+        select {
+        case <-sub.events:
+        default:
+        }
+        select {
+        case sub.events <- event:
+        default:
+        }
+    }
+}
+
+// Subscribe registers for anomaly events matching filter, or all events when filter is nil.
+// The returned function unsubscribes and closes the channel.
+func (l *AdvancedSecurityLedger) Subscribe(filter func(AnomalyEvent) bool) (<-chan AnomalyEvent, func()) {
+    l.Lock()
+    defer l.Unlock()
+
+    if l.anomalySubscribers == nil {
+        l.anomalySubscribers = make(map[int]*anomalySubscription)
+    }
+
+    id := l.nextAnomalySubscriberID
+    l.nextAnomalySubscriberID++
+    sub := &anomalySubscription{
+        filter: filter,
+        events: make(chan AnomalyEvent, anomalySubscriberBuffer),
+    }
+    l.anomalySubscribers[id] = sub
+
+    var once sync.Once
+    unsubscribe := func() {
+        once.Do(func() {
+            l.Lock()
+            defer l.Unlock()
+
+            delete(l.anomalySubscribers, id)
+            close(sub.events)
+        })
+    }
+
+    return sub.events, unsubscribe
+}
+
 
 // RecordTrafficAnomaly logs a detected traffic anomaly in the ledger
 func (l *AdvancedSecurityLedger) RecordTrafficAnomaly(details, timestamp string) error {
@@ -1097,28 +1900,114 @@ func (l *AdvancedSecurityLedger) RecordTrafficAnomaly(details, timestamp string)
 }
 
 
-// RecordThreatEvent logs a security threat event in the ledger
-func (l *AdvancedSecurityLedger) RecordThreatEvent(event string, timestamp time.Time) error {
-    // Input validation
-    if event == "" {
-        return fmt.Errorf("event cannot be empty")
+// threatMitigationPlaybooks lists the mitigation steps run for each threat type.
+var threatMitigationPlaybooks = map[string][]string{
+    "DDoS":      {"enable rate limiting", "block offending sources", "scale network capacity"},
+    "Malware":   {"isolate affected systems", "remove malicious code", "restore from clean backup"},
+    "Intrusion": {"revoke compromised credentials", "isolate affected systems", "audit access logs"},
+}
+
+// defaultMitigationSteps are run for threat types without a playbook.
+var defaultMitigationSteps = []string{"investigate threat", "contain threat", "recover affected systems"}
+
+// RecordThreatEvent records a threat against the affected systems with the mitigation steps
+// from its threat type's playbook, and marks the threat as detected.
+func (l *AdvancedSecurityLedger) RecordThreatEvent(threatType, desc string, systems []string, now time.Time) (*ThreatEvent, error) {
+    if threatType == "" {
+        return nil, fmt.Errorf("threat type cannot be empty")
+    }
+    if desc == "" {
+        return nil, fmt.Errorf("threat description cannot be empty")
+    }
+
+    steps, exists := threatMitigationPlaybooks[threatType]
+    if !exists {
+        steps = defaultMitigationSteps
     }
 
-    // Ensure thread safety
     l.Lock()
     defer l.Unlock()
 
-    // Record the threat event
-    l.ThreatEvents = append(l.ThreatEvents, ThreatEvent{
-        Event:     event,
-        Timestamp: timestamp,
-    })
+    if l.ThreatEvents == nil {
+        l.ThreatEvents = make(map[string]ThreatEvent)
+    }
+    if l.ThreatDetectionStatus == nil {
+        l.ThreatDetectionStatus = make(map[string]ThreatDetectionStatus)
+    }
 
-    // Log the operation
-    log.Printf("[INFO] Threat event recorded: %s at %s", event, timestamp.Format(time.RFC3339))
+    event := ThreatEvent{
+        EventID:         generateUniqueID(),
+        Timestamp:       now,
+        ThreatType:      threatType,
+        Description:     desc,
+        AffectedSystems: append([]string(nil), systems...),
+        MitigationSteps: append([]string(nil), steps...),
+        CompletedSteps:  make([]bool, len(steps)),
+    }
+    l.ThreatEvents[event.EventID] = event
+
+    l.ThreatDetectionStatus[event.EventID] = ThreatDetectionStatus{
+        ThreatID:        event.EventID,
+        DetectedAt:      now,
+        Status:          "Detected",
+        AssociatedNodes: event.AffectedSystems,
+    }
+
+    log.Printf("[INFO] Threat event %s recorded: %s (%s) at %s", event.EventID, desc, threatType, now.Format(time.RFC3339))
+    return &event, nil
+}
+
+// ExecuteMitigationStep marks a mitigation step of a threat event as executed. Once every step
+// has run the threat's detection status becomes Mitigated.
+func (l *AdvancedSecurityLedger) ExecuteMitigationStep(eventID string, stepIndex int) error {
+    l.Lock()
+    defer l.Unlock()
+
+    event, exists := l.ThreatEvents[eventID]
+    if !exists {
+        return fmt.Errorf("threat event %s not found", eventID)
+    }
+    if stepIndex < 0 || stepIndex >= len(event.MitigationSteps) {
+        return fmt.Errorf("threat event %s has no mitigation step %d", eventID, stepIndex)
+    }
+    if event.CompletedSteps[stepIndex] {
+        return fmt.Errorf("mitigation step %d of threat event %s has already been executed", stepIndex, eventID)
+    }
+
+    event.CompletedSteps[stepIndex] = true
+    l.ThreatEvents[eventID] = event
+
+    status := l.ThreatDetectionStatus[eventID]
+    status.ThreatID = eventID
+    status.MitigationSteps = append(status.MitigationSteps, event.MitigationSteps[stepIndex])
+    if threatMitigated(event) {
+        status.Status = "Mitigated"
+    }
+    l.ThreatDetectionStatus[eventID] = status
+
+    log.Printf("[INFO] Mitigation step %d (%s) executed for threat event %s", stepIndex, event.MitigationSteps[stepIndex], eventID)
     return nil
 }
 
+// ThreatFullyMitigated reports whether every mitigation step of a threat event has been executed.
+func (l *AdvancedSecurityLedger) ThreatFullyMitigated(eventID string) bool {
+    l.Lock()
+    defer l.Unlock()
+
+    event, exists := l.ThreatEvents[eventID]
+    return exists && threatMitigated(event)
+}
+
+// threatMitigated reports whether all of a threat event's mitigation steps are complete.
+func threatMitigated(event ThreatEvent) bool {
+    for _, completed := range event.CompletedSteps {
+        if !completed {
+            return false
+        }
+    }
+    return true
+}
+
 
 // RecordSecurityThresholdSet logs the security threshold in the ledger
 func (l *AdvancedSecurityLedger) RecordSecurityThresholdSet(threshold int, timestamp string) error {
@@ -1196,6 +2085,99 @@ func (l *AdvancedSecurityLedger) RecordThreatLevel(level int, timestamp string)
 }
 
 
+// SetThreatThreshold maps a threat level threshold to the mitigation plan that activates when it is reached.
+func (l *AdvancedSecurityLedger) SetThreatThreshold(level int, planID string) error {
+    if level < 0 || level > 10 {
+        return fmt.Errorf("threat level must be between 0 and 10")
+    }
+    if planID == "" {
+        return fmt.Errorf("plan ID must not be empty")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if _, exists := l.MitigationPlans[planID]; !exists {
+        return fmt.Errorf("mitigation plan %s does not exist", planID)
+    }
+
+    if l.ThreatLevelThresholds == nil {
+        l.ThreatLevelThresholds = make(map[int]string)
+    }
+    l.ThreatLevelThresholds[level] = planID
+
+    log.Printf("[INFO] Threat threshold %d mapped to mitigation plan %s", level, planID)
+    return nil
+}
+
+// SetThreatLevel records a new threat level and activates exactly the mitigation plans with at
+// least one threshold at or below it, deactivating the rest. The set of active plans is worked
+// out first and then applied, so a plan shared by several thresholds ends up in the same state
+// whatever order the thresholds are visited in.
+func (l *AdvancedSecurityLedger) SetThreatLevel(level int, now time.Time) (*ThreatLevel, error) {
+    if level < 0 || level > 10 {
+        return nil, fmt.Errorf("threat level must be between 0 and 10")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    previous := l.currentThreatLevel()
+
+    // Work out which threshold-governed plans should be active at the new level
+    active := make(map[string]bool)
+    for threshold, planID := range l.ThreatLevelThresholds {
+        active[planID] = active[planID] || level >= threshold
+    }
+
+    planIDs := make([]string, 0, len(active))
+    for planID := range active {
+        planIDs = append(planIDs, planID)
+    }
+    sort.Strings(planIDs)
+
+    // Apply the change to every plan whose state differs
+    for _, planID := range planIDs {
+        plan, exists := l.MitigationPlans[planID]
+        if !exists || plan.Activated == active[planID] {
+            continue
+        }
+        plan.Activated = active[planID]
+        l.MitigationPlans[planID] = plan
+        if plan.Activated {
+            log.Printf("[INFO] Threat level %d activated mitigation plan %s", level, planID)
+        } else {
+            log.Printf("[INFO] Threat level %d deactivated mitigation plan %s", level, planID)
+        }
+    }
+
+    threat := ThreatLevel{
+        Level:     level,
+        Timestamp: now.Format(time.RFC3339),
+    }
+    l.ThreatLevelHistory = append(l.ThreatLevelHistory, threat)
+
+    log.Printf("[INFO] Threat level changed from %d to %d", previous, level)
+    return &threat, nil
+}
+
+// CurrentThreatLevel returns the most recently recorded threat level.
+func (l *AdvancedSecurityLedger) CurrentThreatLevel() int {
+    l.Lock()
+    defer l.Unlock()
+
+    return l.currentThreatLevel()
+}
+
+// currentThreatLevel returns the latest threat level. The caller must hold the lock.
+func (l *AdvancedSecurityLedger) currentThreatLevel() int {
+    if len(l.ThreatLevelHistory) == 0 {
+        return 0
+    }
+    return l.ThreatLevelHistory[len(l.ThreatLevelHistory)-1].Level
+}
+
+
 // RecordIncidentDeactivation logs the deactivation of an incident response in the ledger
 func (l *AdvancedSecurityLedger) RecordIncidentDeactivation(incidentID, timestamp string) error {
     // Validate input parameters
@@ -1222,33 +2204,125 @@ func (l *AdvancedSecurityLedger) RecordIncidentDeactivation(incidentID, timestam
 }
 
 
-// RecordIncidentEvent logs an event related to an incident response in the ledger
-func (l *AdvancedSecurityLedger) RecordIncidentEvent(incidentID, event, timestamp string) error {
-    // Validate input parameters
+// RecordIncidentEvent logs an event against an active incident. Events cannot be
+// recorded once the incident has been resolved.
+func (l *AdvancedSecurityLedger) RecordIncidentEvent(incidentID, desc string, now time.Time) (*IncidentEvent, error) {
     if incidentID == "" {
-        return fmt.Errorf("incident ID cannot be empty")
+        return nil, fmt.Errorf("incident ID cannot be empty")
     }
-    if event == "" {
-        return fmt.Errorf("event cannot be empty")
+    if desc == "" {
+        return nil, fmt.Errorf("event description cannot be empty")
     }
-    if timestamp == "" {
-        return fmt.Errorf("timestamp cannot be empty")
+
+    l.Lock()
+    defer l.Unlock()
+
+    if _, active := l.IncidentActivations[incidentID]; !active {
+        return nil, fmt.Errorf("incident %s has not been activated", incidentID)
+    }
+    if _, resolved := l.IncidentResolutions[incidentID]; resolved {
+        return nil, fmt.Errorf("incident %s is already resolved", incidentID)
+    }
+
+    if l.IncidentEvents == nil {
+        l.IncidentEvents = make(map[string]IncidentEvent)
+    }
+
+    event := IncidentEvent{
+        EventID:     generateUniqueID(),
+        IncidentID:  incidentID,
+        Description: desc,
+        RecordedAt:  now,
+    }
+    l.IncidentEvents[event.EventID] = event
+
+    log.Printf("[INFO] Incident event logged for incident ID: %s, Event: %s at %s", incidentID, desc, now.Format(time.RFC3339))
+    return &event, nil
+}
+
+// ActivateProtocol activates an incident response protocol and opens a new incident under it.
+func (l *AdvancedSecurityLedger) ActivateProtocol(protocolID string, now time.Time) (*IncidentActivation, error) {
+    if protocolID == "" {
+        return nil, fmt.Errorf("protocol ID cannot be empty")
     }
 
-    // Ensure thread safety
     l.Lock()
     defer l.Unlock()
 
-    // Record the incident event
-    l.IncidentEvents = append(l.IncidentEvents, IncidentEvent{
-        IncidentID: incidentID,
-        Event:      event,
-        Timestamp:  timestamp,
-    })
+    protocol, exists := l.IncidentProtocols[protocolID]
+    if !exists {
+        return nil, fmt.Errorf("incident protocol %s not found", protocolID)
+    }
+    if protocol.Activated {
+        return nil, fmt.Errorf("incident protocol %s is already active", protocolID)
+    }
 
-    // Log the operation
-    log.Printf("[INFO] Incident event logged for incident ID: %s, Event: %s at %s", incidentID, event, timestamp)
-    return nil
+    protocol.Activated = true
+    protocol.ActivatedAt = now
+    protocol.DeactivatedAt = time.Time{}
+    l.IncidentProtocols[protocolID] = protocol
+
+    if l.IncidentActivations == nil {
+        l.IncidentActivations = make(map[string]IncidentActivation)
+    }
+
+    activation := IncidentActivation{
+        IncidentID: generateUniqueID(),
+        ProtocolID: protocolID,
+        Timestamp:  now.Format(time.RFC3339),
+    }
+    l.IncidentActivations[activation.IncidentID] = activation
+
+    log.Printf("[INFO] Incident protocol %s activated for incident ID: %s", protocolID, activation.IncidentID)
+    return &activation, nil
+}
+
+// ResolveIncident records the resolution of an active incident and deactivates its protocol.
+func (l *AdvancedSecurityLedger) ResolveIncident(incidentID, status string, now time.Time) (*IncidentResolution, error) {
+    if incidentID == "" {
+        return nil, fmt.Errorf("incident ID cannot be empty")
+    }
+    if status == "" {
+        return nil, fmt.Errorf("resolution status cannot be empty")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    activation, active := l.IncidentActivations[incidentID]
+    if !active {
+        return nil, fmt.Errorf("incident %s has not been activated", incidentID)
+    }
+    if _, resolved := l.IncidentResolutions[incidentID]; resolved {
+        return nil, fmt.Errorf("incident %s is already resolved", incidentID)
+    }
+
+    if protocol, exists := l.IncidentProtocols[activation.ProtocolID]; exists {
+        protocol.Activated = false
+        protocol.DeactivatedAt = now
+        l.IncidentProtocols[activation.ProtocolID] = protocol
+    }
+
+    if l.IncidentResolutions == nil {
+        l.IncidentResolutions = make(map[string]IncidentResolution)
+    }
+
+    resolution := IncidentResolution{
+        IncidentID:       incidentID,
+        ResolutionStatus: status,
+        Timestamp:        now.Format(time.RFC3339),
+    }
+    l.IncidentResolutions[incidentID] = resolution
+
+    for id, event := range l.IncidentEvents {
+        if event.IncidentID == incidentID {
+            event.Resolution = status
+            l.IncidentEvents[id] = event
+        }
+    }
+
+    log.Printf("[INFO] Incident %s resolved with status %s; protocol %s deactivated", incidentID, status, activation.ProtocolID)
+    return &resolution, nil
 }
 
 
@@ -1350,6 +2424,82 @@ func (l *AdvancedSecurityLedger) RecordEscalationProtocolSet(protocol, timestamp
 }
 
 
+// DefineEscalationProtocol registers an escalation protocol with the actions for each stage.
+func (l *AdvancedSecurityLedger) DefineEscalationProtocol(protocolID, description string, stages [][]string) error {
+    if protocolID == "" {
+        return fmt.Errorf("protocol ID cannot be empty")
+    }
+    if len(stages) == 0 {
+        return fmt.Errorf("escalation protocol must define at least one stage")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if l.EscalationProtocol == nil {
+        l.EscalationProtocol = make(map[string]EscalationProtocol)
+    }
+
+    l.EscalationProtocol[protocolID] = EscalationProtocol{
+        ProtocolID:  protocolID,
+        Description: description,
+        SetAt:       time.Now(),
+        Stages:      stages,
+    }
+
+    log.Printf("[INFO] Escalation protocol %s defined with %d stages", protocolID, len(stages))
+    return nil
+}
+
+// Escalate executes the given stage of an escalation protocol and returns its actions along
+// with the next stage to execute. Stages must be executed in order; once the final stage has
+// run, nextStage equals the number of stages and further escalation is rejected.
+func (l *AdvancedSecurityLedger) Escalate(protocolID string, stage int, now time.Time) (nextStage int, actions []string, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    protocol, exists := l.EscalationProtocol[protocolID]
+    if !exists {
+        return 0, nil, fmt.Errorf("escalation protocol %s not found", protocolID)
+    }
+    if protocol.CurrentStage >= len(protocol.Stages) {
+        return protocol.CurrentStage, nil, fmt.Errorf("escalation protocol %s has no stages left", protocolID)
+    }
+    if stage != protocol.CurrentStage {
+        return protocol.CurrentStage, nil, fmt.Errorf("escalation protocol %s expects stage %d, got %d", protocolID, protocol.CurrentStage, stage)
+    }
+
+    actions = append([]string(nil), protocol.Stages[stage]...)
+    protocol.CurrentStage = stage + 1
+    l.EscalationProtocol[protocolID] = protocol
+
+    if l.EscalationTimestamp == nil {
+        l.EscalationTimestamp = make(map[string]time.Time)
+    }
+    l.EscalationTimestamp[protocolID] = now
+
+    log.Printf("[INFO] Escalation protocol %s advanced to stage %d", protocolID, stage)
+    return protocol.CurrentStage, actions, nil
+}
+
+// ResetEscalation returns an escalation protocol to its first stage.
+func (l *AdvancedSecurityLedger) ResetEscalation(protocolID string) {
+    l.Lock()
+    defer l.Unlock()
+
+    protocol, exists := l.EscalationProtocol[protocolID]
+    if !exists {
+        return
+    }
+
+    protocol.CurrentStage = 0
+    l.EscalationProtocol[protocolID] = protocol
+    delete(l.EscalationTimestamp, protocolID)
+
+    log.Printf("[INFO] Escalation protocol %s reset", protocolID)
+}
+
+
 // GetDDoSScore retrieves the DDoS threat score from the ledger
 func (l *AdvancedSecurityLedger) GetDDoSScore() (int, error) {
     l.Lock()
@@ -1384,6 +2534,90 @@ func (l *AdvancedSecurityLedger) FetchAllIncidentReports() ([]string, error) {
     return reports, nil
 }
 
+// FileIncidentReport files a new open incident report.
+func (l *AdvancedSecurityLedger) FileIncidentReport(details string, now time.Time) (*IncidentReport, error) {
+    if details == "" {
+        return nil, fmt.Errorf("incident details cannot be empty")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if l.IncidentReports == nil {
+        l.IncidentReports = make(map[string]IncidentReport)
+    }
+
+    report := IncidentReport{
+        IncidentID: generateUniqueID(),
+        Details:    details,
+        Timestamp:  now.Format(time.RFC3339),
+        Status:     "Open",
+    }
+    l.IncidentReports[report.IncidentID] = report
+
+    log.Printf("[INFO] Incident report %s filed: %s", report.IncidentID, details)
+    return &report, nil
+}
+
+// LinkResolution resolves a filed incident report, recording the resolution against it.
+func (l *AdvancedSecurityLedger) LinkResolution(incidentID, status string, now time.Time) (*IncidentResolution, error) {
+    if incidentID == "" {
+        return nil, fmt.Errorf("incident ID cannot be empty")
+    }
+    if status == "" {
+        return nil, fmt.Errorf("resolution status cannot be empty")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    report, exists := l.IncidentReports[incidentID]
+    if !exists {
+        return nil, fmt.Errorf("incident report %s not found", incidentID)
+    }
+    if _, resolved := l.IncidentResolutions[incidentID]; resolved {
+        return nil, fmt.Errorf("incident %s is already resolved", incidentID)
+    }
+
+    if l.IncidentResolutions == nil {
+        l.IncidentResolutions = make(map[string]IncidentResolution)
+    }
+
+    resolution := IncidentResolution{
+        IncidentID:       incidentID,
+        ResolutionStatus: status,
+        Timestamp:        now.Format(time.RFC3339),
+    }
+    l.IncidentResolutions[incidentID] = resolution
+
+    report.Status = status
+    l.IncidentReports[incidentID] = report
+
+    log.Printf("[INFO] Incident report %s resolved with status %s", incidentID, status)
+    return &resolution, nil
+}
+
+// OpenIncidents returns the incident reports that have no linked resolution, oldest first.
+func (l *AdvancedSecurityLedger) OpenIncidents() []IncidentReport {
+    l.Lock()
+    defer l.Unlock()
+
+    var open []IncidentReport
+    for id, report := range l.IncidentReports {
+        if _, resolved := l.IncidentResolutions[id]; !resolved {
+            open = append(open, report)
+        }
+    }
+    sort.Slice(open, func(i, j int) bool {
+        if open[i].Timestamp != open[j].Timestamp {
+            return open[i].Timestamp < open[j].Timestamp
+        }
+        return open[i].IncidentID < open[j].IncidentID
+    })
+
+    return open
+}
+
 
 // RecordRetentionPolicySet logs the retention policy for incident logs in the ledger
 func (l *AdvancedSecurityLedger) RecordRetentionPolicySet(policyName, policy, timestamp string) error {
@@ -1417,6 +2651,69 @@ func (l *AdvancedSecurityLedger) RecordRetentionPolicySet(policyName, policy, ti
 }
 
 
+// raiseSystemAlert records an unresolved system alert under the given ID, keeping any
+// existing alert with that ID. The caller must hold the lock.
+func (l *AdvancedSecurityLedger) raiseSystemAlert(alertID, description string, now time.Time) SystemAlert {
+    if l.SystemAlerts == nil {
+        l.SystemAlerts = make(map[string]SystemAlert)
+    }
+    if existing, exists := l.SystemAlerts[alertID]; exists && !existing.Resolved {
+        return existing
+    }
+
+    alert := SystemAlert{
+        AlertID:     alertID,
+        Description: description,
+        Timestamp:   now,
+    }
+    l.SystemAlerts[alertID] = alert
+
+    log.Printf("[WARNING] System alert %s raised: %s", alertID, description)
+    return alert
+}
+
+// CheckRetentionCompliance reports whether a retention policy is still within its compliance
+// date and how many whole days remain. Overdue policies raise a system alert.
+func (l *AdvancedSecurityLedger) CheckRetentionCompliance(policyID string, now time.Time) (compliant bool, daysUntilDue int, err error) {
+    if policyID == "" {
+        return false, 0, fmt.Errorf("policy ID cannot be empty")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    policy, exists := l.RetentionPolicies[policyID]
+    if !exists {
+        return false, 0, fmt.Errorf("retention policy %s not found", policyID)
+    }
+
+    daysUntilDue = int(policy.ComplianceDate.Sub(now).Hours() / 24)
+    if now.After(policy.ComplianceDate) {
+        l.raiseSystemAlert("retention-"+policyID,
+            fmt.Sprintf("retention policy %s passed its compliance date %s", policyID, policy.ComplianceDate.Format(time.RFC3339)), now)
+        return false, daysUntilDue, nil
+    }
+
+    return true, daysUntilDue, nil
+}
+
+// NonCompliantPolicies returns the IDs of retention policies past their compliance date.
+func (l *AdvancedSecurityLedger) NonCompliantPolicies(now time.Time) []string {
+    l.Lock()
+    defer l.Unlock()
+
+    var overdue []string
+    for id, policy := range l.RetentionPolicies {
+        if now.After(policy.ComplianceDate) {
+            overdue = append(overdue, id)
+        }
+    }
+    sort.Strings(overdue)
+
+    return overdue
+}
+
+
 // RecordIncidentResponseProtocol sets up an incident response protocol
 func (l *AdvancedSecurityLedger) RecordIncidentResponseProtocol(protocolID, description string) error {
     // Validate inputs
@@ -1490,6 +2787,82 @@ func (l *AdvancedSecurityLedger) RecordIsolationEvent(incidentID, eventDetails s
 }
 
 
+// IsolateEntity places an entity in quarantine, blocking its transactions and communications
+// until it is released.
+func (l *AdvancedSecurityLedger) IsolateEntity(entityID, reason string, now time.Time) (*IsolationIncident, error) {
+    if entityID == "" {
+        return nil, fmt.Errorf("entity ID cannot be empty")
+    }
+    if reason == "" {
+        return nil, fmt.Errorf("isolation reason cannot be empty")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if incidentID, isolated := l.IsolatedEntities[entityID]; isolated {
+        return nil, fmt.Errorf("entity %s is already isolated under incident %s", entityID, incidentID)
+    }
+
+    if l.IsolationIncidents == nil {
+        l.IsolationIncidents = make(map[string]IsolationIncident)
+    }
+    if l.IsolatedEntities == nil {
+        l.IsolatedEntities = make(map[string]string)
+    }
+
+    incident := IsolationIncident{
+        IncidentID:  generateUniqueID(),
+        EntityID:    entityID,
+        Description: reason,
+        IsolatedAt:  now,
+    }
+    l.IsolationIncidents[incident.IncidentID] = incident
+    l.IsolatedEntities[entityID] = incident.IncidentID
+
+    log.Printf("[WARNING] Entity %s isolated under incident %s: %s", entityID, incident.IncidentID, reason)
+    return &incident, nil
+}
+
+// IsIsolated reports whether an entity is currently quarantined.
+func (l *AdvancedSecurityLedger) IsIsolated(entityID string) bool {
+    l.Lock()
+    defer l.Unlock()
+
+    _, isolated := l.IsolatedEntities[entityID]
+    return isolated
+}
+
+// AuthorizeEntityOperation rejects transactions and communications from isolated entities.
+func (l *AdvancedSecurityLedger) AuthorizeEntityOperation(entityID, operation string) error {
+    if l.IsIsolated(entityID) {
+        return fmt.Errorf("operation %s blocked: entity %s is isolated", operation, entityID)
+    }
+    return nil
+}
+
+// ReleaseIsolation lifts the quarantine on an entity and closes its isolation incident.
+func (l *AdvancedSecurityLedger) ReleaseIsolation(entityID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    incidentID, isolated := l.IsolatedEntities[entityID]
+    if !isolated {
+        return fmt.Errorf("entity %s is not isolated", entityID)
+    }
+
+    if incident, exists := l.IsolationIncidents[incidentID]; exists {
+        releasedAt := time.Now()
+        incident.ReleasedAt = &releasedAt
+        l.IsolationIncidents[incidentID] = incident
+    }
+    delete(l.IsolatedEntities, entityID)
+
+    log.Printf("[INFO] Entity %s released from isolation incident %s", entityID, incidentID)
+    return nil
+}
+
+
 // RecordHealthThresholdSet logs the system health threshold in the ledger with a timestamp.
 func (l *AdvancedSecurityLedger) RecordHealthThresholdSet(threshold int, timestamp time.Time) error {
     if threshold <= 0 {
@@ -1832,8 +3205,8 @@ func (asl *AdvancedSecurityLedger) SetEventMonitoringStatus(enabled bool) error
 }
 
 
-// RecordAPIUsage records API usage metrics in the ledger.
-func (asl *AdvancedSecurityLedger) RecordAPIUsage(apiName string, usageCount int) error {
+// RecordAPIUsageMetrics records API usage metrics in the ledger.
+func (asl *AdvancedSecurityLedger) RecordAPIUsageMetrics(apiName string, usageCount int) error {
     if apiName == "" || usageCount < 0 {
         return fmt.Errorf("invalid API name or usage count")
     }
@@ -1858,6 +3231,163 @@ func NewThreatDetectionManager() *ThreatDetectionManager {
     return &ThreatDetectionManager{Active: false}
 }
 
+// NewSessionStore creates an empty SessionStore.
+func NewSessionStore() *SessionStore {
+    return &SessionStore{Sessions: make(map[string]*ActiveSession)}
+}
+
+// Add registers an active session with the store.
+func (s *SessionStore) Add(session *ActiveSession) error {
+    if session == nil || session.SessionID == "" {
+        return fmt.Errorf("session ID cannot be empty")
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, exists := s.Sessions[session.SessionID]; exists {
+        return fmt.Errorf("session with ID '%s' already exists", session.SessionID)
+    }
+    if session.LastActivity.IsZero() {
+        session.LastActivity = time.Now()
+    }
+    session.IsActive = true
+    s.Sessions[session.SessionID] = session
+    return nil
+}
+
+// Touch records activity on a session. Inactive sessions, and sessions already
+// idle past their timeout, are left untouched so activity cannot revive them.
+func (s *SessionStore) Touch(sessionID string) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    session, exists := s.Sessions[sessionID]
+    now := time.Now()
+    if !exists || !session.IsActive || sessionIdle(session, now) {
+        return
+    }
+    session.LastActivity = now
+}
+
+// ExpireIdle deactivates every session idle for longer than its timeout and
+// writes a SessionTimeoutLog for each.
+func (s *SessionStore) ExpireIdle(now time.Time) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for id, session := range s.Sessions {
+        if !session.IsActive || !sessionIdle(session, now) {
+            continue
+        }
+        session.IsActive = false
+        s.TimeoutLogs = append(s.TimeoutLogs, SessionTimeoutLog{
+            SessionID: id,
+            UserID:    session.UserID,
+            TimeoutAt: session.LastActivity.Add(session.Timeout),
+            LoggedAt:  now,
+        })
+        log.Printf("[INFO] Session '%s' for user '%s' expired after %s idle", id, session.UserID, now.Sub(session.LastActivity))
+    }
+}
+
+// Validate returns an error unless the session exists, is active and has not
+// been idle past its timeout.
+func (s *SessionStore) Validate(sessionID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    session, exists := s.Sessions[sessionID]
+    if !exists {
+        return fmt.Errorf("session '%s' not found", sessionID)
+    }
+    if !session.IsActive {
+        return fmt.Errorf("session '%s' is not active", sessionID)
+    }
+    if sessionIdle(session, time.Now()) {
+        return fmt.Errorf("session '%s' has expired", sessionID)
+    }
+    return nil
+}
+
+// sessionIdle reports whether a session with a timeout has been idle past it.
+func sessionIdle(session *ActiveSession, now time.Time) bool {
+    return session.Timeout > 0 && now.Sub(session.LastActivity) > session.Timeout
+}
+
+// NewRateLimiter creates a RateLimiter allowing limit calls per node in each window.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+    return &RateLimiter{
+        Statuses:     make(map[string]*RateLimitingStatus),
+        DefaultLimit: limit,
+        Window:       window,
+    }
+}
+
+// SetLimit overrides the usage limit for a single node.
+func (r *RateLimiter) SetLimit(nodeID string, limit int) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    r.status(nodeID, time.Now()).Limit = limit
+}
+
+// Allow counts a call from nodeID and reports whether it is within the node's
+// limit for the current window. Once the window has passed, usage resets and
+// a new window starts from now.
+func (r *RateLimiter) Allow(nodeID string) bool {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    status := r.status(nodeID, time.Now())
+    if status.CurrentUsage >= status.Limit {
+        if status.Status != "Throttled" {
+            log.Printf("[WARNING] Node %s throttled until %s", nodeID, status.ResetTime.Format(time.RFC3339))
+        }
+        status.Status = "Throttled"
+        return false
+    }
+    status.CurrentUsage++
+    status.Status = "Active"
+    return true
+}
+
+// Remaining returns how many more calls nodeID may make in the current window.
+func (r *RateLimiter) Remaining(nodeID string) int {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    status := r.status(nodeID, time.Now())
+    if remaining := status.Limit - status.CurrentUsage; remaining > 0 {
+        return remaining
+    }
+    return 0
+}
+
+// status returns nodeID's usage window, creating it or starting a new window
+// as needed. The caller must hold the lock.
+func (r *RateLimiter) status(nodeID string, now time.Time) *RateLimitingStatus {
+    if r.Statuses == nil {
+        r.Statuses = make(map[string]*RateLimitingStatus)
+    }
+    status, exists := r.Statuses[nodeID]
+    if !exists {
+        status = &RateLimitingStatus{
+            NodeID:    nodeID,
+            Limit:     r.DefaultLimit,
+            ResetTime: now.Add(r.Window),
+            Status:    "Active",
+        }
+        r.Statuses[nodeID] = status
+    }
+    if !now.Before(status.ResetTime) {
+        status.CurrentUsage = 0
+        status.ResetTime = now.Add(r.Window)
+        status.Status = "Active"
+    }
+    return status
+}
+
 
 // DeactivateThreatDetection deactivates the threat detection system.
 func (tdm *ThreatDetectionManager) DeactivateThreatDetection() error {
@@ -1900,3 +3430,292 @@ func (asl *AdvancedSecurityLedger) DisableIntrusionDetection() error {
     return nil
 }
 
+
+// TrapActionHandler carries out a trap response action for whoever triggered the trap.
+type TrapActionHandler func(tm *TrapManager, triggeredBy, reason string) error
+
+var (
+    trapActionsMu sync.RWMutex
+    trapActions   = make(map[string]TrapActionHandler)
+)
+
+// RegisterTrapAction makes handler available to traps listing action in their ResponseActions.
+func RegisterTrapAction(action string, handler TrapActionHandler) error {
+    if action == "" || handler == nil {
+        return fmt.Errorf("trap action name and handler are required")
+    }
+    trapActionsMu.Lock()
+    defer trapActionsMu.Unlock()
+    trapActions[action] = handler
+    return nil
+}
+
+// Activate arms the trap.
+func (tm *TrapManager) Activate() {
+    tm.IsActive = true
+    tm.ActivationTime = time.Now()
+    log.Printf("[INFO] Trap %s activated", tm.TrapID)
+}
+
+// Deactivate disarms the trap; triggers are rejected until it is activated again.
+func (tm *TrapManager) Deactivate() {
+    tm.IsActive = false
+    log.Printf("[INFO] Trap %s deactivated", tm.TrapID)
+}
+
+// Trigger fires an active trap: it counts the trigger, runs each configured
+// response action through its registered handler and logs the outcome. It
+// returns the actions that ran successfully, and an error naming any that
+// failed or had no handler.
+func Trigger(tm *TrapManager, triggeredBy, reason string) ([]string, error) {
+    if tm == nil {
+        return nil, fmt.Errorf("trap cannot be nil")
+    }
+    if !tm.IsActive {
+        return nil, fmt.Errorf("trap %s is not active", tm.TrapID)
+    }
+
+    tm.TriggerCount++
+
+    var taken, failures []string
+    for _, action := range tm.ResponseActions {
+        trapActionsMu.RLock()
+        handler, exists := trapActions[action]
+        trapActionsMu.RUnlock()
+
+        if !exists {
+            failures = append(failures, fmt.Sprintf("%s: no handler registered", action))
+            continue
+        }
+        if err := handler(tm, triggeredBy, reason); err != nil {
+            failures = append(failures, fmt.Sprintf("%s: %v", action, err))
+            continue
+        }
+        taken = append(taken, action)
+    }
+
+    status := "executed"
+    switch {
+    case len(tm.ResponseActions) == 0:
+        status = "no_actions"
+    case len(taken) == 0:
+        status = "failed"
+    case len(failures) > 0:
+        status = "partial"
+    }
+    tm.TriggerLogs = append(tm.TriggerLogs, TrapTriggerLog{
+        Timestamp:      time.Now(),
+        TriggeredBy:    triggeredBy,
+        TriggerReason:  reason,
+        ResponseStatus: status,
+        LogDetails:     fmt.Sprintf("actions taken: %v; failures: %v", taken, failures),
+    })
+    log.Printf("[WARNING] Trap %s triggered by %s (%s): response %s", tm.TrapID, triggeredBy, reason, status)
+
+    if len(failures) > 0 {
+        return taken, fmt.Errorf("trap %s response actions failed: %s", tm.TrapID, strings.Join(failures, "; "))
+    }
+    return taken, nil
+}
+
+// EscalationActionHandler carries out an alert escalation action at the given level.
+type EscalationActionHandler func(am *AlertManager, level int) error
+
+var (
+    escalationActionsMu sync.RWMutex
+    escalationActions   = make(map[string]EscalationActionHandler)
+)
+
+// RegisterEscalationAction makes handler available to escalation policies listing action.
+func RegisterEscalationAction(action string, handler EscalationActionHandler) error {
+    if action == "" || handler == nil {
+        return fmt.Errorf("escalation action name and handler are required")
+    }
+    escalationActionsMu.Lock()
+    defer escalationActionsMu.Unlock()
+    escalationActions[action] = handler
+    return nil
+}
+
+// Acknowledge marks an alert as acknowledged, which stops further escalation.
+func Acknowledge(am *AlertManager, by string) {
+    if am.IsAcknowledged {
+        return
+    }
+    am.IsAcknowledged = true
+    am.AcknowledgedBy = by
+    am.AcknowledgedAt = time.Now()
+    am.AlertLogs = append(am.AlertLogs, AlertLog{
+        Timestamp:       am.AcknowledgedAt,
+        LogType:         "acknowledged",
+        ActionPerformed: "acknowledge",
+        PerformedBy:     by,
+        LogDetails:      fmt.Sprintf("alert acknowledged at escalation level %d", am.EscalationPolicy.EscalationLevel),
+    })
+    log.Printf("[INFO] Alert %s acknowledged by %s", am.AlertID, by)
+}
+
+// EscalateIfStale escalates an unacknowledged alert one level once it has gone
+// longer than the policy's EscalationInterval since it was raised or last
+// escalated. The new level's action (EscalationActions[level-1]) is run and its
+// contact (EscalationContacts[level-1]) is added to the alert's recipients.
+// Escalation stops at MaxEscalationLevel. It reports whether the alert escalated.
+func EscalateIfStale(am *AlertManager, now time.Time) bool {
+    policy := &am.EscalationPolicy
+    if am.IsAcknowledged || policy.EscalationLevel >= policy.MaxEscalationLevel {
+        return false
+    }
+    if am.LastEscalatedAt.IsZero() {
+        // Start the escalation clock on first observation.
+        am.LastEscalatedAt = now
+        return false
+    }
+    if now.Sub(am.LastEscalatedAt) <= policy.EscalationInterval {
+        return false
+    }
+
+    policy.EscalationLevel++
+    am.LastEscalatedAt = now
+    level := policy.EscalationLevel
+    details := fmt.Sprintf("escalated to level %d", level)
+
+    if level <= len(policy.EscalationActions) {
+        action := policy.EscalationActions[level-1]
+        escalationActionsMu.RLock()
+        handler, exists := escalationActions[action]
+        escalationActionsMu.RUnlock()
+        if !exists {
+            details += fmt.Sprintf("; action %s has no handler", action)
+        } else if err := handler(am, level); err != nil {
+            details += fmt.Sprintf("; action %s failed: %v", action, err)
+        } else {
+            details += fmt.Sprintf("; action %s executed", action)
+        }
+    }
+    if level <= len(policy.EscalationContacts) {
+        contact := policy.EscalationContacts[level-1]
+        notified := false
+        for _, r := range am.NotificationRecipients {
+            if r == contact {
+                notified = true
+                break
+            }
+        }
+        if !notified {
+            am.NotificationRecipients = append(am.NotificationRecipients, contact)
+        }
+        details += fmt.Sprintf("; notified %s", contact)
+    }
+
+    am.AlertLogs = append(am.AlertLogs, AlertLog{
+        Timestamp:       now,
+        LogType:         "escalated",
+        ActionPerformed: "escalate",
+        PerformedBy:     "AlertManager",
+        LogDetails:      details,
+    })
+    log.Printf("[WARNING] Alert %s %s", am.AlertID, details)
+    return true
+}
+
+const (
+    // anomalyRateMultiple is how far above baseline a source's request rate
+    // must be to be flagged; highAnomalyRateMultiple marks it high severity.
+    anomalyRateMultiple     = 3.0
+    highAnomalyRateMultiple = 10.0
+
+    // Failed-login ratios at or above minFailedLoginRatio and
+    // anomalyRateMultiple times baseline are flagged;
+    // highFailedLoginRatio and above are high severity.
+    minFailedLoginRatio  = 0.1
+    highFailedLoginRatio = 0.5
+)
+
+// AnomalyMitigationHook is called with every high severity traffic anomaly.
+type AnomalyMitigationHook func(anomaly TrafficAnomaly)
+
+var (
+    anomalyHooksMu sync.RWMutex
+    anomalyHooks   []AnomalyMitigationHook
+)
+
+// RegisterAnomalyMitigation adds a hook run by DetectAnomalies for each high
+// severity anomaly, typically to activate a MitigationPlan.
+func RegisterAnomalyMitigation(hook AnomalyMitigationHook) {
+    anomalyHooksMu.Lock()
+    defer anomalyHooksMu.Unlock()
+    anomalyHooks = append(anomalyHooks, hook)
+}
+
+// DetectAnomalies compares each source's traffic with baseline and flags
+// sources whose average or peak request rate exceeds anomalyRateMultiple times
+// the baseline, or whose failed-login ratio is abnormally high. Anomalies are
+// rated "Medium" or "High"; high severity anomalies are passed to the
+// registered mitigation hooks.
+func DetectAnomalies(data []TrafficData, baseline TrafficData) []TrafficAnomaly {
+    baselineRatio := failedLoginRatio(baseline)
+    now := time.Now()
+
+    var anomalies []TrafficAnomaly
+    for _, td := range data {
+        var reasons []string
+        high := false
+
+        for _, r := range []struct {
+            name           string
+            rate, baseline float64
+        }{
+            {"average request rate", td.AvgRequestRate, baseline.AvgRequestRate},
+            {"peak request rate", td.PeakRequestRate, baseline.PeakRequestRate},
+        } {
+            if r.baseline <= 0 || r.rate < r.baseline*anomalyRateMultiple {
+                continue
+            }
+            multiple := r.rate / r.baseline
+            reasons = append(reasons, fmt.Sprintf("%s %.1fx baseline", r.name, multiple))
+            high = high || multiple >= highAnomalyRateMultiple
+        }
+
+        ratio := failedLoginRatio(td)
+        if ratio >= minFailedLoginRatio && ratio >= baselineRatio*anomalyRateMultiple {
+            reasons = append(reasons, fmt.Sprintf("failed-login ratio %.2f (baseline %.2f)", ratio, baselineRatio))
+            high = high || ratio >= highFailedLoginRatio
+        }
+
+        if len(reasons) == 0 {
+            continue
+        }
+        severity := "Medium"
+        if high {
+            severity = "High"
+        }
+        anomalies = append(anomalies, TrafficAnomaly{
+            Description: strings.Join(reasons, "; "),
+            SourceIP:    td.SourceIP,
+            DetectedAt:  now,
+            Severity:    severity,
+        })
+    }
+
+    anomalyHooksMu.RLock()
+    hooks := anomalyHooks
+    anomalyHooksMu.RUnlock()
+    for _, anomaly := range anomalies {
+        if anomaly.Severity != "High" {
+            continue
+        }
+        log.Printf("[WARNING] High severity traffic anomaly from %s: %s", anomaly.SourceIP, anomaly.Description)
+        for _, hook := range hooks {
+            hook(anomaly)
+        }
+    }
+    return anomalies
+}
+
+// failedLoginRatio returns the fraction of a source's requests that were failed logins.
+func failedLoginRatio(td TrafficData) float64 {
+    if td.RequestCount <= 0 {
+        return 0
+    }
+    return float64(td.FailedLogins) / float64(td.RequestCount)
+}
@@ -0,0 +1,853 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newAPIQuotaLedger(t *testing.T, quota int) *AdvancedSecurityLedger {
+	l := &AdvancedSecurityLedger{APIUsageWindow: time.Minute}
+	if err := l.SetAPIQuota("api-1", quota); err != nil {
+		t.Fatalf("SetAPIQuota: %v", err)
+	}
+	return l
+}
+
+func TestRecordAPIUsageRejectsCallsOverQuota(t *testing.T) {
+	l := newAPIQuotaLedger(t, 3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if allowed, err := l.RecordAPIUsage("api-1", now); !allowed || err != nil {
+			t.Fatalf("call %d: allowed = %v, err = %v; want it allowed", i, allowed, err)
+		}
+	}
+	if allowed, err := l.RecordAPIUsage("api-1", now); allowed || err == nil {
+		t.Fatal("expected the call over quota to be rejected")
+	}
+
+	count, lastUsed := l.APIUsageStats("api-1")
+	if count != 3 || !lastUsed.Equal(now) {
+		t.Fatalf("APIUsageStats = %d, %v; want 3, %v", count, lastUsed, now)
+	}
+}
+
+func TestRecordAPIUsageSlidingWindowBlocksBoundaryBurst(t *testing.T) {
+	l := newAPIQuotaLedger(t, 4)
+	start := time.Now()
+
+	// Use the whole quota at the end of the first window.
+	for i := 0; i < 4; i++ {
+		if allowed, _ := l.RecordAPIUsage("api-1", start.Add(59*time.Second)); !allowed {
+			t.Fatalf("call %d rejected, want it allowed", i)
+		}
+	}
+
+	// Just past the boundary the sliding window still holds nearly all of them.
+	if allowed, _ := l.RecordAPIUsage("api-1", start.Add(61*time.Second)); allowed {
+		t.Fatal("expected a burst straddling the window boundary to be rejected")
+	}
+}
+
+func TestRecordAPIUsageWindowResetsUsage(t *testing.T) {
+	l := newAPIQuotaLedger(t, 2)
+	start := time.Now()
+
+	for i := 0; i < 2; i++ {
+		l.RecordAPIUsage("api-1", start)
+	}
+	if allowed, _ := l.RecordAPIUsage("api-1", start.Add(30*time.Second)); allowed {
+		t.Fatal("expected the quota to still be exhausted within the window")
+	}
+
+	// Once a full window has slid past the earlier calls they no longer count.
+	if allowed, err := l.RecordAPIUsage("api-1", start.Add(2*time.Minute)); !allowed || err != nil {
+		t.Fatalf("allowed = %v, err = %v; want the call allowed after the window passed", allowed, err)
+	}
+	if count, _ := l.APIUsageStats("api-1"); count != 1 {
+		t.Fatalf("count = %d, want 1", count)
+	}
+}
+
+func TestRecordHealthEventScoresFromSeverity(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	now := time.Now()
+
+	// The description mentions "down" but the structured severity says healthy.
+	if err := l.RecordHealthEvent("scheduled shutdown of the staging node", HealthSeverityHealthy, now); err != nil {
+		t.Fatalf("RecordHealthEvent: %v", err)
+	}
+	if got := l.HealthEvents[0].Score; got != 1 {
+		t.Fatalf("score = %v, want 1", got)
+	}
+	if err := l.RecordHealthEvent("no errors", "fine", now); err == nil {
+		t.Fatal("expected an unknown severity to be rejected")
+	}
+}
+
+func TestDegradationAlertFiresOnDegradingTrend(t *testing.T) {
+	l := &AdvancedSecurityLedger{HealthTrendWindow: time.Hour}
+	now := time.Now()
+
+	l.RecordHealthEvent("all checks passing", HealthSeverityHealthy, now.Add(-50*time.Minute))
+	l.RecordHealthEvent("replica lag rising", HealthSeverityDegraded, now.Add(-20*time.Minute))
+	l.RecordHealthEvent("primary unreachable", HealthSeverityCritical, now.Add(-5*time.Minute))
+
+	alert, err := l.DegradationAlert(0.8, now)
+	if err != nil {
+		t.Fatalf("DegradationAlert: %v", err)
+	}
+	if alert == nil {
+		t.Fatal("expected a degrading trend below the threshold to raise an alert")
+	}
+}
+
+func TestDegradationAlertQuietOnStableTrend(t *testing.T) {
+	l := &AdvancedSecurityLedger{HealthTrendWindow: time.Hour}
+	now := time.Now()
+
+	l.RecordHealthEvent("all checks passing", HealthSeverityHealthy, now.Add(-50*time.Minute))
+	l.RecordHealthEvent("all checks passing", HealthSeverityHealthy, now.Add(-5*time.Minute))
+
+	alert, err := l.DegradationAlert(0.8, now)
+	if err != nil {
+		t.Fatalf("DegradationAlert: %v", err)
+	}
+	if alert != nil {
+		t.Fatalf("alert = %+v, want none for a stable trend", alert)
+	}
+}
+
+func TestIsolateEntityBlocksOperationsUntilReleased(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+
+	if _, err := l.IsolateEntity("node-7", "suspected compromise", time.Now()); err != nil {
+		t.Fatalf("IsolateEntity: %v", err)
+	}
+	if !l.IsIsolated("node-7") {
+		t.Fatal("expected node-7 to be isolated")
+	}
+	if err := l.AuthorizeEntityOperation("node-7", "transaction"); err == nil {
+		t.Fatal("expected operations from an isolated entity to be blocked")
+	}
+	if err := l.AuthorizeEntityOperation("node-8", "transaction"); err != nil {
+		t.Fatalf("AuthorizeEntityOperation(node-8): %v, want other entities unaffected", err)
+	}
+
+	if err := l.ReleaseIsolation("node-7"); err != nil {
+		t.Fatalf("ReleaseIsolation: %v", err)
+	}
+	if err := l.AuthorizeEntityOperation("node-7", "transaction"); err != nil {
+		t.Fatalf("AuthorizeEntityOperation after release: %v", err)
+	}
+}
+
+func newThreatLevelLedger(t *testing.T, thresholds map[int]string) *AdvancedSecurityLedger {
+	l := &AdvancedSecurityLedger{MitigationPlans: make(map[string]MitigationPlan)}
+	for level, planID := range thresholds {
+		l.MitigationPlans[planID] = MitigationPlan{PlanID: planID}
+		if err := l.SetThreatThreshold(level, planID); err != nil {
+			t.Fatalf("SetThreatThreshold: %v", err)
+		}
+	}
+	return l
+}
+
+func TestSetThreatLevelActivatesAndDeactivatesPlan(t *testing.T) {
+	l := newThreatLevelLedger(t, map[int]string{5: "plan-lockdown"})
+	now := time.Now()
+
+	if _, err := l.SetThreatLevel(6, now); err != nil {
+		t.Fatalf("SetThreatLevel: %v", err)
+	}
+	if !l.MitigationPlans["plan-lockdown"].Activated {
+		t.Fatal("expected the plan to activate when the level rose past its threshold")
+	}
+	if got := l.CurrentThreatLevel(); got != 6 {
+		t.Fatalf("CurrentThreatLevel = %d, want 6", got)
+	}
+
+	if _, err := l.SetThreatLevel(2, now); err != nil {
+		t.Fatalf("SetThreatLevel: %v", err)
+	}
+	if l.MitigationPlans["plan-lockdown"].Activated {
+		t.Fatal("expected the plan to deactivate when the level dropped below its threshold")
+	}
+}
+
+func TestSetThreatLevelKeepsSharedPlanActive(t *testing.T) {
+	l := newThreatLevelLedger(t, map[int]string{3: "plan-shared", 7: "plan-shared"})
+	now := time.Now()
+
+	l.SetThreatLevel(8, now)
+	// Dropping below 7 still leaves the level above the plan's other threshold of 3.
+	for i := 0; i < 20; i++ {
+		l.SetThreatLevel(5, now)
+		if !l.MitigationPlans["plan-shared"].Activated {
+			t.Fatal("expected a plan with a threshold still reached to stay active")
+		}
+		l.SetThreatLevel(8, now)
+	}
+}
+
+func newIncidentProtocolLedger() *AdvancedSecurityLedger {
+	return &AdvancedSecurityLedger{
+		IncidentProtocols: map[string]IncidentProtocol{
+			"breach": {ProtocolID: "breach", Description: "Data breach response"},
+		},
+	}
+}
+
+func TestIncidentLifecycleActivateEventResolve(t *testing.T) {
+	l := newIncidentProtocolLedger()
+	now := time.Now()
+
+	activation, err := l.ActivateProtocol("breach", now)
+	if err != nil {
+		t.Fatalf("ActivateProtocol: %v", err)
+	}
+	if !l.IncidentProtocols["breach"].Activated {
+		t.Fatal("expected protocol to be active")
+	}
+
+	event, err := l.RecordIncidentEvent(activation.IncidentID, "credentials rotated", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("RecordIncidentEvent: %v", err)
+	}
+	if event.IncidentID != activation.IncidentID {
+		t.Fatalf("event incident = %s, want %s", event.IncidentID, activation.IncidentID)
+	}
+
+	if _, err := l.ResolveIncident(activation.IncidentID, "contained", now.Add(time.Hour)); err != nil {
+		t.Fatalf("ResolveIncident: %v", err)
+	}
+	protocol := l.IncidentProtocols["breach"]
+	if protocol.Activated || protocol.DeactivatedAt.IsZero() {
+		t.Fatalf("protocol = %+v, want deactivated", protocol)
+	}
+	if got := l.IncidentEvents[event.EventID].Resolution; got != "contained" {
+		t.Fatalf("event resolution = %q, want contained", got)
+	}
+}
+
+func TestRecordIncidentEventRejectsResolvedIncident(t *testing.T) {
+	l := newIncidentProtocolLedger()
+	now := time.Now()
+
+	activation, err := l.ActivateProtocol("breach", now)
+	if err != nil {
+		t.Fatalf("ActivateProtocol: %v", err)
+	}
+	if _, err := l.ResolveIncident(activation.IncidentID, "contained", now); err != nil {
+		t.Fatalf("ResolveIncident: %v", err)
+	}
+
+	if _, err := l.RecordIncidentEvent(activation.IncidentID, "late update", now.Add(time.Minute)); err == nil {
+		t.Fatal("expected events on a resolved incident to be rejected")
+	}
+	if len(l.IncidentEvents) != 0 {
+		t.Fatalf("recorded %d events, want none", len(l.IncidentEvents))
+	}
+}
+
+func TestCheckProtocolConformanceAcceptsConformingNode(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	spec := map[string]interface{}{"version": "2.1", "blockTime": 5}
+
+	deviation, err := l.CheckProtocolConformance("node-1", map[string]interface{}{"version": "2.1", "blockTime": 5}, spec)
+	if err != nil {
+		t.Fatalf("CheckProtocolConformance: %v", err)
+	}
+	if deviation != nil || len(l.ProtocolDeviations) != 0 {
+		t.Fatalf("deviation = %+v, want none for a conforming node", deviation)
+	}
+}
+
+func TestCheckProtocolConformanceRecordsDeviation(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	spec := map[string]interface{}{"version": "2.1", "blockTime": 5}
+	observed := map[string]interface{}{"version": "2.0"}
+
+	deviation, err := l.CheckProtocolConformance("node-2", observed, spec)
+	if err != nil {
+		t.Fatalf("CheckProtocolConformance: %v", err)
+	}
+	if deviation == nil || deviation.NodeID != "node-2" {
+		t.Fatalf("deviation = %+v, want one for node-2", deviation)
+	}
+	for _, want := range []string{"blockTime missing", "version=2.0 (expected 2.1)"} {
+		if !strings.Contains(deviation.Description, want) {
+			t.Errorf("description %q does not mention %q", deviation.Description, want)
+		}
+	}
+	if len(l.SuspiciousActivityLog) != 0 {
+		t.Fatal("a single deviation should not flag the node as suspicious")
+	}
+
+	for i := 1; i < suspiciousDeviationThreshold; i++ {
+		if _, err := l.CheckProtocolConformance("node-2", observed, spec); err != nil {
+			t.Fatalf("CheckProtocolConformance: %v", err)
+		}
+	}
+	if len(l.SuspiciousActivityLog) != 1 {
+		t.Fatalf("suspicious activity records = %d, want 1 after repeated deviations", len(l.SuspiciousActivityLog))
+	}
+}
+
+func TestCorrelateAnomaliesIgnoresScatteredAnomalies(t *testing.T) {
+	now := time.Now()
+	l := &AdvancedSecurityLedger{
+		AnomalyCorrelationThreshold: 3,
+		ConsensusAnomalies: map[string]ConsensusAnomaly{
+			"a1": {AnomalyID: "a1", Description: "double vote", DetectedAt: now.Add(-time.Minute)},
+			"a2": {AnomalyID: "a2", Description: "late proposal", DetectedAt: now.Add(-2 * time.Minute)},
+			"a3": {AnomalyID: "a3", Description: "double vote", DetectedAt: now.Add(-3 * time.Hour)},
+			"a4": {AnomalyID: "a4", Description: "double vote", DetectedAt: now.Add(-4 * time.Hour)},
+		},
+	}
+
+	incidents, err := l.CorrelateAnomalies(10*time.Minute, now)
+	if err != nil {
+		t.Fatalf("CorrelateAnomalies: %v", err)
+	}
+	if len(incidents) != 0 || len(l.IncidentEvents) != 0 {
+		t.Fatalf("incidents = %+v, want none for scattered anomalies", incidents)
+	}
+}
+
+func TestCorrelateAnomaliesRaisesIncidentForCluster(t *testing.T) {
+	now := time.Now()
+	l := &AdvancedSecurityLedger{
+		AnomalyCorrelationThreshold: 3,
+		ConsensusAnomalies: map[string]ConsensusAnomaly{
+			"a1": {AnomalyID: "a1", Description: "double vote", DetectedAt: now.Add(-time.Minute)},
+			"a2": {AnomalyID: "a2", Description: "double vote", DetectedAt: now.Add(-2 * time.Minute)},
+			"a3": {AnomalyID: "a3", Description: "double vote", DetectedAt: now.Add(-3 * time.Minute)},
+			"a4": {AnomalyID: "a4", Description: "late proposal", DetectedAt: now.Add(-time.Minute)},
+		},
+	}
+
+	incidents, err := l.CorrelateAnomalies(10*time.Minute, now)
+	if err != nil {
+		t.Fatalf("CorrelateAnomalies: %v", err)
+	}
+	if len(incidents) != 1 {
+		t.Fatalf("incidents = %d, want 1", len(incidents))
+	}
+	if _, recorded := l.IncidentEvents[incidents[0].EventID]; !recorded {
+		t.Fatal("expected the incident to be recorded in IncidentEvents")
+	}
+	for _, id := range []string{"a1", "a2", "a3"} {
+		if l.CorrelatedAnomalies[id] != incidents[0].EventID {
+			t.Errorf("anomaly %s not linked to incident %s", id, incidents[0].EventID)
+		}
+	}
+
+	again, err := l.CorrelateAnomalies(10*time.Minute, now)
+	if err != nil {
+		t.Fatalf("CorrelateAnomalies: %v", err)
+	}
+	if len(again) != 0 {
+		t.Fatalf("correlated anomalies raised %d more incidents, want 0", len(again))
+	}
+}
+
+func newRetentionLedger(now time.Time) *AdvancedSecurityLedger {
+	day := 24 * time.Hour
+	return &AdvancedSecurityLedger{
+		RetentionPolicies: map[string]RetentionPolicy{
+			"compliant": {PolicyID: "compliant", ComplianceDate: now.Add(90 * day)},
+			"nearing":   {PolicyID: "nearing", ComplianceDate: now.Add(2*day + time.Hour)},
+			"overdue":   {PolicyID: "overdue", ComplianceDate: now.Add(-3 * day)},
+		},
+	}
+}
+
+func TestCheckRetentionCompliance(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		policyID      string
+		wantCompliant bool
+		wantDays      int
+	}{
+		{"compliant", true, 90},
+		{"nearing", true, 2},
+		{"overdue", false, -3},
+	}
+	for _, tt := range tests {
+		l := newRetentionLedger(now)
+		compliant, days, err := l.CheckRetentionCompliance(tt.policyID, now)
+		if err != nil {
+			t.Fatalf("CheckRetentionCompliance(%s): %v", tt.policyID, err)
+		}
+		if compliant != tt.wantCompliant || days != tt.wantDays {
+			t.Errorf("CheckRetentionCompliance(%s) = %v, %d; want %v, %d", tt.policyID, compliant, days, tt.wantCompliant, tt.wantDays)
+		}
+		if _, alerted := l.SystemAlerts["retention-"+tt.policyID]; alerted == tt.wantCompliant {
+			t.Errorf("policy %s alert raised = %v, want %v", tt.policyID, alerted, !tt.wantCompliant)
+		}
+	}
+}
+
+func TestNonCompliantPoliciesListsOverduePolicies(t *testing.T) {
+	now := time.Now()
+	l := newRetentionLedger(now)
+
+	overdue := l.NonCompliantPolicies(now)
+	if len(overdue) != 1 || overdue[0] != "overdue" {
+		t.Fatalf("NonCompliantPolicies = %v, want [overdue]", overdue)
+	}
+}
+
+func newEscalationLedger(t *testing.T) *AdvancedSecurityLedger {
+	t.Helper()
+	l := &AdvancedSecurityLedger{}
+	stages := [][]string{{"notify on-call"}, {"page security lead", "freeze deployments"}}
+	if err := l.DefineEscalationProtocol("outage", "Outage escalation", stages); err != nil {
+		t.Fatalf("DefineEscalationProtocol: %v", err)
+	}
+	return l
+}
+
+func TestEscalateAdvancesThroughStages(t *testing.T) {
+	l := newEscalationLedger(t)
+	now := time.Now()
+
+	next, actions, err := l.Escalate("outage", 0, now)
+	if err != nil || next != 1 || len(actions) != 1 || actions[0] != "notify on-call" {
+		t.Fatalf("Escalate(0) = %d, %v, %v; want 1, [notify on-call], nil", next, actions, err)
+	}
+	if _, _, err := l.Escalate("outage", 0, now); err == nil {
+		t.Fatal("expected re-running stage 0 to be rejected")
+	}
+
+	next, actions, err = l.Escalate("outage", 1, now)
+	if err != nil || next != 2 || len(actions) != 2 {
+		t.Fatalf("Escalate(1) = %d, %v, %v; want 2, two actions, nil", next, actions, err)
+	}
+	if _, _, err := l.Escalate("outage", 2, now); err == nil {
+		t.Fatal("expected escalation past the final stage to be rejected")
+	}
+}
+
+func TestResetEscalationRestartsAtFirstStage(t *testing.T) {
+	l := newEscalationLedger(t)
+	now := time.Now()
+
+	if _, _, err := l.Escalate("outage", 0, now); err != nil {
+		t.Fatalf("Escalate: %v", err)
+	}
+	l.ResetEscalation("outage")
+
+	if _, recorded := l.EscalationTimestamp["outage"]; recorded {
+		t.Fatal("expected the escalation timestamp to be cleared")
+	}
+	next, actions, err := l.Escalate("outage", 0, now)
+	if err != nil || next != 1 || actions[0] != "notify on-call" {
+		t.Fatalf("Escalate(0) after reset = %d, %v, %v; want 1, [notify on-call], nil", next, actions, err)
+	}
+}
+
+func TestCheckNodeAccessAllowsWithinLimit(t *testing.T) {
+	l := &AdvancedSecurityLedger{AccessLimitWindow: time.Minute}
+	now := time.Now()
+	if err := l.SetNodeAccessLimit("node-1", 2, now); err != nil {
+		t.Fatalf("SetNodeAccessLimit: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		allowed, err := l.CheckNodeAccess("node-1", now.Add(time.Duration(i)*time.Second))
+		if err != nil || !allowed {
+			t.Fatalf("access %d = %v, %v; want allowed", i+1, allowed, err)
+		}
+	}
+	if got := l.NodeAccessCounts["node-1"].LastAccess; !got.Equal(now.Add(time.Second)) {
+		t.Fatalf("LastAccess = %v, want %v", got, now.Add(time.Second))
+	}
+
+	allowed, err := l.CheckNodeAccess("node-2", now)
+	if err != nil || !allowed {
+		t.Fatalf("unlimited node access = %v, %v; want allowed", allowed, err)
+	}
+}
+
+func TestCheckNodeAccessRejectsOverLimit(t *testing.T) {
+	l := &AdvancedSecurityLedger{AccessLimitWindow: time.Minute}
+	now := time.Now()
+	if err := l.SetNodeAccessLimit("node-1", 2, now); err != nil {
+		t.Fatalf("SetNodeAccessLimit: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := l.CheckNodeAccess("node-1", now); err != nil {
+			t.Fatalf("CheckNodeAccess: %v", err)
+		}
+	}
+	allowed, err := l.CheckNodeAccess("node-1", now.Add(time.Second))
+	if err == nil || allowed {
+		t.Fatalf("third access = %v, %v; want rejected", allowed, err)
+	}
+
+	allowed, err = l.CheckNodeAccess("node-1", now.Add(time.Minute))
+	if err != nil || !allowed {
+		t.Fatalf("access in the next window = %v, %v; want allowed", allowed, err)
+	}
+}
+
+func TestSetSecurityLevelRecordsAuditAndRestrictions(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	now := time.Now()
+
+	if _, err := l.SetSecurityLevel("network", "medium", "alice", now); err != nil {
+		t.Fatalf("SetSecurityLevel(medium): %v", err)
+	}
+	record, err := l.SetSecurityLevel("network", "high", "bob", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("SetSecurityLevel(high): %v", err)
+	}
+	if record.PreviousLevel != "medium" || record.Level != "high" || record.ChangedBy != "bob" {
+		t.Fatalf("record = %+v, want medium -> high by bob", record)
+	}
+	if len(l.SecurityPolicies) != 2 {
+		t.Fatalf("audit records = %d, want 2", len(l.SecurityPolicies))
+	}
+	if !l.IsOperationDisabled("contract-deployment") {
+		t.Fatal("expected contract deployment to be disabled at high level")
+	}
+
+	if _, err := l.SetSecurityLevel("network", "low", "bob", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("SetSecurityLevel(low): %v", err)
+	}
+	if l.IsOperationDisabled("contract-deployment") {
+		t.Fatal("expected lowering the level to lift its restrictions")
+	}
+}
+
+func TestSetSecurityLevelRejectsInvalidLevel(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+
+	if _, err := l.SetSecurityLevel("network", "extreme", "alice", time.Now()); err == nil {
+		t.Fatal("expected an unknown security level to be rejected")
+	}
+	if len(l.SecurityPolicies) != 0 || len(l.SecurityLevels) != 0 {
+		t.Fatal("a rejected transition must not be recorded")
+	}
+}
+
+func TestSubscribeReceivesMatchingAnomalies(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	events, unsubscribe := l.Subscribe(func(event AnomalyEvent) bool {
+		return strings.HasPrefix(event.Event, "consensus:")
+	})
+	defer unsubscribe()
+
+	l.PublishAnomaly(AnomalyEvent{Event: "network: latency spike"})
+	l.PublishAnomaly(AnomalyEvent{Event: "consensus: double vote"})
+
+	select {
+	case event := <-events:
+		if event.Event != "consensus: double vote" {
+			t.Fatalf("received %q, want the consensus anomaly", event.Event)
+		}
+	default:
+		t.Fatal("expected the matching anomaly to be delivered")
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("received unexpected event %q", event.Event)
+	default:
+	}
+}
+
+func TestPublishAnomalyDropsOldestForSlowSubscriber(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	events, unsubscribe := l.Subscribe(nil)
+	defer unsubscribe()
+
+	for i := 0; i <= anomalySubscriberBuffer; i++ {
+		l.PublishAnomaly(AnomalyEvent{Event: fmt.Sprintf("event-%d", i)})
+	}
+
+	if first := <-events; first.Event != "event-1" {
+		t.Fatalf("first buffered event = %q, want event-1 after event-0 was dropped", first.Event)
+	}
+}
+
+func TestUnsubscribeStopsDelivery(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	events, unsubscribe := l.Subscribe(nil)
+
+	unsubscribe()
+	unsubscribe()
+	l.PublishAnomaly(AnomalyEvent{Event: "consensus: double vote"})
+
+	if event, open := <-events; open {
+		t.Fatalf("received %q after unsubscribing", event.Event)
+	}
+}
+
+func TestRunDetectionCheckAlertsWhenSystemGoesDown(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	var probeErr error
+	if err := l.RegisterDetectionProbe("ids", func() (int, error) { return 2, probeErr }); err != nil {
+		t.Fatalf("RegisterDetectionProbe: %v", err)
+	}
+	now := time.Now()
+
+	status, err := l.RunDetectionCheck("ids", now)
+	if err != nil {
+		t.Fatalf("RunDetectionCheck: %v", err)
+	}
+	if !status.Operational || status.DetectedAnomalies != 2 {
+		t.Fatalf("status = %+v, want operational with 2 anomalies", status)
+	}
+	if len(l.SystemAlerts) != 0 {
+		t.Fatal("a healthy check should not raise an alert")
+	}
+
+	probeErr = fmt.Errorf("sensor unreachable")
+	status, err = l.RunDetectionCheck("ids", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("RunDetectionCheck: %v", err)
+	}
+	if status.Operational || !status.LastChecked.Equal(now.Add(time.Minute)) || status.DetectedAnomalies != 2 {
+		t.Fatalf("status = %+v, want non-operational, rechecked and anomalies kept", status)
+	}
+	if _, alerted := l.SystemAlerts["detection-ids"]; !alerted {
+		t.Fatal("expected an alert when an operational system goes down")
+	}
+}
+
+func TestRunDetectionCheckDoesNotAlertForUncheckedSystem(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	if err := l.RegisterDetectionProbe("ids", func() (int, error) { return 0, fmt.Errorf("starting up") }); err != nil {
+		t.Fatalf("RegisterDetectionProbe: %v", err)
+	}
+
+	if _, err := l.RunDetectionCheck("ids", time.Now()); err != nil {
+		t.Fatalf("RunDetectionCheck: %v", err)
+	}
+	if len(l.SystemAlerts) != 0 {
+		t.Fatal("a system that was never operational should not raise an alert")
+	}
+}
+
+func TestStaleMonitorsDetectsQuietMonitor(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	now := time.Now()
+	l.RecordMonitoredEvent("mempool", "tx flood", now.Add(-10*time.Minute))
+	l.RecordMonitoredEvent("consensus", "round timeout", now.Add(-time.Minute))
+	l.RecordMonitoredEvent("consensus", "round timeout", now)
+
+	if got := l.EventMonitoringStatus["consensus"].EventCount; got != 2 {
+		t.Fatalf("consensus EventCount = %d, want 2", got)
+	}
+	stale := l.StaleMonitors(5*time.Minute, now)
+	if len(stale) != 1 || stale[0] != "mempool" {
+		t.Fatalf("StaleMonitors = %v, want [mempool]", stale)
+	}
+}
+
+func TestMonitoringSummaryCountsMonitors(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	now := time.Now()
+	l.RecordMonitoredEvent("mempool", "tx flood", now)
+	l.RecordMonitoredEvent("consensus", "round timeout", now)
+	l.EventMonitoringStatus["p2p"] = EventMonitoringStatus{MonitorID: "p2p"}
+
+	active, inactive := l.MonitoringSummary()
+	if active != 2 || inactive != 1 {
+		t.Fatalf("MonitoringSummary = %d, %d; want 2, 1", active, inactive)
+	}
+	if stale := l.StaleMonitors(time.Minute, now.Add(time.Hour)); len(stale) != 2 {
+		t.Fatalf("StaleMonitors = %v, want only the two active monitors", stale)
+	}
+}
+
+func TestLinkResolutionClosesIncidentReport(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	now := time.Now()
+
+	report, err := l.FileIncidentReport("unauthorised key use", now)
+	if err != nil {
+		t.Fatalf("FileIncidentReport: %v", err)
+	}
+	if report.Status != "Open" {
+		t.Fatalf("report status = %q, want Open", report.Status)
+	}
+
+	resolution, err := l.LinkResolution(report.IncidentID, "Mitigated", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("LinkResolution: %v", err)
+	}
+	if resolution.IncidentID != report.IncidentID {
+		t.Fatalf("resolution linked to %s, want %s", resolution.IncidentID, report.IncidentID)
+	}
+	if got := l.IncidentReports[report.IncidentID].Status; got != "Mitigated" {
+		t.Fatalf("report status = %q, want Mitigated", got)
+	}
+	if _, err := l.LinkResolution(report.IncidentID, "Closed", now.Add(2*time.Hour)); err == nil {
+		t.Fatal("expected resolving an incident twice to be rejected")
+	}
+}
+
+func TestOpenIncidentsExcludesResolvedReports(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	now := time.Now()
+
+	first, _ := l.FileIncidentReport("phishing attempt", now)
+	second, _ := l.FileIncidentReport("node compromise", now.Add(time.Second))
+	if _, err := l.LinkResolution(first.IncidentID, "Closed", now.Add(time.Minute)); err != nil {
+		t.Fatalf("LinkResolution: %v", err)
+	}
+
+	open := l.OpenIncidents()
+	if len(open) != 1 || open[0].IncidentID != second.IncidentID {
+		t.Fatalf("OpenIncidents = %+v, want only %s", open, second.IncidentID)
+	}
+}
+
+func healthCheck(ok bool, detail string) func() (bool, string) {
+	return func() (bool, string) { return ok, detail }
+}
+
+func TestVerifyHealthScoresHealthySystem(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	checks := []func() (bool, string){
+		healthCheck(true, "disk ok"),
+		healthCheck(true, "peers ok"),
+		healthCheck(true, "clock ok"),
+		healthCheck(true, "memory ok"),
+		healthCheck(false, "cpu high"),
+	}
+
+	verification, err := l.VerifyHealth("node-1", checks)
+	if err != nil {
+		t.Fatalf("VerifyHealth: %v", err)
+	}
+	if verification.VerificationScore != 80 || verification.HealthStatus != "Healthy" {
+		t.Fatalf("verification = %d %s, want 80 Healthy", verification.VerificationScore, verification.HealthStatus)
+	}
+	if len(verification.VerificationLog) != len(checks) {
+		t.Fatalf("logged %d checks, want %d", len(verification.VerificationLog), len(checks))
+	}
+	if l.RequiresRemediation("node-1", 70) {
+		t.Fatal("a healthy system should not require remediation")
+	}
+}
+
+func TestVerifyHealthFlagsLowScoreForRemediation(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	checks := []func() (bool, string){
+		healthCheck(true, "disk ok"),
+		healthCheck(false, "peers unreachable"),
+		healthCheck(false, "clock skew"),
+	}
+
+	verification, err := l.VerifyHealth("node-2", checks)
+	if err != nil {
+		t.Fatalf("VerifyHealth: %v", err)
+	}
+	if verification.VerificationScore != 33 || verification.HealthStatus != "Unhealthy" {
+		t.Fatalf("verification = %d %s, want 33 Unhealthy", verification.VerificationScore, verification.HealthStatus)
+	}
+	if !l.RequiresRemediation("node-2", 70) {
+		t.Fatal("expected a low-scoring system to require remediation")
+	}
+	if !l.RequiresRemediation("node-unknown", 70) {
+		t.Fatal("expected an unverified system to require remediation")
+	}
+}
+
+func newAlertResponseLedger(t *testing.T) (*AdvancedSecurityLedger, *[]string) {
+	t.Helper()
+	l := &AdvancedSecurityLedger{}
+	var executed []string
+	handlers := map[string]AlertActionHandler{
+		"block-ip": func(alertID string) error {
+			executed = append(executed, "block-ip")
+			return nil
+		},
+		"rotate-keys": func(alertID string) error {
+			executed = append(executed, "rotate-keys")
+			return nil
+		},
+		"restart-node": func(alertID string) error {
+			return fmt.Errorf("node unreachable")
+		},
+	}
+	for action, handler := range handlers {
+		if err := l.RegisterAlertAction(action, handler); err != nil {
+			t.Fatalf("RegisterAlertAction(%s): %v", action, err)
+		}
+	}
+	return l, &executed
+}
+
+func TestRespondToAlertResolvesWhenAllActionsSucceed(t *testing.T) {
+	l, executed := newAlertResponseLedger(t)
+
+	response, err := l.RespondToAlert("alert-1", []string{"block-ip", "rotate-keys"}, "alice", time.Now())
+	if err != nil {
+		t.Fatalf("RespondToAlert: %v", err)
+	}
+	if response.Status != "Resolved" || len(response.ResponseActions) != 2 {
+		t.Fatalf("response = %+v, want Resolved with both actions", response)
+	}
+	if len(*executed) != 2 {
+		t.Fatalf("executed %v, want both handlers run", *executed)
+	}
+	if _, recorded := l.AlertResponses["alert-1"]; !recorded {
+		t.Fatal("expected the response to be recorded")
+	}
+}
+
+func TestRespondToAlertEscalatesOnFailedAction(t *testing.T) {
+	l, _ := newAlertResponseLedger(t)
+
+	response, err := l.RespondToAlert("alert-2", []string{"block-ip", "restart-node", "unknown-action"}, "alice", time.Now())
+	if err != nil {
+		t.Fatalf("RespondToAlert: %v", err)
+	}
+	if response.Status != "Escalated" {
+		t.Fatalf("status = %q, want Escalated", response.Status)
+	}
+	if len(response.ResponseActions) != 1 || response.ResponseActions[0] != "block-ip" {
+		t.Fatalf("succeeded actions = %v, want [block-ip]", response.ResponseActions)
+	}
+}
+
+func TestThreatMitigationStepsReachMitigated(t *testing.T) {
+	l := &AdvancedSecurityLedger{}
+	event, err := l.RecordThreatEvent("DDoS", "SYN flood on RPC port", []string{"rpc-1"}, time.Now())
+	if err != nil {
+		t.Fatalf("RecordThreatEvent: %v", err)
+	}
+	if len(event.MitigationSteps) != 3 {
+		t.Fatalf("mitigation steps = %v, want the DDoS playbook", event.MitigationSteps)
+	}
+	if got := l.ThreatDetectionStatus[event.EventID].Status; got != "Detected" {
+		t.Fatalf("status = %q, want Detected", got)
+	}
+
+	for i := range event.MitigationSteps {
+		if l.ThreatFullyMitigated(event.EventID) {
+			t.Fatalf("threat reported mitigated after %d of %d steps", i, len(event.MitigationSteps))
+		}
+		if err := l.ExecuteMitigationStep(event.EventID, i); err != nil {
+			t.Fatalf("ExecuteMitigationStep(%d): %v", i, err)
+		}
+	}
+
+	if !l.ThreatFullyMitigated(event.EventID) {
+		t.Fatal("expected the threat to be fully mitigated")
+	}
+	if got := l.ThreatDetectionStatus[event.EventID].Status; got != "Mitigated" {
+		t.Fatalf("status = %q, want Mitigated", got)
+	}
+	if err := l.ExecuteMitigationStep(event.EventID, 0); err == nil {
+		t.Fatal("expected re-running a completed step to be rejected")
+	}
+}
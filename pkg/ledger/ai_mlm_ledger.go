@@ -1,6 +1,7 @@
 package ledger
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -794,3 +795,130 @@ func (l *AiMLMLedger) RecordModelUpdate(transactionID, modelID, description stri
 	return nil
 }
 
+
+// recommendationCacheKey builds the CacheRecords key for a model's cached recommendation.
+func recommendationCacheKey(modelID, txID string) string {
+	return modelID + ":" + txID
+}
+
+// SetRecommendationKey sets the 32-byte AES key used to encrypt cached recommendations.
+func (l *AiMLMLedger) SetRecommendationKey(key []byte) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if len(key) != 32 {
+		return errors.New("recommendation key must be 32 bytes long")
+	}
+	l.RecommendationKey = append([]byte(nil), key...)
+	return nil
+}
+
+// CacheRecommendationEncrypted encrypts a recommendation and stores it in the model's cache.
+func (l *AiMLMLedger) CacheRecommendationEncrypted(modelID, txID string, rec []byte) error {
+	if modelID == "" || txID == "" {
+		return fmt.Errorf("invalid input: modelID and txID must be non-empty")
+	}
+	if len(rec) == 0 {
+		return fmt.Errorf("invalid input: recommendation must be non-empty")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	model, exists := l.Models[modelID]
+	if !exists {
+		return fmt.Errorf("model %s not found", modelID)
+	}
+
+	if len(l.RecommendationKey) == 0 {
+		return errors.New("no recommendation key set")
+	}
+	encrypted, err := (&Encryption{}).EncryptData("AES", rec, l.RecommendationKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt recommendation: %v", err)
+	}
+
+	if model.RecommendationCache == nil {
+		model.RecommendationCache = make(map[string][]byte)
+	}
+	model.RecommendationCache[txID] = encrypted
+	l.Models[modelID] = model
+
+	if l.CacheRecords == nil {
+		l.CacheRecords = make(map[string]CacheData)
+	}
+	l.CacheRecords[recommendationCacheKey(modelID, txID)] = CacheData{
+		ModelID:   modelID,
+		DataID:    txID,
+		Data:      hex.EncodeToString(encrypted),
+		CreatedAt: time.Now(),
+	}
+
+	if l.EncryptionLogs == nil {
+		l.EncryptionLogs = make(map[string]EncryptionLog)
+	}
+	l.RecordEncryption(txID, hex.EncodeToString(encrypted))
+
+	log.Printf("[INFO] Recommendation cached: ModelID=%s, TransactionID=%s", modelID, txID)
+	return nil
+}
+
+// GetCachedRecommendation retrieves and decrypts a cached recommendation for a model.
+func (l *AiMLMLedger) GetCachedRecommendation(modelID, txID string) ([]byte, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	model, exists := l.Models[modelID]
+	if !exists {
+		return nil, fmt.Errorf("model %s not found", modelID)
+	}
+
+	encrypted, exists := model.RecommendationCache[txID]
+	if !exists {
+		return nil, fmt.Errorf("no cached recommendation for transaction %s", txID)
+	}
+
+	if len(l.RecommendationKey) == 0 {
+		return nil, errors.New("no recommendation key set")
+	}
+	decrypted, err := (&Encryption{}).DecryptData(encrypted, l.RecommendationKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt recommendation: %v", err)
+	}
+
+	if l.DecryptionLogs == nil {
+		l.DecryptionLogs = make(map[string]DecryptionLog)
+	}
+	// Only metadata is logged; the plaintext never leaves this call.
+	l.RecordDecryption(txID, fmt.Sprintf("model=%s bytes=%d", modelID, len(decrypted)))
+
+	return decrypted, nil
+}
+
+// EvictCachedRecommendations removes cached recommendations older than maxAge and returns the number evicted.
+func (l *AiMLMLedger) EvictCachedRecommendations(maxAge time.Duration, now time.Time) int {
+	l.Lock()
+	defer l.Unlock()
+
+	evicted := 0
+	for key, record := range l.CacheRecords {
+		if key != recommendationCacheKey(record.ModelID, record.DataID) || now.Sub(record.CreatedAt) <= maxAge {
+			continue
+		}
+		model, exists := l.Models[record.ModelID]
+		if !exists || model.RecommendationCache == nil {
+			continue
+		}
+		if _, cached := model.RecommendationCache[record.DataID]; !cached {
+			continue
+		}
+		delete(model.RecommendationCache, record.DataID)
+		delete(l.CacheRecords, key)
+		evicted++
+	}
+
+	if evicted > 0 {
+		log.Printf("[INFO] Evicted %d stale cached recommendations", evicted)
+	}
+	return evicted
+}
@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func newRecommendationTestLedger(t *testing.T) *AiMLMLedger {
+	t.Helper()
+	l := &AiMLMLedger{Models: map[string]Model{"model-1": {}}}
+	if err := l.SetRecommendationKey(bytes.Repeat([]byte{7}, 32)); err != nil {
+		t.Fatalf("SetRecommendationKey: %v", err)
+	}
+	return l
+}
+
+func TestCachedRecommendationRoundTrip(t *testing.T) {
+	l := newRecommendationTestLedger(t)
+	rec := []byte(`{"action":"approve","score":0.93}`)
+
+	if err := l.CacheRecommendationEncrypted("model-1", "tx-1", rec); err != nil {
+		t.Fatalf("CacheRecommendationEncrypted: %v", err)
+	}
+	if stored := l.Models["model-1"].RecommendationCache["tx-1"]; bytes.Contains(stored, rec) {
+		t.Fatal("recommendation was cached in plaintext")
+	}
+	if _, logged := l.EncryptionLogs["tx-1"]; !logged {
+		t.Fatal("expected an encryption log entry")
+	}
+
+	got, err := l.GetCachedRecommendation("model-1", "tx-1")
+	if err != nil {
+		t.Fatalf("GetCachedRecommendation: %v", err)
+	}
+	if !bytes.Equal(got, rec) {
+		t.Fatalf("GetCachedRecommendation = %q, want %q", got, rec)
+	}
+	if entry, logged := l.DecryptionLogs["tx-1"]; !logged || bytes.Contains([]byte(entry.DecryptedData), rec) {
+		t.Fatalf("decryption log = %+v, want metadata only", entry)
+	}
+}
+
+func TestEvictCachedRecommendationsRemovesOldEntry(t *testing.T) {
+	l := newRecommendationTestLedger(t)
+	for _, txID := range []string{"tx-old", "tx-new"} {
+		if err := l.CacheRecommendationEncrypted("model-1", txID, []byte("rec-"+txID)); err != nil {
+			t.Fatalf("CacheRecommendationEncrypted(%s): %v", txID, err)
+		}
+	}
+
+	now := time.Now()
+	old := l.CacheRecords[recommendationCacheKey("model-1", "tx-old")]
+	old.CreatedAt = now.Add(-2 * time.Hour)
+	l.CacheRecords[recommendationCacheKey("model-1", "tx-old")] = old
+
+	if evicted := l.EvictCachedRecommendations(time.Hour, now); evicted != 1 {
+		t.Fatalf("EvictCachedRecommendations = %d, want 1", evicted)
+	}
+	if _, err := l.GetCachedRecommendation("model-1", "tx-old"); err == nil {
+		t.Fatal("expected the old recommendation to be evicted")
+	}
+	if _, err := l.GetCachedRecommendation("model-1", "tx-new"); err != nil {
+		t.Fatalf("recent recommendation was evicted: %v", err)
+	}
+}
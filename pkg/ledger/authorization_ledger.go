@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -312,25 +314,74 @@ func (l *AuthorizationLedger) AddPublicKey(signerID string, publicKeyPEM string)
 	l.Lock()
 	defer l.Unlock()
 
-	publicKey, err := parsePublicKeyPEM(publicKeyPEM)
-	if err != nil {
+	if _, err := parsePublicKeyPEM(publicKeyPEM); err != nil {
 		return fmt.Errorf("failed to parse public key: %v", err)
 	}
 
-	l.PublicKeys[signerID] = publicKey
+	if l.PublicKeys == nil {
+		l.PublicKeys = make(map[string]PublicKeyRecord)
+	}
+	l.PublicKeys[signerID] = PublicKeyRecord{
+		KeyID:     signerID,
+		OwnerID:   signerID,
+		Key:       publicKeyPEM,
+		CreatedAt: time.Now(),
+	}
 	return nil
 }
 
 // GetPublicKey retrieves the public key associated with a given signerID.
+// Revoked and expired keys are refused so they cannot verify signatures.
 func (l *AuthorizationLedger) GetPublicKey(signerID string) (*ecdsa.PublicKey, error) {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
+	l.Lock()
+	defer l.Unlock()
 
-	publicKey, exists := l.PublicKeys[signerID]
+	record, exists := l.PublicKeys[signerID]
 	if !exists {
 		return nil, fmt.Errorf("public key not found for signerID: %s", signerID)
 	}
-	return publicKey, nil
+	if !IsKeyUsable(&record, time.Now()) {
+		return nil, fmt.Errorf("public key for signerID %s is revoked or expired", signerID)
+	}
+	return parsePublicKeyPEM(record.Key)
+}
+
+// RevokeKey marks a public key as revoked so no verification path will accept
+// it, and records the revocation as an authorization event.
+func (l *AuthorizationLedger) RevokeKey(keyID, reason string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	record, exists := l.PublicKeys[keyID]
+	if !exists {
+		return fmt.Errorf("public key not found for keyID: %s", keyID)
+	}
+	if record.Revoked {
+		return fmt.Errorf("public key %s is already revoked", keyID)
+	}
+
+	record.Revoked = true
+	record.RevokedAt = time.Now()
+	l.PublicKeys[keyID] = record
+
+	l.AuthorizationEvents = append(l.AuthorizationEvents, AuthorizationEvent{
+		EventID:   generateUniqueID(),
+		Action:    "KeyRevoked",
+		UserID:    record.OwnerID,
+		Timestamp: record.RevokedAt,
+		Details:   fmt.Sprintf("public key %s revoked: %s", keyID, reason),
+	})
+	log.Printf("[WARNING] Public key %s revoked: %s", keyID, reason)
+	return nil
+}
+
+// IsKeyUsable reports whether a public key may be used at now. Revoked keys
+// and keys past a non-zero ExpiresAt are not usable.
+func IsKeyUsable(rec *PublicKeyRecord, now time.Time) bool {
+	if rec == nil || rec.Revoked {
+		return false
+	}
+	return rec.ExpiresAt.IsZero() || now.Before(rec.ExpiresAt)
 }
 
 // parsePublicKeyPEM decodes and parses an ECDSA public key from a PEM-encoded string.
@@ -760,3 +811,240 @@ func (l *AuthorizationLedger) FetchSignerStatus(signerID string) (SignerPriority
 	status, exists := l.SignerPriorities[signerID]
 	return status, exists
 }
+
+// HasPermission reports whether a role grants permission, either directly or
+// through its chain of parent roles in roleStore. The role checked is rm, or
+// roleStore[roleID] when rm is nil. A parent chain containing a cycle or a
+// missing role grants nothing beyond the roles visited before it.
+func HasPermission(rm *RoleManager, roleStore map[string]*RoleManager, roleID, permission string) bool {
+	if rm == nil {
+		rm = roleStore[roleID]
+	}
+
+	visited := make(map[string]bool)
+	for role := rm; role != nil; role = roleStore[role.ParentRoleID] {
+		if visited[role.RoleID] {
+			log.Printf("[WARNING] Role hierarchy cycle detected at role %s", role.RoleID)
+			return false
+		}
+		visited[role.RoleID] = true
+
+		for _, p := range role.Permissions {
+			if p == permission {
+				return true
+			}
+		}
+		if role.ParentRoleID == "" {
+			break
+		}
+	}
+	return false
+}
+
+// RegisterRole adds or replaces a role. Roles whose parent chain would loop
+// back to themselves are rejected.
+func (l *AuthorizationLedger) RegisterRole(role *RoleManager) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if role == nil || role.RoleID == "" {
+		return errors.New("role must have an ID")
+	}
+	for parentID, hops := role.ParentRoleID, 0; parentID != ""; hops++ {
+		if parentID == role.RoleID || hops > len(l.Roles) {
+			return fmt.Errorf("role %s would create a cycle in the role hierarchy", role.RoleID)
+		}
+		parent, exists := l.Roles[parentID]
+		if !exists {
+			break
+		}
+		parentID = parent.ParentRoleID
+	}
+
+	if l.Roles == nil {
+		l.Roles = make(map[string]*RoleManager)
+	}
+	if role.CreatedAt.IsZero() {
+		role.CreatedAt = time.Now()
+	}
+	role.UpdatedAt = time.Now()
+	l.Roles[role.RoleID] = role
+	log.Printf("Role %s registered with parent %q.", role.RoleID, role.ParentRoleID)
+	return nil
+}
+
+// AssignUser adds userID to a role's AssignedUsers and logs the assignment.
+func (l *AuthorizationLedger) AssignUser(roleID, userID string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	role, exists := l.Roles[roleID]
+	if !exists {
+		return fmt.Errorf("role %s not found", roleID)
+	}
+	for _, assigned := range role.AssignedUsers {
+		if assigned == userID {
+			return fmt.Errorf("user %s is already assigned to role %s", userID, roleID)
+		}
+	}
+
+	role.AssignedUsers = append(role.AssignedUsers, userID)
+	role.UpdatedAt = time.Now()
+	l.logRoleAssignment(userID, roleID, "assigned")
+	return nil
+}
+
+// RemoveUser removes userID from a role's AssignedUsers and logs the removal.
+func (l *AuthorizationLedger) RemoveUser(roleID, userID string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	role, exists := l.Roles[roleID]
+	if !exists {
+		return fmt.Errorf("role %s not found", roleID)
+	}
+	for i, assigned := range role.AssignedUsers {
+		if assigned == userID {
+			role.AssignedUsers = append(role.AssignedUsers[:i], role.AssignedUsers[i+1:]...)
+			role.UpdatedAt = time.Now()
+			l.logRoleAssignment(userID, roleID, "removed")
+			return nil
+		}
+	}
+	return fmt.Errorf("user %s is not assigned to role %s", userID, roleID)
+}
+
+// logRoleAssignment appends a RoleAssignmentLog entry. The caller must hold the lock.
+func (l *AuthorizationLedger) logRoleAssignment(userID, roleID, action string) {
+	l.RoleAssignmentLogs = append(l.RoleAssignmentLogs, RoleAssignmentLog{
+		UserID:      userID,
+		RoleID:      roleID,
+		Action:      action,
+		PerformedBy: "AuthorizationLedger",
+		Timestamp:   time.Now(),
+		LogDetails:  fmt.Sprintf("user %s %s role %s", userID, action, roleID),
+	})
+	log.Printf("User %s %s role %s.", userID, action, roleID)
+}
+
+// Authorize evaluates a request for action on resource against the access
+// policy in am. A rule matches when its resource and action match and all of
+// its Conditions hold for ctx. Deny rules override allow rules; a request no
+// rule matches is denied. The ID of the deciding rule is returned for auditing.
+//
+// A condition key is either an attribute name, compared for equality, or an
+// attribute name followed by one of ==, !=, > or <, e.g. "amount >".
+func Authorize(am *AccessManager, resource, action string, ctx map[string]interface{}) (allowed bool, ruleID string) {
+	if am == nil || !am.IsActive {
+		return false, ""
+	}
+
+	for _, rule := range am.AccessRules {
+		if rule.Resource != resource || rule.Action != action || !conditionsHold(rule.Conditions, ctx) {
+			continue
+		}
+		if !rule.IsAllowed {
+			log.Printf("[INFO] Access to %s:%s denied by rule %s", resource, action, rule.RuleID)
+			return false, rule.RuleID
+		}
+		if ruleID == "" {
+			ruleID = rule.RuleID
+		}
+	}
+	return ruleID != "", ruleID
+}
+
+// conditionsHold reports whether every access rule condition holds for ctx.
+func conditionsHold(conditions map[string]interface{}, ctx map[string]interface{}) bool {
+	for key, expected := range conditions {
+		attr, op := key, "=="
+		if fields := strings.Fields(key); len(fields) == 2 {
+			attr, op = fields[0], fields[1]
+		}
+		actual, exists := ctx[attr]
+		if !exists || !compareCondition(actual, op, expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareCondition applies op to actual and expected. Numbers are compared by
+// value regardless of type; strings and times support ordering too. Any other
+// types only support == and !=.
+func compareCondition(actual interface{}, op string, expected interface{}) bool {
+	var cmp int
+	a, aNum := conditionNumber(actual)
+	e, eNum := conditionNumber(expected)
+	switch {
+	case aNum && eNum:
+		cmp = compareOrdered(a, e)
+	case isString(actual) && isString(expected):
+		cmp = strings.Compare(actual.(string), expected.(string))
+	case isTime(actual) && isTime(expected):
+		cmp = actual.(time.Time).Compare(expected.(time.Time))
+	default:
+		switch op {
+		case "==":
+			return reflect.DeepEqual(actual, expected)
+		case "!=":
+			return !reflect.DeepEqual(actual, expected)
+		}
+		return false
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	}
+	return false
+}
+
+// conditionNumber converts numeric condition values to float64.
+func conditionNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func compareOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isString(v interface{}) bool {
+	_, ok := v.(string)
+	return ok
+}
+
+func isTime(v interface{}) bool {
+	_, ok := v.(time.Time)
+	return ok
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"sort"
 	"time"
 )
 
@@ -352,6 +353,94 @@ func (l *BlockchainConsensusCoinLedger) GetBlockCount() int {
 	return len(l.Blocks)
 }
 
+// FinalizedHeight returns the number of blocks that have been finalized.
+// Blocks below this height can no longer be replaced by a fork.
+func (l *BlockchainConsensusCoinLedger) FinalizedHeight() int {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.FinalizedBlocks)
+}
+
+// ReconcileChain swaps the stored chain for the given one, keeping the prefix
+// both chains share. Forks that diverge below the finalized height are
+// refused. The replaced blocks are moved to RejectedBlocks and returned so
+// their transactions can be re-queued. FinalizedBlocks is left to the
+// finality path.
+func (l *BlockchainConsensusCoinLedger) ReconcileChain(chain []Block) ([]Block, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	shared := 0
+	for shared < len(l.Blocks) && shared < len(chain) && l.Blocks[shared].Hash == chain[shared].Hash {
+		shared++
+	}
+	if shared < len(l.FinalizedBlocks) {
+		return nil, fmt.Errorf("fork diverges at height %d, below finalized height %d", shared, len(l.FinalizedBlocks))
+	}
+
+	discarded := append([]Block(nil), l.Blocks[shared:]...)
+	l.RejectedBlocks = append(l.RejectedBlocks, discarded...)
+	for _, block := range discarded {
+		delete(l.ValidationCache, validationCacheKey(block.BlockID, block.Hash))
+	}
+	l.Blocks = append([]Block(nil), chain...)
+
+	l.BlockchainConsensusCoinState.BlockHeight = len(chain)
+	l.BlockIndex = len(chain) - 1
+	if len(chain) > 0 {
+		l.BlockchainConsensusCoinState.LastBlockHash = chain[len(chain)-1].Hash
+	}
+
+	log.Printf("[INFO] Chain replaced: %d blocks kept, %d discarded, new height %d", shared, len(discarded), len(chain))
+	return discarded, nil
+}
+
+// validationCacheKey keys a cached validation result by block ID and hash, so
+// a different block reusing an ID is never served another block's result.
+func validationCacheKey(blockID, hash string) string {
+	return blockID + ":" + hash
+}
+
+// CacheValidationResult stores the outcome of validating a block so it need not be re-validated.
+func (l *BlockchainConsensusCoinLedger) CacheValidationResult(blockID, hash string, valid bool, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.ValidationCache == nil {
+		l.ValidationCache = make(map[string]BlockValidationResult)
+	}
+	l.ValidationCache[validationCacheKey(blockID, hash)] = BlockValidationResult{
+		BlockID:   blockID,
+		Hash:      hash,
+		IsValid:   valid,
+		Timestamp: now,
+	}
+}
+
+// GetCachedValidation returns the cached validation result for a block, if any.
+func (l *BlockchainConsensusCoinLedger) GetCachedValidation(blockID, hash string) (*BlockValidationResult, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	result, exists := l.ValidationCache[validationCacheKey(blockID, hash)]
+	if !exists {
+		return nil, false
+	}
+	return &result, true
+}
+
+// InvalidateValidation drops every cached validation result for a block ID, forcing it to be re-checked.
+func (l *BlockchainConsensusCoinLedger) InvalidateValidation(blockID string) {
+	l.Lock()
+	defer l.Unlock()
+
+	for key, result := range l.ValidationCache {
+		if result.BlockID == blockID {
+			delete(l.ValidationCache, key)
+		}
+	}
+}
+
 // GetSubBlockByID retrieves a sub-block by its ID from the ledger.
 func (l *BlockchainConsensusCoinLedger) GetSubBlockByID(subBlockID string) (SubBlock, error) {
 	l.Lock()
@@ -2052,6 +2141,22 @@ func (l *BlockchainConsensusCoinLedger) UpdateMinerReward(minerID string, reward
 	return nil
 }
 
+// RecordBlockReward records the PoW reward issued at a block height.
+func (l *BlockchainConsensusCoinLedger) RecordBlockReward(blockHeight int, reward float64) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if blockHeight < 0 {
+		return fmt.Errorf("block height cannot be negative")
+	}
+	if l.ConsensusState.BlockRewards == nil {
+		l.ConsensusState.BlockRewards = make(map[int]float64)
+	}
+	l.ConsensusState.BlockRewards[blockHeight] = reward
+	fmt.Printf("Recorded PoW reward of %.2f for block %d\n", reward, blockHeight)
+	return nil
+}
+
 // GetFinalizedSubBlocks returns all finalized sub-blocks in the ledger.
 func (l *BlockchainConsensusCoinLedger) GetFinalizedSubBlocks() []SubBlock {
 	l.Lock()
@@ -2266,18 +2371,80 @@ func (l *BlockchainConsensusCoinLedger) GetRewardDistributionMode() (RewardDistr
 }
 
 func (l *BlockchainConsensusCoinLedger) LogConsensusParticipation(validatorID, status string) error {
+	return l.RecordParticipation(validatorID, status, time.Now())
+}
+
+// participatingStatus is the audit status recorded when a validator took part in a round.
+const participatingStatus = "Active"
+
+// RecordParticipation logs a validator's participation status for a consensus round.
+func (l *BlockchainConsensusCoinLedger) RecordParticipation(validatorID, status string, now time.Time) error {
 	l.Lock()
 	defer l.Unlock()
+
+	if validatorID == "" {
+		return fmt.Errorf("validator ID cannot be empty")
+	}
+
 	auditLog := ConsensusAuditLog{
-		AuditID:             fmt.Sprintf("audit-%d", time.Now().UnixNano()),
+		AuditID:             fmt.Sprintf("audit-%d", now.UnixNano()),
 		ValidatorID:         validatorID,
-		Timestamp:           time.Now(),
+		Timestamp:           now,
 		ParticipationStatus: status,
 	}
 	l.ConsensusAuditLogs = append(l.ConsensusAuditLogs, auditLog)
 	return nil
 }
 
+// ParticipationRate returns the fraction of the validator's rounds within the
+// window in which it participated. A validator with no rounds in the window has
+// a rate of 0.
+func (l *BlockchainConsensusCoinLedger) ParticipationRate(validatorID string, window time.Duration, now time.Time) float64 {
+	l.Lock()
+	defer l.Unlock()
+
+	rounds, participated := l.participationCounts(window, now)
+	if rounds[validatorID] == 0 {
+		return 0
+	}
+	return float64(participated[validatorID]) / float64(rounds[validatorID])
+}
+
+// UnderperformingValidators returns, in sorted order, the validators with rounds
+// in the window whose participation rate is below minRate.
+func (l *BlockchainConsensusCoinLedger) UnderperformingValidators(minRate float64, window time.Duration, now time.Time) []string {
+	l.Lock()
+	defer l.Unlock()
+
+	rounds, participated := l.participationCounts(window, now)
+	var underperforming []string
+	for validatorID, total := range rounds {
+		if float64(participated[validatorID])/float64(total) < minRate {
+			underperforming = append(underperforming, validatorID)
+		}
+	}
+	sort.Strings(underperforming)
+	return underperforming
+}
+
+// participationCounts tallies each validator's rounds and participations logged
+// in (now-window, now]. The caller must hold the lock.
+func (l *BlockchainConsensusCoinLedger) participationCounts(window time.Duration, now time.Time) (rounds, participated map[string]int) {
+	rounds = make(map[string]int)
+	participated = make(map[string]int)
+	cutoff := now.Add(-window)
+	for _, auditLog := range l.ConsensusAuditLogs {
+		if !auditLog.Timestamp.After(cutoff) || auditLog.Timestamp.After(now) {
+			continue
+		}
+		rounds[auditLog.ValidatorID]++
+		if auditLog.ParticipationStatus == participatingStatus {
+			participated[auditLog.ValidatorID]++
+		}
+	}
+	return rounds, participated
+}
+
 func (l *BlockchainConsensusCoinLedger) SetValidatorSelectionMode(mode ValidatorSelectionMode) error {
 	l.Lock()
 	defer l.Unlock()
@@ -2523,6 +2690,33 @@ func (l *BlockchainConsensusCoinLedger) GetPunishmentHistory(validatorID string)
 	return history, nil
 }
 
+// RecordPunishmentRecord appends a punishment record to a validator's punishment history.
+func (l *BlockchainConsensusCoinLedger) RecordPunishmentRecord(record PunishmentRecord) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if record.ValidatorID == "" {
+		return errors.New("validator ID cannot be empty")
+	}
+	if l.ValidatorPunishments == nil {
+		l.ValidatorPunishments = make(map[string][]PunishmentRecord)
+	}
+	l.ValidatorPunishments[record.ValidatorID] = append(l.ValidatorPunishments[record.ValidatorID], record)
+	return nil
+}
+
+// GetValidatorStake returns the stake currently held by a validator.
+func (l *BlockchainConsensusCoinLedger) GetValidatorStake(validatorID string) (float64, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	stake, exists := l.ValidatorStakes[validatorID]
+	if !exists {
+		return 0, fmt.Errorf("no stake found for validator %s", validatorID)
+	}
+	return stake, nil
+}
+
 func (l *BlockchainConsensusCoinLedger) ResetPunishmentCount(validatorID string) error {
 	l.Lock()
 	defer l.Unlock()
@@ -2782,3 +2976,126 @@ func (l *BlockchainConsensusCoinLedger) RecordCacheInvalidation(recordID string)
 	}
 	return errors.New("transaction record not found in cache")
 }
+
+// merkleRootOfHashes folds a list of hex hashes into a single Merkle root,
+// duplicating the last node on levels with an odd count.
+func merkleRootOfHashes(hashes []string) string {
+	if len(hashes) == 0 {
+		return ""
+	}
+
+	level := append([]string(nil), hashes...)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([]string, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			sum := sha256.Sum256([]byte(level[i] + level[i+1]))
+			next = append(next, hex.EncodeToString(sum[:]))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// prunedValidationHash extends a previous validation hash with the hashes of
+// newly pruned blocks. The previous hash is the first leaf so that repeated
+// prunes still commit to every block pruned before them.
+func prunedValidationHash(previous string, prunedHashes []string) string {
+	leaves := make([]string, 0, len(prunedHashes)+1)
+	if previous != "" {
+		leaves = append(leaves, previous)
+	}
+	leaves = append(leaves, prunedHashes...)
+	return merkleRootOfHashes(leaves)
+}
+
+// Prune drops all but the keepRecent most recent retained blocks. The hashes of
+// the dropped blocks are folded into ValidationHash so their integrity can still
+// be proven against a full chain.
+func (pb *PrunedBlockchain) Prune(keepRecent int) error {
+	if keepRecent < 1 {
+		return fmt.Errorf("keepRecent must be at least 1")
+	}
+
+	blocks := make([]*Block, 0, len(pb.RetainedBlocks))
+	for _, block := range pb.RetainedBlocks {
+		blocks = append(blocks, block)
+	}
+	if len(blocks) <= keepRecent {
+		return nil
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Index < blocks[j].Index })
+
+	cut := len(blocks) - keepRecent
+	if blocks[0].Index != pb.PrunedBlockHeight {
+		return fmt.Errorf("retained blocks start at index %d, expected %d", blocks[0].Index, pb.PrunedBlockHeight)
+	}
+	prunedHashes := make([]string, 0, cut)
+	for i, block := range blocks[:cut] {
+		if block.Index != pb.PrunedBlockHeight+i {
+			return fmt.Errorf("retained blocks are not contiguous at index %d", block.Index)
+		}
+		prunedHashes = append(prunedHashes, block.Hash)
+	}
+
+	for _, hash := range prunedHashes {
+		delete(pb.RetainedBlocks, hash)
+	}
+	pb.ValidationHash = prunedValidationHash(pb.ValidationHash, prunedHashes)
+	pb.PrunedBlockHeight = blocks[cut].Index
+	pb.PruneBoundaries = append(pb.PruneBoundaries, pb.PrunedBlockHeight)
+	pb.SnapshotTimestamp = time.Now()
+
+	latest := blocks[len(blocks)-1]
+	pb.LatestBlockHash = latest.Hash
+	pb.BlockHeight = latest.Index + 1
+
+	log.Printf("[INFO] Pruned %d blocks below height %d, %d retained", cut, pb.PrunedBlockHeight, keepRecent)
+	return nil
+}
+
+// VerifyAgainstFull checks the pruned state against a full chain: the
+// validation hash must match the hashes of the full chain's pruned range, and
+// every retained block must appear in the full chain at the same index.
+func (pb *PrunedBlockchain) VerifyAgainstFull(full *Blockchain) bool {
+	if full == nil || pb.BlockHeight > len(full.Chain) {
+		return false
+	}
+	for i := 0; i < pb.BlockHeight; i++ {
+		if full.Chain[i].Index != i {
+			return false
+		}
+	}
+
+	validationHash, start := "", 0
+	for _, boundary := range pb.PruneBoundaries {
+		if boundary < start || boundary > pb.BlockHeight {
+			return false
+		}
+		prunedHashes := make([]string, 0, boundary-start)
+		for i := start; i < boundary; i++ {
+			prunedHashes = append(prunedHashes, full.Chain[i].Hash)
+		}
+		validationHash = prunedValidationHash(validationHash, prunedHashes)
+		start = boundary
+	}
+	if start != pb.PrunedBlockHeight || validationHash != pb.ValidationHash {
+		return false
+	}
+
+	if len(pb.RetainedBlocks) != pb.BlockHeight-pb.PrunedBlockHeight {
+		return false
+	}
+	for hash, block := range pb.RetainedBlocks {
+		if block == nil || block.Index < pb.PrunedBlockHeight || block.Index >= pb.BlockHeight {
+			return false
+		}
+		if block.Hash != hash || full.Chain[block.Index].Hash != hash {
+			return false
+		}
+	}
+
+	return pb.BlockHeight == 0 || full.Chain[pb.BlockHeight-1].Hash == pb.LatestBlockHash
+}
@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+)
+
+func recordRounds(t *testing.T, l *BlockchainConsensusCoinLedger, validatorID string, statuses []string, start time.Time) {
+	t.Helper()
+	for i, status := range statuses {
+		if err := l.RecordParticipation(validatorID, status, start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("RecordParticipation(%s): %v", validatorID, err)
+		}
+	}
+}
+
+func TestParticipationRateWithinWindow(t *testing.T) {
+	l := &BlockchainConsensusCoinLedger{}
+	now := time.Now()
+
+	// Rounds outside the window must not count.
+	recordRounds(t, l, "validator-1", []string{"Missed", "Missed"}, now.Add(-3*time.Hour))
+	recordRounds(t, l, "validator-1", []string{"Active", "Active", "Missed", "Active"}, now.Add(-10*time.Minute))
+
+	if rate := l.ParticipationRate("validator-1", time.Hour, now); rate != 0.75 {
+		t.Fatalf("ParticipationRate = %v, want 0.75", rate)
+	}
+	if rate := l.ParticipationRate("validator-unknown", time.Hour, now); rate != 0 {
+		t.Fatalf("ParticipationRate for unknown validator = %v, want 0", rate)
+	}
+}
+
+func TestUnderperformingValidators(t *testing.T) {
+	l := &BlockchainConsensusCoinLedger{}
+	now := time.Now()
+	start := now.Add(-10 * time.Minute)
+
+	recordRounds(t, l, "validator-1", []string{"Active", "Active", "Active", "Active"}, start)
+	recordRounds(t, l, "validator-2", []string{"Active", "Missed", "Missed", "Missed"}, start)
+	recordRounds(t, l, "validator-3", []string{"Missed", "Active", "Active", "Missed"}, start)
+
+	got := l.UnderperformingValidators(0.6, time.Hour, now)
+	if len(got) != 2 || got[0] != "validator-2" || got[1] != "validator-3" {
+		t.Fatalf("UnderperformingValidators = %v, want [validator-2 validator-3]", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -73,6 +74,79 @@ func (l *ComplianceLedger) RecordMigrationCompliance(policyID string, compliance
     return nil
 }
 
+// migrationComplianceRules maps data-handling and retention rule names to checks over migration details.
+var migrationComplianceRules = map[string]func(details MigrationDetails) bool{
+    "encryption-in-transit": func(details MigrationDetails) bool { return details.EncryptedInTransit },
+    "no-plaintext-pii":      func(details MigrationDetails) bool { return !details.PlaintextPII },
+    "retain-history":        func(details MigrationDetails) bool { return !details.PurgesHistory },
+    "audit-trail":           func(details MigrationDetails) bool { return details.AuditTrail },
+}
+
+// ValidateMigration checks a migration against data-handling and retention rules and records
+// the outcome. Non-compliant migrations are blocked and an error listing the violated rules is returned.
+func (l *ComplianceLedger) ValidateMigration(migrationID string, details MigrationDetails, rules []string) (*MigrationCompliance, error) {
+    if migrationID == "" {
+        return nil, fmt.Errorf("migration ID cannot be empty")
+    }
+    if len(rules) == 0 {
+        return nil, fmt.Errorf("at least one compliance rule is required")
+    }
+
+    var violations []string
+    for _, rule := range rules {
+        check, known := migrationComplianceRules[rule]
+        if !known {
+            return nil, fmt.Errorf("unknown migration compliance rule %s", rule)
+        }
+        if !check(details) {
+            violations = append(violations, rule)
+        }
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    compliance := MigrationCompliance{
+        MigrationID:      migrationID,
+        Details:          details.Description,
+        ComplianceStatus: "Compliant",
+        RecordedAt:       time.Now(),
+    }
+
+    if len(violations) > 0 {
+        reason := strings.Join(violations, ", ")
+        compliance.ComplianceStatus = "NonCompliant"
+        compliance.Details = fmt.Sprintf("%s (violated rules: %s)", details.Description, reason)
+        l.MigrationCompliances = append(l.MigrationCompliances, compliance)
+
+        if l.BlockedMigrations == nil {
+            l.BlockedMigrations = make(map[string]string)
+        }
+        l.BlockedMigrations[migrationID] = reason
+
+        log.Printf("Migration %s blocked: violated rules %s", migrationID, reason)
+        return &compliance, fmt.Errorf("migration %s is not compliant: violated rules %s", migrationID, reason)
+    }
+
+    delete(l.BlockedMigrations, migrationID)
+    l.MigrationCompliances = append(l.MigrationCompliances, compliance)
+
+    log.Printf("Migration %s validated as compliant", migrationID)
+    return &compliance, nil
+}
+
+// CheckMigrationAllowed returns an error naming the violated rules when ValidateMigration
+// has blocked the migration. Migration executors must call it before running a migration.
+func (l *ComplianceLedger) CheckMigrationAllowed(migrationID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if reason, blocked := l.BlockedMigrations[migrationID]; blocked {
+        return fmt.Errorf("migration %s is blocked: violated rules %s", migrationID, reason)
+    }
+    return nil
+}
+
 
 // RecordAuditEntry logs an audit entry for a specific transaction or action.
 func (l *ComplianceLedger) RecordAuditEntry(txID string, action string, details string) (string, error) {
@@ -1032,13 +1106,26 @@ func (l *ComplianceLedger) SendWarning(entityID, notice string) error {
 
 
 func (l *ComplianceLedger) RecordSuspiciousTransaction(transactionID, details string) error {
-    transaction := TransactionRecord{
+    return l.StoreSuspiciousTransaction(SuspiciousTransaction{
         TransactionID: transactionID,
-        Details:       details,
+        DetectedBy:    "System",
+        Reason:        details,
         Timestamp:     time.Now(),
+    })
+}
+
+// StoreSuspiciousTransaction stores a flagged transaction in the suspicious transaction log.
+func (l *ComplianceLedger) StoreSuspiciousTransaction(record SuspiciousTransaction) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if record.TransactionID == "" {
+        return errors.New("suspicious transaction must have an ID")
     }
-    // Store suspicious transaction in transaction history log
-    l.SuspiciousTransactions[transactionID] = transaction
+    if l.SuspiciousTransactions == nil {
+        l.SuspiciousTransactions = make(map[string]SuspiciousTransaction)
+    }
+    l.SuspiciousTransactions[record.TransactionID] = record
     return nil
 }
 
@@ -1395,3 +1482,263 @@ func (l *ComplianceLedger) StoreValidatedAuditEntry(entryID string, entry *Audit
 	l.AuditEntries[entryID] = entry
 	return nil
 }
+
+// defaultKYCValidity is how long a KYC verification remains valid when the
+// manager has no Validity configured.
+const defaultKYCValidity = 365 * 24 * time.Hour
+
+// SubmitKYC hashes and encrypts the user's KYC data and stores it as a
+// pending record. A user with a current verification cannot resubmit until
+// it has expired.
+func (km *KYCManager) SubmitKYC(userID string, data []byte) error {
+	km.mutex.Lock()
+	defer km.mutex.Unlock()
+
+	if len(data) == 0 {
+		return errors.New("KYC data cannot be empty")
+	}
+	if km.Records == nil {
+		km.Records = make(map[string]KYCRecord)
+	}
+	if record, exists := km.Records[userID]; exists && record.Status.IsVerified && time.Now().Before(record.ExpiresAt) {
+		return fmt.Errorf("KYC for user %s is already verified until %s", userID, record.ExpiresAt.Format(time.RFC3339))
+	}
+
+	encryption := &Encryption{}
+	encryptedKYC, err := encryption.EncryptData("AES", data, EncryptionKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt KYC data: %v", err)
+	}
+
+	km.Records[userID] = KYCRecord{
+		UserID:       userID,
+		Status:       KYCStatus{Reason: "pending verification"},
+		DataHash:     generateHash(string(data)),
+		EncryptedKYC: encryptedKYC,
+	}
+
+	log.Printf("[INFO] KYC data submitted for user %s", userID)
+	return nil
+}
+
+// VerifyKYC marks the user's pending KYC record as verified by the given
+// officer, starts its validity period and records it in the compliance ledger.
+func (km *KYCManager) VerifyKYC(userID, officer string) error {
+	km.mutex.Lock()
+	defer km.mutex.Unlock()
+
+	if officer == "" {
+		return errors.New("verifying officer must be specified")
+	}
+	record, exists := km.Records[userID]
+	if !exists {
+		return fmt.Errorf("no KYC data found for user %s", userID)
+	}
+	if record.Status.IsVerified && time.Now().Before(record.ExpiresAt) {
+		return fmt.Errorf("KYC for user %s is already verified", userID)
+	}
+	if len(record.EncryptedKYC) == 0 {
+		return fmt.Errorf("KYC for user %s must be resubmitted before verification", userID)
+	}
+
+	now := time.Now()
+	record.Status = KYCStatus{
+		IsVerified: true,
+		Reason:     "verified",
+		VerifiedAt: now,
+		VerifiedBy: officer,
+	}
+	record.VerifiedAt = now
+	record.ExpiresAt = now.Add(km.validity())
+	km.Records[userID] = record
+
+	if km.LedgerInstance != nil {
+		km.LedgerInstance.ComplianceLedger.RecordKYCVerification(record)
+	}
+
+	log.Printf("[SUCCESS] KYC for user %s verified by %s, valid until %s", userID, officer, record.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+// IsVerified reports whether the user holds a KYC verification that has not
+// yet expired.
+func (km *KYCManager) IsVerified(userID string) bool {
+	km.mutex.Lock()
+	defer km.mutex.Unlock()
+
+	record, exists := km.Records[userID]
+	return exists && record.Status.IsVerified && time.Now().Before(record.ExpiresAt)
+}
+
+// ExpireKYC revokes every verification whose validity period has ended as of
+// now and clears the stored data so the user must resubmit. It returns the
+// IDs of the affected users.
+func (km *KYCManager) ExpireKYC(now time.Time) []string {
+	km.mutex.Lock()
+	defer km.mutex.Unlock()
+
+	var expired []string
+	for userID, record := range km.Records {
+		if !record.Status.IsVerified || now.Before(record.ExpiresAt) {
+			continue
+		}
+		record.Status.IsVerified = false
+		record.Status.Reason = "expired"
+		record.EncryptedKYC = nil
+		km.Records[userID] = record
+		expired = append(expired, userID)
+		log.Printf("[WARNING] KYC for user %s expired at %s and must be refreshed", userID, record.ExpiresAt.Format(time.RFC3339))
+	}
+	return expired
+}
+
+// validity returns the configured verification period or the default.
+func (km *KYCManager) validity() time.Duration {
+	if km.Validity > 0 {
+		return km.Validity
+	}
+	return defaultKYCValidity
+}
+
+// RecordKYCVerification appends a verified KYC record to the compliance ledger.
+func (l *ComplianceLedger) RecordKYCVerification(record KYCRecord) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.KYCRecords = append(l.KYCRecords, record)
+}
+
+const (
+	defaultStructuringWindow = time.Hour // Window for counting near-threshold transfers
+	defaultStructuringCount  = 3         // Near-threshold transfers in the window that indicate structuring
+	structuringBand          = 0.9       // Fraction of the threshold above which a transfer counts as near-threshold
+)
+
+// ScreenTransaction evaluates tx against the AML rules. A transaction is
+// flagged when it involves a blocked wallet, exceeds the suspicious activity
+// threshold, or completes a structuring pattern of repeated near-threshold
+// transfers from the same sender within the structuring window. Flagged
+// transactions are reported and recorded in the compliance ledger; with
+// AutoBlock set the sender is also blocked.
+func (aml *AMLSystem) ScreenTransaction(tx Transaction) (flagged bool, reason string) {
+	aml.mutex.Lock()
+	defer aml.mutex.Unlock()
+
+	now := tx.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	switch {
+	case aml.BlockedWallets[tx.FromAddress]:
+		reason = fmt.Sprintf("sender wallet %s is blocked", tx.FromAddress)
+	case aml.BlockedWallets[tx.ToAddress]:
+		reason = fmt.Sprintf("recipient wallet %s is blocked", tx.ToAddress)
+	case aml.SuspiciousActivityThreshold > 0 && tx.Amount > aml.SuspiciousActivityThreshold:
+		reason = fmt.Sprintf("amount %.2f exceeds threshold %.2f", tx.Amount, aml.SuspiciousActivityThreshold)
+	default:
+		if count := aml.trackNearThreshold(tx, now); count >= aml.structuringCount() {
+			reason = fmt.Sprintf("structuring: %d near-threshold transfers from %s within %s", count, tx.FromAddress, aml.structuringWindow())
+		}
+	}
+	if reason == "" {
+		return false, ""
+	}
+
+	if aml.ReportedTransactions == nil {
+		aml.ReportedTransactions = make(map[string]string)
+	}
+	aml.ReportedTransactions[tx.TransactionID] = reason
+	if aml.LedgerInstance != nil {
+		err := aml.LedgerInstance.ComplianceLedger.StoreSuspiciousTransaction(SuspiciousTransaction{
+			TransactionID: tx.TransactionID,
+			DetectedBy:    "AMLSystem",
+			Reason:        reason,
+			Amount:        tx.Amount,
+			Timestamp:     now,
+		})
+		if err != nil {
+			log.Printf("[ERROR] Failed to record suspicious transaction %s: %v", tx.TransactionID, err)
+		}
+	}
+	log.Printf("[WARNING] Transaction %s flagged: %s", tx.TransactionID, reason)
+
+	if aml.AutoBlock && !aml.BlockedWallets[tx.FromAddress] {
+		aml.blockWallet(tx.FromAddress, "auto-blocked: "+reason)
+	}
+	return true, reason
+}
+
+// BlockWallet blocks a wallet from transacting and records the reason.
+func (aml *AMLSystem) BlockWallet(addr, reason string) {
+	aml.mutex.Lock()
+	defer aml.mutex.Unlock()
+
+	aml.blockWallet(addr, reason)
+}
+
+// UnblockWallet lifts a block on a wallet and clears its structuring history.
+func (aml *AMLSystem) UnblockWallet(addr string) {
+	aml.mutex.Lock()
+	defer aml.mutex.Unlock()
+
+	if !aml.BlockedWallets[addr] {
+		return
+	}
+	delete(aml.BlockedWallets, addr)
+	delete(aml.BlockReasons, addr)
+	delete(aml.nearThreshold, addr)
+	log.Printf("[INFO] Wallet %s unblocked", addr)
+}
+
+// blockWallet blocks addr. The caller must hold the mutex.
+func (aml *AMLSystem) blockWallet(addr, reason string) {
+	if aml.BlockedWallets == nil {
+		aml.BlockedWallets = make(map[string]bool)
+	}
+	if aml.BlockReasons == nil {
+		aml.BlockReasons = make(map[string]string)
+	}
+	aml.BlockedWallets[addr] = true
+	aml.BlockReasons[addr] = reason
+	log.Printf("[WARNING] Wallet %s blocked: %s", addr, reason)
+}
+
+// trackNearThreshold records tx if its amount falls in the near-threshold
+// band and returns the sender's near-threshold transfer count within the
+// structuring window. The caller must hold the mutex.
+func (aml *AMLSystem) trackNearThreshold(tx Transaction, now time.Time) int {
+	if aml.SuspiciousActivityThreshold <= 0 || tx.Amount < aml.SuspiciousActivityThreshold*structuringBand {
+		return 0
+	}
+	if aml.nearThreshold == nil {
+		aml.nearThreshold = make(map[string][]time.Time)
+	}
+
+	cutoff := now.Add(-aml.structuringWindow())
+	recent := aml.nearThreshold[tx.FromAddress][:0]
+	for _, t := range aml.nearThreshold[tx.FromAddress] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	aml.nearThreshold[tx.FromAddress] = recent
+	return len(recent)
+}
+
+// structuringWindow returns the configured structuring window or the default.
+func (aml *AMLSystem) structuringWindow() time.Duration {
+	if aml.StructuringWindow > 0 {
+		return aml.StructuringWindow
+	}
+	return defaultStructuringWindow
+}
+
+// structuringCount returns the configured structuring count or the default.
+func (aml *AMLSystem) structuringCount() int {
+	if aml.StructuringCount > 0 {
+		return aml.StructuringCount
+	}
+	return defaultStructuringCount
+}
@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+)
+
+var migrationTestRules = []string{"encryption-in-transit", "no-plaintext-pii", "retain-history", "audit-trail"}
+
+func TestValidateMigrationAllowsCompliantMigration(t *testing.T) {
+	l := &ComplianceLedger{}
+	details := MigrationDetails{
+		// The free-text description is never matched against the rules.
+		Description:        "unencrypted staging copy will be purged",
+		EncryptedInTransit: true,
+		AuditTrail:         true,
+	}
+
+	compliance, err := l.ValidateMigration("migration-1", details, migrationTestRules)
+	if err != nil {
+		t.Fatalf("ValidateMigration: %v", err)
+	}
+	if compliance.ComplianceStatus != "Compliant" {
+		t.Fatalf("status = %q, want Compliant", compliance.ComplianceStatus)
+	}
+	if err := l.CheckMigrationAllowed("migration-1"); err != nil {
+		t.Fatalf("CheckMigrationAllowed: %v", err)
+	}
+}
+
+func TestValidateMigrationBlocksNonCompliantMigration(t *testing.T) {
+	l := &ComplianceLedger{}
+	details := MigrationDetails{
+		Description:        "move customer table",
+		EncryptedInTransit: true,
+		PlaintextPII:       true,
+		PurgesHistory:      true,
+		AuditTrail:         true,
+	}
+
+	compliance, err := l.ValidateMigration("migration-2", details, migrationTestRules)
+	if err == nil {
+		t.Fatal("expected a non-compliant migration to be rejected")
+	}
+	if compliance.ComplianceStatus != "NonCompliant" {
+		t.Fatalf("status = %q, want NonCompliant", compliance.ComplianceStatus)
+	}
+	for _, rule := range []string{"no-plaintext-pii", "retain-history"} {
+		if !strings.Contains(compliance.Details, rule) {
+			t.Fatalf("details %q do not name violated rule %s", compliance.Details, rule)
+		}
+	}
+	if err := l.CheckMigrationAllowed("migration-2"); err == nil {
+		t.Fatal("expected the blocked migration to be refused")
+	}
+
+	// Revalidating once the migration is fixed lifts the block.
+	details.PlaintextPII, details.PurgesHistory = false, false
+	if _, err := l.ValidateMigration("migration-2", details, migrationTestRules); err != nil {
+		t.Fatalf("ValidateMigration after fix: %v", err)
+	}
+	if err := l.CheckMigrationAllowed("migration-2"); err != nil {
+		t.Fatalf("CheckMigrationAllowed after fix: %v", err)
+	}
+}
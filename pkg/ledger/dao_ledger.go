@@ -123,17 +123,35 @@ func (l *DAOLedger) RecordProposalCreation(daoID, proposalID, creatorID, content
 
 // RecordProposalVote records a vote on a proposal.
 func (l *DAOLedger) RecordProposalVote(daoID, proposalID, memberID string, vote bool) error {
+	return l.RecordWeightedProposalVote(daoID, proposalID, memberID, vote, 1)
+}
+
+// RecordWeightedProposalVote records a member's vote on a proposal, counted
+// with the given weight. Each member can vote on a proposal only once.
+func (l *DAOLedger) RecordWeightedProposalVote(daoID, proposalID, memberID string, vote bool, weight int) error {
 	l.Lock()
 	defer l.Unlock()
 
 	if dao, exists := l.DAORecords[daoID]; exists {
 		if proposal, ok := dao.Proposals[proposalID]; ok {
+			if dao.ProposalVotes == nil {
+				dao.ProposalVotes = make(map[string]map[string]bool)
+			}
+			if dao.ProposalVotes[proposalID] == nil {
+				dao.ProposalVotes[proposalID] = make(map[string]bool)
+			}
+			if _, voted := dao.ProposalVotes[proposalID][memberID]; voted {
+				return errors.New("member has already voted on this proposal")
+			}
+
 			if vote {
-				proposal.ApproveCount++
+				proposal.ApproveCount += weight
 			} else {
-				proposal.RejectCount++
+				proposal.RejectCount += weight
 			}
-			proposal.VoteCount++
+			proposal.VoteCount += weight
+			dao.Proposals[proposalID] = proposal
+			dao.ProposalVotes[proposalID][memberID] = vote
 			fmt.Printf("Member %s voted on Proposal %s in DAO %s\n", memberID, proposalID, daoID)
 			return nil
 		}
@@ -149,8 +167,8 @@ func (l *DAOLedger) RecordProposalFinalization(daoID, proposalID, result string)
 
 	if dao, exists := l.DAORecords[daoID]; exists {
 		if proposal, ok := dao.Proposals[proposalID]; ok {
-			proposal.Status = "Finalized"
 			proposal.Status = result
+			dao.Proposals[proposalID] = proposal
 			fmt.Printf("Proposal %s finalized in DAO %s with result: %s\n", proposalID, daoID, result)
 			return nil
 		}
@@ -106,6 +106,84 @@ func (l *DataManagementLedger) RecordAggregationValidation(aggregatorID string)
 }
 
 
+// defaultRequiredConfirmations is used when RequiredConfirmations has not been configured.
+const defaultRequiredConfirmations = 3
+
+// SetRequiredConfirmations configures how many distinct validators must confirm an aggregation.
+func (l *DataManagementLedger) SetRequiredConfirmations(count int) error {
+	if count <= 0 {
+		return errors.New("required confirmations must be positive")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.RequiredConfirmations = count
+	return nil
+}
+
+// SubmitAggregation records a new aggregation awaiting validator confirmation.
+func (l *DataManagementLedger) SubmitAggregation(aggregator string) (*AggregationValidation, error) {
+	if aggregator == "" {
+		return nil, errors.New("aggregator must be provided")
+	}
+
+	id := l.generateUniqueID()
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.Aggregations == nil {
+		l.Aggregations = make(map[string]AggregationValidation)
+	}
+
+	aggregation := AggregationValidation{
+		ID:         id,
+		Aggregator: aggregator,
+		Validated:  false,
+		Timestamp:  time.Now(),
+	}
+	l.Aggregations[id] = aggregation
+
+	return &aggregation, nil
+}
+
+// ConfirmAggregation records a validator's confirmation and marks the aggregation
+// validated once the required number of distinct validators have confirmed it.
+func (l *DataManagementLedger) ConfirmAggregation(aggregationID, validatorID string) error {
+	if validatorID == "" {
+		return errors.New("validator must be provided")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	aggregation, exists := l.Aggregations[aggregationID]
+	if !exists {
+		return fmt.Errorf("aggregation %s not found", aggregationID)
+	}
+
+	for _, confirmed := range aggregation.Confirmations {
+		if confirmed == validatorID {
+			return fmt.Errorf("validator %s has already confirmed aggregation %s", validatorID, aggregationID)
+		}
+	}
+
+	aggregation.Confirmations = append(aggregation.Confirmations, validatorID)
+
+	required := l.RequiredConfirmations
+	if required <= 0 {
+		required = defaultRequiredConfirmations
+	}
+	if len(aggregation.Confirmations) >= required && !aggregation.Validated {
+		aggregation.Validated = true
+		aggregation.Timestamp = time.Now()
+		log.Printf("Aggregation %s validated with %d confirmations", aggregationID, len(aggregation.Confirmations))
+	}
+
+	l.Aggregations[aggregationID] = aggregation
+	return nil
+}
 
 // RecordDataTransmission records the transmission of data between entities.
 func (l *DataManagementLedger) RecordDataTransmission(sender, receiver string, data map[string]interface{}) (string, error) {
@@ -0,0 +1,47 @@
+package ledger
+
+import "testing"
+
+func TestConfirmAggregationValidatesAtRequiredCount(t *testing.T) {
+	l := &DataManagementLedger{}
+	if err := l.SetRequiredConfirmations(2); err != nil {
+		t.Fatalf("SetRequiredConfirmations: %v", err)
+	}
+	aggregation, err := l.SubmitAggregation("aggregator-1")
+	if err != nil {
+		t.Fatalf("SubmitAggregation: %v", err)
+	}
+
+	if err := l.ConfirmAggregation(aggregation.ID, "validator-1"); err != nil {
+		t.Fatalf("ConfirmAggregation(validator-1): %v", err)
+	}
+	if l.Aggregations[aggregation.ID].Validated {
+		t.Fatal("aggregation validated after a single confirmation")
+	}
+	if err := l.ConfirmAggregation(aggregation.ID, "validator-2"); err != nil {
+		t.Fatalf("ConfirmAggregation(validator-2): %v", err)
+	}
+	if !l.Aggregations[aggregation.ID].Validated {
+		t.Fatal("expected aggregation to be validated after two distinct confirmations")
+	}
+}
+
+func TestConfirmAggregationIgnoresDuplicateValidator(t *testing.T) {
+	l := &DataManagementLedger{}
+	if err := l.SetRequiredConfirmations(2); err != nil {
+		t.Fatalf("SetRequiredConfirmations: %v", err)
+	}
+	aggregation, _ := l.SubmitAggregation("aggregator-1")
+
+	if err := l.ConfirmAggregation(aggregation.ID, "validator-1"); err != nil {
+		t.Fatalf("ConfirmAggregation: %v", err)
+	}
+	if err := l.ConfirmAggregation(aggregation.ID, "validator-1"); err == nil {
+		t.Fatal("expected a duplicate confirmation to be rejected")
+	}
+
+	got := l.Aggregations[aggregation.ID]
+	if got.Validated || len(got.Confirmations) != 1 {
+		t.Fatalf("aggregation = %+v, want one confirmation and not validated", got)
+	}
+}
@@ -33,17 +33,22 @@ func (l *DeFiLedger) RecordPolicyCreation(policyID, holder string, insuredAmount
 }
 
 // RecordClaimSubmission records a claim submission for an insurance policy.
-func (l *DeFiLedger) RecordClaimSubmission(policyID string, claimAmount float64) error {
+func (l *DeFiLedger) RecordClaimSubmission(claimID, policyID string, claimAmount float64) error {
 	l.Lock()
 	defer l.Unlock()
 
+	if claimID == "" {
+		return errors.New("claim ID cannot be empty")
+	}
+	if _, exists := l.InsuranceClaims[claimID]; exists {
+		return fmt.Errorf("claim %s already exists", claimID)
+	}
 	if policy, exists := l.InsurancePolicies[policyID]; exists {
 		if policy.Status != "Active" {
 			return errors.New("policy is not active")
 		}
 
-		// Create a new claim for the policy
-		claimID := generateClaimID()
+		// Record the claim under the caller's claim ID so later updates find it
 		claim := InsuranceClaim{
 			ClaimID:     claimID,
 			PolicyID:    policyID,
@@ -87,6 +92,34 @@ func (l *DeFiLedger) RecordClaimRejection(claimID string) error {
 	return errors.New("claim does not exist")
 }
 
+// RecordClaimPayout records the payout of an approved claim to the insured.
+func (l *DeFiLedger) RecordClaimPayout(claimID, policyID, recipient string, amount, fee float64) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.InsurancePayouts == nil {
+		l.InsurancePayouts = make(map[string]InsurancePayout)
+	}
+	if _, exists := l.InsurancePayouts[claimID]; exists {
+		return fmt.Errorf("payout for claim %s already recorded", claimID)
+	}
+
+	l.InsurancePayouts[claimID] = InsurancePayout{
+		ClaimID:   claimID,
+		PolicyID:  policyID,
+		Recipient: recipient,
+		Amount:    amount,
+		Fee:       fee,
+		PaidAt:    time.Now(),
+	}
+	if claim, exists := l.InsuranceClaims[claimID]; exists {
+		claim.ClaimStatus = "Approved"
+		l.InsuranceClaims[claimID] = claim
+	}
+	fmt.Printf("Payout of %.2f for claim %s on policy %s recorded for %s\n", amount, claimID, policyID, recipient)
+	return nil
+}
+
 // RecordLiquidityPoolCreation records the creation of a liquidity pool.
 func (l *DeFiLedger) RecordLiquidityPoolCreation(poolID string, totalLiquidity, rewardRate float64) error {
 	l.Lock()
@@ -228,6 +261,48 @@ func (l *DeFiLedger) RecordLoanRepayment(poolID, loanID string) error {
 	return fmt.Errorf("loan %s does not exist in pool %s", loanID, poolID)
 }
 
+// RecordLoanDefault records that a loan defaulted and its collateral was seized into the pool.
+func (l *DeFiLedger) RecordLoanDefault(poolID, loanID string, seizedCollateral float64) error {
+	l.Lock()
+	defer l.Unlock()
+
+	// Check if the lending pool exists
+	pool, poolExists := l.LendingPools[poolID]
+	if !poolExists {
+		return fmt.Errorf("lending pool %s does not exist", poolID)
+	}
+
+	// Find the loan within the lending pool
+	for _, loan := range pool.ActiveLoans {
+		if loan.LoanID == loanID {
+			loan.Status = "Defaulted"
+			pool.TotalLiquidity += seizedCollateral
+			pool.AvailableFunds += seizedCollateral
+			l.LendingPools[poolID] = pool
+			fmt.Printf("Loan %s defaulted in pool %s; collateral %.2f seized\n", loanID, poolID, seizedCollateral)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("loan %s does not exist in pool %s", loanID, poolID)
+}
+
+// RecordLatePayment appends a late payment record, including its penalty fee, for a loan.
+func (l *DeFiLedger) RecordLatePayment(record LatePaymentRecord) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if record.LoanID == "" {
+		return errors.New("loan ID is required")
+	}
+	if l.LatePayments == nil {
+		l.LatePayments = make(map[string][]LatePaymentRecord)
+	}
+	l.LatePayments[record.LoanID] = append(l.LatePayments[record.LoanID], record)
+	fmt.Printf("Late payment recorded for loan %s with penalty fee %.2f\n", record.LoanID, record.PenaltyFee)
+	return nil
+}
+
 
 // RecordSyntheticAssetCreation records the creation of a synthetic asset.
 func (l *DeFiLedger) RecordSyntheticAssetCreation(assetID, assetName, underlyingAsset string, collateralRatio, totalSupply float64) error {
@@ -285,11 +360,6 @@ func (l *DeFiLedger) RecordBurningEvent(assetID string, amount float64) error {
 	return errors.New("synthetic asset does not exist")
 }
 
-// Helper function to generate a unique claim ID
-func generateClaimID() string {
-	return fmt.Sprintf("claim_%d", time.Now().UnixNano())
-}
-
 // RecordLendingPoolCreation logs the creation of a new lending pool in the ledger
 func (l *DeFiLedger) RecordLendingPoolCreation(poolID string, liquidity, interestRate float64) error {
     // Check for errors or constraints, e.g., if the poolID already exists (optional)
@@ -665,8 +735,15 @@ func (l *DeFiLedger) CreateCampaign(campaign CrowdfundingCampaign) error {
 	return nil
 }
 
-func (l *Ledger) Contribute(campaignID, userID string, amount float64) error {
-	campaign, exists := l.Campaigns[campaignID]
+// Contribute records a contribution to an active crowdfunding campaign and holds
+// it in escrow until the campaign is finalized. Each contribution must be at
+// least the campaign's minimum contribution, and a user's total contributions
+// may not exceed its maximum.
+func (l *DeFiLedger) Contribute(campaignID, userID string, amount float64) error {
+	l.Lock()
+	defer l.Unlock()
+
+	campaign, exists := l.CrowdfundingCampaigns[campaignID]
 	if !exists {
 		return fmt.Errorf("campaign not found")
 	}
@@ -676,17 +753,89 @@ func (l *Ledger) Contribute(campaignID, userID string, amount float64) error {
 	if campaign.EndTime.Before(time.Now()) {
 		return fmt.Errorf("campaign has ended")
 	}
+	if amount <= 0 {
+		return fmt.Errorf("contribution amount must be greater than zero")
+	}
 
-	contribution := CrowdfundingContribution{
+	if limits, limited := l.ContributionLimits[campaignID]; limited {
+		if amount < limits.Min {
+			return fmt.Errorf("contribution %.2f is below the minimum of %.2f", amount, limits.Min)
+		}
+		total := amount
+		for _, contribution := range l.Contributions[campaignID] {
+			if contribution.UserID == userID {
+				total += contribution.Amount
+			}
+		}
+		if total > limits.Max {
+			return fmt.Errorf("total contributions of %.2f by user %s would exceed the maximum of %.2f", total, userID, limits.Max)
+		}
+	}
+
+	if l.Contributions == nil {
+		l.Contributions = make(map[string][]CrowdfundingContribution)
+	}
+	if l.CrowdfundingEscrowFunds == nil {
+		l.CrowdfundingEscrowFunds = make(map[string]float64)
+	}
+
+	l.Contributions[campaignID] = append(l.Contributions[campaignID], CrowdfundingContribution{
 		CampaignID: campaignID,
 		UserID:     userID,
 		Amount:     amount,
 		Time:       time.Now(),
+	})
+	l.CrowdfundingEscrowFunds[campaignID] += amount
+	campaign.CollectedFunds += amount
+	l.CrowdfundingCampaigns[campaignID] = campaign
+	return nil
+}
+
+// FinalizeCampaign settles a crowdfunding campaign once its EndTime has passed.
+// If the goal was met the campaign is Closed and the escrowed funds are
+// released to the creator; otherwise it is Failed and every contribution is
+// refunded. Either outcome is written to the campaign's audit records.
+func (l *DeFiLedger) FinalizeCampaign(campaignID string, now time.Time) error {
+	l.Lock()
+	defer l.Unlock()
+
+	campaign, exists := l.CrowdfundingCampaigns[campaignID]
+	if !exists {
+		return fmt.Errorf("campaign not found")
+	}
+	if campaign.Status == "Closed" || campaign.Status == "Failed" {
+		return fmt.Errorf("campaign %s has already been finalized as %s", campaignID, campaign.Status)
+	}
+	if now.Before(campaign.EndTime) {
+		return fmt.Errorf("campaign %s does not end until %s", campaignID, campaign.EndTime.Format(time.RFC3339))
 	}
 
-	l.Contributions[campaignID] = append(l.Contributions[campaignID], contribution)
-	campaign.CollectedFunds += amount
-	l.Campaigns[campaignID] = campaign
+	escrowed := l.CrowdfundingEscrowFunds[campaignID]
+	var details string
+	if campaign.CollectedFunds >= campaign.GoalAmount {
+		campaign.Status = "Closed"
+		fmt.Printf("Releasing %.2f to campaign creator %s for campaign ID %s.\n", escrowed, campaign.CreatorID, campaignID)
+		details = fmt.Sprintf("Campaign %s closed: goal of %.2f met with %.2f collected; %.2f released to creator.", campaignID, campaign.GoalAmount, campaign.CollectedFunds, escrowed)
+	} else {
+		campaign.Status = "Failed"
+		refunded := 0.0
+		for _, contribution := range l.Contributions[campaignID] {
+			fmt.Printf("Refunding %.2f to user %s for campaign ID %s.\n", contribution.Amount, contribution.UserID, campaignID)
+			refunded += contribution.Amount
+		}
+		details = fmt.Sprintf("Campaign %s failed: goal of %.2f not met with %.2f collected; %.2f refunded to %d contributions.", campaignID, campaign.GoalAmount, campaign.CollectedFunds, refunded, len(l.Contributions[campaignID]))
+	}
+
+	delete(l.CrowdfundingEscrowFunds, campaignID)
+	l.CrowdfundingCampaigns[campaignID] = campaign
+
+	if l.CrowdfundingAuditRecords == nil {
+		l.CrowdfundingAuditRecords = make(map[string][]CrowdfundingAuditRecord)
+	}
+	l.CrowdfundingAuditRecords[campaignID] = append(l.CrowdfundingAuditRecords[campaignID], CrowdfundingAuditRecord{
+		CampaignID: campaignID,
+		Details:    details,
+	})
 	return nil
 }
 
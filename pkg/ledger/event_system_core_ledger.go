@@ -3,6 +3,10 @@ package ledger
 import (
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -252,6 +256,79 @@ func (l *EnvironmentSystemCoreLedger) GetNetworkMetrics() (NetworkMetrics, error
     return l.NetworkMetrics, nil
 }
 
+const (
+    ActivityTypeTransaction   = "Transaction"   // Chain activity logged for each processed transaction
+    ActivityTypeBlockProduced = "BlockProduced" // Chain activity logged for each produced block
+    networkMetricsWindow      = 5 * time.Minute // Window of recent activity used to compute network metrics
+)
+
+// LogChainActivity appends an activity entry to the chain activity log.
+func (l *EnvironmentSystemCoreLedger) LogChainActivity(activityType, desc string, now time.Time) {
+    l.Lock()
+    defer l.Unlock()
+
+    l.ChainActivityLogs = append(l.ChainActivityLogs, ChainActivityLog{
+        Timestamp:    now,
+        ActivityType: activityType,
+        Description:  desc,
+    })
+}
+
+// ComputeNetworkMetrics derives network metrics from the activity logged in
+// the networkMetricsWindow before now. TransactionRate is transactions per
+// second over the window and BlockLatency is the mean interval between
+// consecutive produced blocks. The result is also stored as the ledger's
+// current NetworkMetrics.
+func (l *EnvironmentSystemCoreLedger) ComputeNetworkMetrics(now time.Time) *NetworkMetrics {
+    l.Lock()
+    defer l.Unlock()
+
+    windowStart := now.Add(-networkMetricsWindow)
+    transactions := 0
+    var blockTimes []time.Time
+    for _, entry := range l.ChainActivityLogs {
+        if entry.Timestamp.Before(windowStart) || entry.Timestamp.After(now) {
+            continue
+        }
+        switch entry.ActivityType {
+        case ActivityTypeTransaction:
+            transactions++
+        case ActivityTypeBlockProduced:
+            blockTimes = append(blockTimes, entry.Timestamp)
+        }
+    }
+
+    var latency time.Duration
+    if len(blockTimes) > 1 {
+        sort.Slice(blockTimes, func(i, j int) bool { return blockTimes[i].Before(blockTimes[j]) })
+        latency = blockTimes[len(blockTimes)-1].Sub(blockTimes[0]) / time.Duration(len(blockTimes)-1)
+    }
+
+    l.NetworkMetrics = NetworkMetrics{
+        NodeCount:       len(l.NodeStatuses),
+        TransactionRate: float64(transactions) / networkMetricsWindow.Seconds(),
+        BlockLatency:    latency,
+    }
+    metrics := l.NetworkMetrics
+    return &metrics
+}
+
+// ActivityBreakdown counts logged chain activities by type between from and
+// to, inclusive.
+func (l *EnvironmentSystemCoreLedger) ActivityBreakdown(from, to time.Time) map[string]int {
+    l.Lock()
+    defer l.Unlock()
+
+    breakdown := make(map[string]int)
+    for _, entry := range l.ChainActivityLogs {
+        if entry.Timestamp.Before(from) || entry.Timestamp.After(to) {
+            continue
+        }
+        breakdown[entry.ActivityType]++
+    }
+    return breakdown
+}
+
 func (l *EnvironmentSystemCoreLedger) RecordNodeHealth(entry NodeHealthLog) error {
     l.NodeHealthLogs = append(l.NodeHealthLogs, entry)
     return nil
@@ -964,6 +1041,106 @@ func (l *EnvironmentSystemCoreLedger) UpdateEnvironmentConfig(contextID string,
     return nil
 }
 
+// ValidateEnvironment checks every variable in cfg against its constraint and
+// every limited value against its limit. Supported constraints are
+// "required" (must be present and non-empty), "int", "bool",
+// "oneof:a|b|c" and "regex:<pattern>". A limit is the maximum allowed value
+// for a variable, which must then be an integer. Violations are reported in
+// sorted variable order.
+func ValidateEnvironment(cfg EnvironmentConfig) (bool, []string) {
+    var violations []string
+
+    names := make([]string, 0, len(cfg.Constraints))
+    for name := range cfg.Constraints {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        if violation := checkEnvironmentConstraint(name, cfg.Variables[name], cfg.Constraints[name]); violation != "" {
+            violations = append(violations, violation)
+        }
+    }
+
+    names = names[:0]
+    for name := range cfg.Limits {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        value, exists := cfg.Variables[name]
+        if !exists {
+            continue
+        }
+        number, err := strconv.Atoi(value)
+        if err != nil {
+            violations = append(violations, fmt.Sprintf("%s: value %q is not an integer and cannot be checked against its limit", name, value))
+            continue
+        }
+        if number > cfg.Limits[name] {
+            violations = append(violations, fmt.Sprintf("%s: value %d exceeds limit %d", name, number, cfg.Limits[name]))
+        }
+    }
+
+    return len(violations) == 0, violations
+}
+
+// checkEnvironmentConstraint returns a violation message if value does not
+// satisfy constraint, or an empty string if it does.
+func checkEnvironmentConstraint(name, value, constraint string) string {
+    switch {
+    case constraint == "required":
+        if value == "" {
+            return fmt.Sprintf("%s: required variable is missing", name)
+        }
+    case constraint == "int":
+        if _, err := strconv.Atoi(value); err != nil {
+            return fmt.Sprintf("%s: value %q is not an integer", name, value)
+        }
+    case constraint == "bool":
+        if _, err := strconv.ParseBool(value); err != nil {
+            return fmt.Sprintf("%s: value %q is not a boolean", name, value)
+        }
+    case strings.HasPrefix(constraint, "oneof:"):
+        for _, allowed := range strings.Split(strings.TrimPrefix(constraint, "oneof:"), "|") {
+            if value == allowed {
+                return ""
+            }
+        }
+        return fmt.Sprintf("%s: value %q is not one of %s", name, value, strings.TrimPrefix(constraint, "oneof:"))
+    case strings.HasPrefix(constraint, "regex:"):
+        pattern, err := regexp.Compile(strings.TrimPrefix(constraint, "regex:"))
+        if err != nil {
+            return fmt.Sprintf("%s: invalid constraint pattern: %v", name, err)
+        }
+        if !pattern.MatchString(value) {
+            return fmt.Sprintf("%s: value %q does not match %s", name, value, pattern.String())
+        }
+    default:
+        return fmt.Sprintf("%s: unknown constraint %q", name, constraint)
+    }
+    return ""
+}
+
+// ApplyEnvironment validates cfg and, if it is valid, applies its variables
+// to the ledger's environment variables.
+func (l *EnvironmentSystemCoreLedger) ApplyEnvironment(cfg EnvironmentConfig) error {
+    if valid, violations := ValidateEnvironment(cfg); !valid {
+        return fmt.Errorf("environment config rejected: %s", strings.Join(violations, "; "))
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if l.EnvironmentVars == nil {
+        l.EnvironmentVars = make(map[string]string)
+    }
+    for name, value := range cfg.Variables {
+        l.EnvironmentVars[name] = value
+    }
+    log.Printf("[INFO] Applied environment config with %d variables", len(cfg.Variables))
+    return nil
+}
+
 func (l *EnvironmentSystemCoreLedger) CreateSubContext(parentContextID string, subContextID string, encryptedResources string) error {
     if l.SubExecutionContexts == nil {
         l.SubExecutionContexts = make(map[string]map[string]string)
@@ -1055,7 +1232,13 @@ func (l *EnvironmentSystemCoreLedger) VerifyConsensusIntegrity() (bool, error) {
 }
 
 func (l *EnvironmentSystemCoreLedger) RecordFinalityEvent(entry FinalityLogEntry) error {
-    // Implement logic to log the finality event
+    l.Lock()
+    defer l.Unlock()
+
+    if entry.EventID == "" {
+        return fmt.Errorf("finality event must have an ID")
+    }
+    l.FinalityLog = append(l.FinalityLog, entry)
     return nil
 }
 
@@ -1084,6 +1267,9 @@ func (l *EnvironmentSystemCoreLedger) RetryFinalization(entityID string) error {
 }
 
 func (l *EnvironmentSystemCoreLedger) SetFinalityPending(entityID string) error {
+    if l.FinalizationRecords == nil {
+        l.FinalizationRecords = make(map[string]FinalizationRecord)
+    }
     record, exists := l.FinalizationRecords[entityID]
     if !exists {
         record = FinalizationRecord{
@@ -1098,6 +1284,7 @@ func (l *EnvironmentSystemCoreLedger) SetFinalityPending(entityID string) error
     }
 
     l.FinalizationRecords[entityID] = record
+    l.FinalityLog = append(l.FinalityLog, FinalityLogEntry{EventID: entityID, Description: FinalityEventPending, Timestamp: time.Now()})
     return nil
 }
 
@@ -1114,6 +1301,7 @@ func (l *EnvironmentSystemCoreLedger) ResolveFinalityPending(entityID string) er
     record.IsPending = false
     record.IsResolved = true
     l.FinalizationRecords[entityID] = record
+    l.FinalityLog = append(l.FinalityLog, FinalityLogEntry{EventID: entityID, Description: FinalityEventFinalized, Timestamp: time.Now()})
     return nil
 }
 
@@ -1126,6 +1314,76 @@ func (l *EnvironmentSystemCoreLedger) GetFinalizationStartTime(entityID string)
     return record.StartTime, nil
 }
 
+const (
+    FinalityEventPending   = "pending"   // FinalityLogEntry description for an entity awaiting finality
+    FinalityEventFinalized = "finalized" // FinalityLogEntry description for a finalized entity
+)
+
+// RecordFinalization marks entityID as finalized at now and appends the
+// event to the finality log so the state survives a restart.
+func (l *EnvironmentSystemCoreLedger) RecordFinalization(entityID string, now time.Time) (*FinalizationRecord, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    if entityID == "" {
+        return nil, fmt.Errorf("entity ID cannot be empty")
+    }
+    if l.FinalizationRecords == nil {
+        l.FinalizationRecords = make(map[string]FinalizationRecord)
+    }
+
+    record, exists := l.FinalizationRecords[entityID]
+    if exists && record.IsResolved {
+        return nil, fmt.Errorf("entity %s is already finalized", entityID)
+    }
+    if !exists {
+        record = FinalizationRecord{EntityID: entityID, StartTime: now}
+    }
+    record.IsPending = false
+    record.IsResolved = true
+    l.FinalizationRecords[entityID] = record
+    l.FinalityLog = append(l.FinalityLog, FinalityLogEntry{EventID: entityID, Description: FinalityEventFinalized, Timestamp: now})
+    return &record, nil
+}
+
+// ReplayFinalityLog rebuilds FinalizationRecords from the finality log,
+// applying events in order. An entity's StartTime is taken from its first
+// event. It returns how many entities ended up finalized and pending.
+func (l *EnvironmentSystemCoreLedger) ReplayFinalityLog() (finalized, pending int, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    records := make(map[string]FinalizationRecord)
+    for i, entry := range l.FinalityLog {
+        record, exists := records[entry.EventID]
+        if !exists {
+            record = FinalizationRecord{EntityID: entry.EventID, StartTime: entry.Timestamp}
+        }
+        switch entry.Description {
+        case FinalityEventPending:
+            record.IsPending = true
+            record.IsResolved = false
+        case FinalityEventFinalized:
+            record.IsPending = false
+            record.IsResolved = true
+        default:
+            return 0, 0, fmt.Errorf("finality log entry %d for %s has unknown event %q", i, entry.EventID, entry.Description)
+        }
+        records[entry.EventID] = record
+    }
+
+    for _, record := range records {
+        if record.IsResolved {
+            finalized++
+        } else if record.IsPending {
+            pending++
+        }
+    }
+    l.FinalizationRecords = records
+    log.Printf("[INFO] Replayed %d finality events: %d finalized, %d pending", len(l.FinalityLog), finalized, pending)
+    return finalized, pending, nil
+}
+
 func (l *EnvironmentSystemCoreLedger) RecordReconciliationResult(entry ReconciliationLogEntry) error {
     // Implement logging logic here
     return nil
@@ -1160,6 +1418,78 @@ func (l *EnvironmentSystemCoreLedger) RecordExceptionLog(entry ExceptionLogEntry
 	return nil
 }
 
+var (
+	exceptionVariablePattern   = regexp.MustCompile(`0x[0-9a-f]+|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|\d+(\.\d+)?`)
+	exceptionWhitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// RecordException logs an exception with the given description at now.
+func (l *EnvironmentSystemCoreLedger) RecordException(desc string, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.ExceptionLogs = append(l.ExceptionLogs, ExceptionLogEntry{
+		ExceptionID: generateUniqueID(),
+		Description: desc,
+		Timestamp:   now,
+	})
+}
+
+// GroupExceptions clusters the exceptions logged within window before now by
+// normalized description, so occurrences that differ only in numbers, hex
+// values or IDs share a root-cause group.
+func (l *EnvironmentSystemCoreLedger) GroupExceptions(window time.Duration, now time.Time) map[string][]ExceptionLogEntry {
+	l.Lock()
+	defer l.Unlock()
+
+	cutoff := now.Add(-window)
+	groups := make(map[string][]ExceptionLogEntry)
+	for _, entry := range l.ExceptionLogs {
+		if entry.Timestamp.Before(cutoff) || entry.Timestamp.After(now) {
+			continue
+		}
+		pattern := normalizeExceptionDescription(entry.Description)
+		groups[pattern] = append(groups[pattern], entry)
+	}
+	return groups
+}
+
+// TopExceptions returns the n most frequent exception patterns across the
+// whole log, most frequent first. Ties are ordered by pattern.
+func (l *EnvironmentSystemCoreLedger) TopExceptions(n int) []ExceptionPattern {
+	l.Lock()
+	defer l.Unlock()
+
+	counts := make(map[string]int)
+	for _, entry := range l.ExceptionLogs {
+		counts[normalizeExceptionDescription(entry.Description)]++
+	}
+
+	patterns := make([]ExceptionPattern, 0, len(counts))
+	for pattern, count := range counts {
+		patterns = append(patterns, ExceptionPattern{Pattern: pattern, Count: count})
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Count != patterns[j].Count {
+			return patterns[i].Count > patterns[j].Count
+		}
+		return patterns[i].Pattern < patterns[j].Pattern
+	})
+
+	if n >= 0 && n < len(patterns) {
+		patterns = patterns[:n]
+	}
+	return patterns
+}
+
+// normalizeExceptionDescription lowercases desc, replaces numbers, hex values
+// and UUIDs with "#", and collapses whitespace.
+func normalizeExceptionDescription(desc string) string {
+	normalized := strings.ToLower(strings.TrimSpace(desc))
+	normalized = exceptionVariablePattern.ReplaceAllString(normalized, "#")
+	return exceptionWhitespacePattern.ReplaceAllString(normalized, " ")
+}
+
 func (l *EnvironmentSystemCoreLedger) RetryOperation(operationID string) error {
 	// Implement retry logic; this is a placeholder example
 	if attempt, exists := l.RetryableOperations[operationID]; exists && attempt < 3 {
@@ -1308,6 +1638,82 @@ func (l *EnvironmentSystemCoreLedger) ClearEmergencyAlert() error {
     return nil
 }
 
+// BroadcastEmergency raises an emergency alert to every known node and
+// activates the emergency status until all of them acknowledge it.
+func (l *EnvironmentSystemCoreLedger) BroadcastEmergency(message string, now time.Time) (*EmergencyAlert, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    if message == "" {
+        return nil, fmt.Errorf("emergency message cannot be empty")
+    }
+    if len(l.NodeStatuses) == 0 {
+        return nil, fmt.Errorf("no nodes to broadcast the emergency to")
+    }
+
+    l.EmergencyAcks = make(map[string]bool, len(l.NodeStatuses))
+    for nodeID := range l.NodeStatuses {
+        l.EmergencyAcks[nodeID] = false
+    }
+    l.EmergencyAlert = &EmergencyAlert{
+        Message:   message,
+        IsActive:  true,
+        Timestamp: now,
+    }
+    l.EmergencyStatus = EmergencyStatus{Active: true, UpdatedAt: now}
+    log.Printf("[WARNING] Emergency broadcast to %d nodes: %s", len(l.EmergencyAcks), message)
+
+    alert := *l.EmergencyAlert
+    return &alert, nil
+}
+
+// AcknowledgeEmergency records nodeID's acknowledgement of the active
+// emergency. Once every notified node has acknowledged, the emergency is cleared.
+func (l *EnvironmentSystemCoreLedger) AcknowledgeEmergency(nodeID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if !l.EmergencyStatus.Active {
+        return fmt.Errorf("no active emergency to acknowledge")
+    }
+    if _, notified := l.EmergencyAcks[nodeID]; !notified {
+        return fmt.Errorf("node %s was not notified of the active emergency", nodeID)
+    }
+
+    l.EmergencyAcks[nodeID] = true
+    for _, acked := range l.EmergencyAcks {
+        if !acked {
+            return nil
+        }
+    }
+
+    now := time.Now()
+    l.EmergencyStatus = EmergencyStatus{Active: false, UpdatedAt: now}
+    if l.EmergencyAlert != nil {
+        l.EmergencyAlert.IsActive = false
+    }
+    log.Printf("[INFO] Emergency acknowledged by all %d nodes and cleared", len(l.EmergencyAcks))
+    return nil
+}
+
+// EmergencyAcknowledgmentRate returns the fraction of notified nodes that have
+// acknowledged the most recent emergency broadcast.
+func (l *EnvironmentSystemCoreLedger) EmergencyAcknowledgmentRate() float64 {
+    l.Lock()
+    defer l.Unlock()
+
+    if len(l.EmergencyAcks) == 0 {
+        return 0
+    }
+    acked := 0
+    for _, ok := range l.EmergencyAcks {
+        if ok {
+            acked++
+        }
+    }
+    return float64(acked) / float64(len(l.EmergencyAcks))
+}
+
 // Logs diagnostic results.
 func (l *EnvironmentSystemCoreLedger) LogSystemDiagnostics(encryptedResults string) error {
     l.DiagnosticLogs = append(l.DiagnosticLogs, encryptedResults)
@@ -1354,16 +1760,69 @@ func (l *EnvironmentSystemCoreLedger) ClearCriticalInterrupt(interruptID string)
     return nil
 }
 
-// SetTrapTimeout defines a timeout for a specific trap.
-func (l *EnvironmentSystemCoreLedger) SetTrapTimeout(trapID string, timeout time.Duration) error {
+// TrapTimeoutErrorCode is the ErrorCode of TrapEvents emitted for traps
+// that time out without being resolved.
+const TrapTimeoutErrorCode = 408
+
+// SetTrapTimeout defines a timeout for a specific trap, starting at now.
+func (l *EnvironmentSystemCoreLedger) SetTrapTimeout(trapID string, timeout time.Duration, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if trapID == "" || timeout <= 0 {
+        return fmt.Errorf("trap ID and a positive timeout are required")
+    }
+    if l.TrapTimeouts == nil {
+        l.TrapTimeouts = make(map[string]TrapTimeout)
+    }
     l.TrapTimeouts[trapID] = TrapTimeout{
-        TrapID:   trapID,
-        Timeout:  timeout,
-        SetTime:  time.Now(),
+        TrapID:  trapID,
+        Timeout: timeout,
+        SetTime: now,
     }
     return nil
 }
 
+// ClearTrapTimeout removes the timeout for a trap that has been resolved.
+func (l *EnvironmentSystemCoreLedger) ClearTrapTimeout(trapID string) {
+    l.Lock()
+    defer l.Unlock()
+
+    delete(l.TrapTimeouts, trapID)
+}
+
+// CheckTrapTimeouts emits a TrapEvent for every trap whose timeout elapsed
+// as of now without being cleared. Each expired timeout fires once: it is
+// removed and its event is appended to TrapEvents.
+func (l *EnvironmentSystemCoreLedger) CheckTrapTimeouts(now time.Time) []TrapEvent {
+    l.Lock()
+    defer l.Unlock()
+
+    trapIDs := make([]string, 0, len(l.TrapTimeouts))
+    for trapID := range l.TrapTimeouts {
+        trapIDs = append(trapIDs, trapID)
+    }
+    sort.Strings(trapIDs)
+
+    var events []TrapEvent
+    for _, trapID := range trapIDs {
+        trap := l.TrapTimeouts[trapID]
+        if now.Sub(trap.SetTime) <= trap.Timeout {
+            continue
+        }
+        event := TrapEvent{
+            ErrorCode: TrapTimeoutErrorCode,
+            Message:   fmt.Sprintf("trap %s timed out after %s without resolution", trapID, trap.Timeout),
+            Timestamp: now,
+        }
+        l.TrapEvents = append(l.TrapEvents, event)
+        events = append(events, event)
+        delete(l.TrapTimeouts, trapID)
+        log.Printf("[WARNING] %s", event.Message)
+    }
+    return events
+}
+
 // IsTrapTimedOut checks if a specific trap has reached its timeout.
 func (l *EnvironmentSystemCoreLedger) IsTrapTimedOut(trapID string) (bool, error) {
     trap, exists := l.TrapTimeouts[trapID]
@@ -1415,6 +1874,69 @@ func (l *EnvironmentSystemCoreLedger) LogBlockEvent(eventType string, blockID st
     return nil
 }
 
+const (
+    BlockEventConfirmed   = "Confirmed" // Block has been included in the chain
+    BlockEventFinalized   = "Finalized" // Block has reached finality
+    BlockEventOrphaned    = "Orphaned"  // Block was dropped from the chain by a reorg
+    blockSubscriberBuffer = 16          // Events buffered per subscriber before delivery is dropped
+)
+
+// SubscribeToBlock returns a channel that receives every event emitted for
+// blockID, and a function that cancels the subscription and closes the channel.
+func (l *EnvironmentSystemCoreLedger) SubscribeToBlock(blockID string) (<-chan BlockEvent, func()) {
+    l.Lock()
+    defer l.Unlock()
+
+    if l.BlockSubscribers == nil {
+        l.BlockSubscribers = make(map[string]map[int]chan BlockEvent)
+    }
+    if l.BlockSubscribers[blockID] == nil {
+        l.BlockSubscribers[blockID] = make(map[int]chan BlockEvent)
+    }
+
+    id := l.nextBlockSubscriberID
+    l.nextBlockSubscriberID++
+    ch := make(chan BlockEvent, blockSubscriberBuffer)
+    l.BlockSubscribers[blockID][id] = ch
+
+    unsubscribe := func() {
+        l.Lock()
+        defer l.Unlock()
+
+        subscribers := l.BlockSubscribers[blockID]
+        if _, exists := subscribers[id]; !exists {
+            return
+        }
+        delete(subscribers, id)
+        if len(subscribers) == 0 {
+            delete(l.BlockSubscribers, blockID)
+        }
+        close(ch)
+    }
+    return ch, unsubscribe
+}
+
+// EmitBlockEvent logs a block event and delivers it to every subscriber of
+// the event's block. Delivery never blocks: a subscriber whose buffer is full
+// misses the event.
+func (l *EnvironmentSystemCoreLedger) EmitBlockEvent(event BlockEvent) {
+    l.Lock()
+    defer l.Unlock()
+
+    if event.Timestamp.IsZero() {
+        event.Timestamp = time.Now()
+    }
+    l.BlockEvents = append(l.BlockEvents, event)
+
+    for id, ch := range l.BlockSubscribers[event.BlockID] {
+        select {
+        case ch <- event:
+        default:
+            log.Printf("[WARNING] Subscriber %d for block %s is full; dropped %s event", id, event.BlockID, event.EventType)
+        }
+    }
+}
+
 // GetBlockchainParameter retrieves the value of a blockchain parameter.
 func (l *EnvironmentSystemCoreLedger) GetBlockchainParameter(paramName string) (string, error) {
     value, exists := l.BlockchainParameters[paramName]
@@ -1430,6 +1952,117 @@ func (l *EnvironmentSystemCoreLedger) SetBlockchainParameter(paramName string, p
     return nil
 }
 
+const (
+    ParameterKindInt   = "int"   // Parameter holds an integer value
+    ParameterKindFloat = "float" // Parameter holds a floating-point value
+)
+
+// defaultParameterSpecs are the governed parameters registered with every ledger.
+var defaultParameterSpecs = []ParameterSpec{
+    {Name: "BlockDifficulty", Kind: ParameterKindInt, Min: 1, Max: 64, Default: "4"},
+    {Name: "RewardRate", Kind: ParameterKindFloat, Min: 0, Max: 1, Default: "0.05"},
+    {Name: "BlockGasLimit", Kind: ParameterKindInt, Min: 1000000, Max: 100000000, Default: "8000000"},
+    {Name: "SubBlocksPerBlock", Kind: ParameterKindInt, Min: 1, Max: 10000, Default: "1000"},
+}
+
+// RegisterParameter registers or replaces the type and bounds of a governed parameter.
+func (l *EnvironmentSystemCoreLedger) RegisterParameter(spec ParameterSpec) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if spec.Name == "" {
+        return fmt.Errorf("parameter name cannot be empty")
+    }
+    if spec.Kind != ParameterKindInt && spec.Kind != ParameterKindFloat {
+        return fmt.Errorf("unsupported kind %q for parameter %s", spec.Kind, spec.Name)
+    }
+    if spec.Min > spec.Max {
+        return fmt.Errorf("parameter %s has min %v greater than max %v", spec.Name, spec.Min, spec.Max)
+    }
+    l.ensureParameterSpecs()
+    l.ParameterSpecs[spec.Name] = spec
+    return nil
+}
+
+// SetParameter validates value against the parameter's registered type and
+// range, stores it, and appends the change to the parameter change log.
+func (l *EnvironmentSystemCoreLedger) SetParameter(name, value string, by string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    l.ensureParameterSpecs()
+    spec, exists := l.ParameterSpecs[name]
+    if !exists {
+        return fmt.Errorf("unknown blockchain parameter %s", name)
+    }
+
+    var number float64
+    switch spec.Kind {
+    case ParameterKindInt:
+        parsed, err := strconv.ParseInt(value, 10, 64)
+        if err != nil {
+            return fmt.Errorf("parameter %s requires an integer value: %v", name, err)
+        }
+        number = float64(parsed)
+    case ParameterKindFloat:
+        parsed, err := strconv.ParseFloat(value, 64)
+        if err != nil {
+            return fmt.Errorf("parameter %s requires a numeric value: %v", name, err)
+        }
+        number = parsed
+    }
+    if number < spec.Min || number > spec.Max {
+        return fmt.Errorf("value %s for parameter %s is outside the allowed range [%v, %v]", value, name, spec.Min, spec.Max)
+    }
+
+    if l.BlockchainParameters == nil {
+        l.BlockchainParameters = make(map[string]string)
+    }
+    oldValue, set := l.BlockchainParameters[name]
+    if !set {
+        oldValue = spec.Default
+    }
+    l.BlockchainParameters[name] = value
+    l.ParameterChangeLog = append(l.ParameterChangeLog, ParameterChange{
+        Name:      name,
+        OldValue:  oldValue,
+        NewValue:  value,
+        ChangedBy: by,
+        Timestamp: time.Now(),
+    })
+
+    log.Printf("[INFO] Parameter %s changed from %s to %s by %s", name, oldValue, value, by)
+    return nil
+}
+
+// GetParameter returns the current value of a governed parameter, or its
+// default if it has not been set.
+func (l *EnvironmentSystemCoreLedger) GetParameter(name string) (string, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    l.ensureParameterSpecs()
+    spec, exists := l.ParameterSpecs[name]
+    if !exists {
+        return "", fmt.Errorf("unknown blockchain parameter %s", name)
+    }
+    if value, set := l.BlockchainParameters[name]; set {
+        return value, nil
+    }
+    return spec.Default, nil
+}
+
+// ensureParameterSpecs registers the default parameter specs on first use.
+func (l *EnvironmentSystemCoreLedger) ensureParameterSpecs() {
+    if l.ParameterSpecs != nil {
+        return
+    }
+    l.ParameterSpecs = make(map[string]ParameterSpec, len(defaultParameterSpecs))
+    for _, spec := range defaultParameterSpecs {
+        l.ParameterSpecs[spec.Name] = spec
+    }
+}
+
 // ValidateBlockIntegrity checks the integrity of a block by ID.
 func (l *EnvironmentSystemCoreLedger) ValidateBlockIntegrity(blockID string) (bool, error) {
     // Simulate block validation logic
@@ -1454,17 +2087,228 @@ func (l *EnvironmentSystemCoreLedger) LogTrafficReport(encryptedReport string) e
     return nil
 }
 
+const trafficReportTopSources = 5 // Number of busiest sources listed in a traffic report
+
+// RecordTrafficData stores a traffic sample for inclusion in traffic reports.
+func (l *EnvironmentSystemCoreLedger) RecordTrafficData(data TrafficData) {
+    l.Lock()
+    defer l.Unlock()
+    l.TrafficSamples = append(l.TrafficSamples, data)
+}
+
+// RecordTransferMetrics stores data transfer metrics for inclusion in traffic reports.
+func (l *EnvironmentSystemCoreLedger) RecordTransferMetrics(metrics DataTransferMetrics) {
+    l.Lock()
+    defer l.Unlock()
+    l.TransferMetrics = append(l.TransferMetrics, metrics)
+}
+
+// GenerateTrafficReport summarises the traffic recorded between from and to,
+// inclusive. Each request timestamp inside the window counts towards the total
+// and towards its source; a source's PeakRequestRate counts towards the peak
+// if it made any request in the window. The report is also appended to the
+// ledger's TrafficReports.
+func (l *EnvironmentSystemCoreLedger) GenerateTrafficReport(from, to time.Time) (*NetworkTrafficReport, error) {
+    if to.Before(from) {
+        return nil, fmt.Errorf("invalid report window: %s is before %s", to.Format(time.RFC3339), from.Format(time.RFC3339))
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    report := l.buildTrafficReport(from, to, time.Now())
+    l.TrafficReports = append(l.TrafficReports, report)
+    return &report, nil
+}
+
+// ScheduleTrafficReports generates a traffic report covering each interval as
+// it elapses. Scheduling again replaces the previous schedule, and a
+// non-positive interval stops scheduled reports.
+func (l *EnvironmentSystemCoreLedger) ScheduleTrafficReports(interval time.Duration) {
+    l.Lock()
+    defer l.Unlock()
+
+    if l.trafficReportStop != nil {
+        close(l.trafficReportStop)
+        l.trafficReportStop = nil
+    }
+    if interval <= 0 {
+        return
+    }
+
+    stop := make(chan struct{})
+    l.trafficReportStop = stop
+    go func() {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-stop:
+                return
+            case now := <-ticker.C:
+                if _, err := l.GenerateTrafficReport(now.Add(-interval), now); err != nil {
+                    log.Printf("[WARNING] Scheduled traffic report failed: %v", err)
+                }
+            }
+        }
+    }()
+}
+
+// buildTrafficReport aggregates the recorded traffic in [from, to]. The caller must hold the lock.
+func (l *EnvironmentSystemCoreLedger) buildTrafficReport(from, to, now time.Time) NetworkTrafficReport {
+    report := NetworkTrafficReport{
+        Timestamp: now,
+        From:      from,
+        To:        to,
+    }
+
+    requestsBySource := make(map[string]int)
+    for _, sample := range l.TrafficSamples {
+        requests := 0
+        for _, ts := range sample.Timestamps {
+            if !ts.Before(from) && !ts.After(to) {
+                requests++
+            }
+        }
+        if requests == 0 {
+            continue
+        }
+        report.TotalRequests += requests
+        requestsBySource[sample.SourceIP] += requests
+        if sample.PeakRequestRate > report.PeakRequestRate {
+            report.PeakRequestRate = sample.PeakRequestRate
+        }
+    }
+
+    for _, metrics := range l.TransferMetrics {
+        if metrics.Timestamp.Before(from) || metrics.Timestamp.After(to) {
+            continue
+        }
+        if metrics.PeakRateMBps > report.PeakTransferMBps {
+            report.PeakTransferMBps = metrics.PeakRateMBps
+        }
+    }
+
+    sources := make([]string, 0, len(requestsBySource))
+    for source := range requestsBySource {
+        sources = append(sources, source)
+    }
+    sort.Slice(sources, func(i, j int) bool {
+        if requestsBySource[sources[i]] != requestsBySource[sources[j]] {
+            return requestsBySource[sources[i]] > requestsBySource[sources[j]]
+        }
+        return sources[i] < sources[j]
+    })
+    if len(sources) > trafficReportTopSources {
+        sources = sources[:trafficReportTopSources]
+    }
+    report.TopSources = sources
+
+    report.Report = fmt.Sprintf("Traffic %s to %s: %d requests, peak request rate %.2f/s, peak transfer %d MB/s, top sources %v",
+        from.Format(time.RFC3339), to.Format(time.RFC3339), report.TotalRequests, report.PeakRequestRate, report.PeakTransferMBps, report.TopSources)
+    return report
+}
+
 // RecordProcessState updates the ledger with the current state of a process.
 func (l *EnvironmentSystemCoreLedger) RecordProcessState(processID string, status string, timeout time.Duration) error {
+    now := time.Now()
+    if l.ProcessStates == nil {
+        l.ProcessStates = make(map[string]ProcessState)
+    }
     l.ProcessStates[processID] = ProcessState{
         ProcessID:  processID,
         Status:     status,
         Timeout:    timeout,
-        LastUpdate: time.Now(),
+        LastUpdate: now,
+        Elapsed:    processRunningTime(l.ProcessStates[processID], now),
     }
     return nil
 }
 
+const (
+    ProcessStatusRunning    = "running"    // Process is executing and accruing running time
+    ProcessStatusPaused     = "paused"     // Process is suspended and does not accrue running time
+    ProcessStatusTerminated = "terminated" // Process was stopped and cannot be resumed
+)
+
+// PauseProcess suspends a running process. Time spent paused does not count
+// towards the process's timeout.
+func (l *EnvironmentSystemCoreLedger) PauseProcess(processID string, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    state, exists := l.ProcessStates[processID]
+    if !exists {
+        return fmt.Errorf("process %s not found", processID)
+    }
+    if state.Status != ProcessStatusRunning {
+        return fmt.Errorf("process %s cannot be paused from status %s", processID, state.Status)
+    }
+
+    state.Elapsed = processRunningTime(state, now)
+    state.Status = ProcessStatusPaused
+    state.LastUpdate = now
+    l.ProcessStates[processID] = state
+    return nil
+}
+
+// ResumeProcess restarts a paused process. Terminated processes cannot be resumed.
+func (l *EnvironmentSystemCoreLedger) ResumeProcess(processID string, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    state, exists := l.ProcessStates[processID]
+    if !exists {
+        return fmt.Errorf("process %s not found", processID)
+    }
+    if state.Status == ProcessStatusTerminated {
+        return fmt.Errorf("process %s has been terminated and cannot be resumed", processID)
+    }
+    if state.Status != ProcessStatusPaused {
+        return fmt.Errorf("process %s is not paused", processID)
+    }
+
+    state.Status = ProcessStatusRunning
+    state.LastUpdate = now
+    l.ProcessStates[processID] = state
+    return nil
+}
+
+// EnforceProcessTimeouts terminates every running process whose running time
+// has exceeded its Timeout and returns their IDs in sorted order. A zero
+// Timeout means the process never times out.
+func (l *EnvironmentSystemCoreLedger) EnforceProcessTimeouts(now time.Time) []string {
+    l.Lock()
+    defer l.Unlock()
+
+    var terminated []string
+    for processID, state := range l.ProcessStates {
+        if state.Status != ProcessStatusRunning || state.Timeout <= 0 {
+            continue
+        }
+        elapsed := processRunningTime(state, now)
+        if elapsed <= state.Timeout {
+            continue
+        }
+        state.Elapsed = elapsed
+        state.Status = ProcessStatusTerminated
+        state.LastUpdate = now
+        l.ProcessStates[processID] = state
+        terminated = append(terminated, processID)
+        log.Printf("[WARNING] Process %s terminated after running %v (timeout %v)", processID, elapsed, state.Timeout)
+    }
+    sort.Strings(terminated)
+    return terminated
+}
+
+// processRunningTime returns the total running time of a process as of now.
+func processRunningTime(state ProcessState, now time.Time) time.Duration {
+    if state.Status != ProcessStatusRunning || now.Before(state.LastUpdate) {
+        return state.Elapsed
+    }
+    return state.Elapsed + now.Sub(state.LastUpdate)
+}
+
 // RecordResourceAllocation logs a new resource allocation in the ledger.
 func (l *EnvironmentSystemCoreLedger) RecordResourceAllocation(resourceType string, resourceID string, quantity int) error {
     l.ResourceAllocations = append(l.ResourceAllocations, ResourceAllocation{
@@ -1605,6 +2449,96 @@ func (l *EnvironmentSystemCoreLedger) RecordDiagnosticEvent(eventType string) er
     return nil
 }
 
+// RecordDiagnostic records a diagnostic event raised within a context.
+func (l *EnvironmentSystemCoreLedger) RecordDiagnostic(contextID, eventType string, now time.Time) {
+    l.Lock()
+    defer l.Unlock()
+
+    event := DiagnosticEvent{
+        EventID:   generateUniqueID(),
+        ContextID: contextID,
+        EventType: eventType,
+        Timestamp: now,
+    }
+    l.DiagnosticEvents = append(l.DiagnosticEvents, event)
+    l.DiagnosticsLogs = append(l.DiagnosticsLogs, ContextDiagnosticsLog{
+        ContextID:   contextID,
+        Timestamp:   now,
+        Diagnostics: eventType,
+    })
+    log.Printf("Diagnostic event %s recorded for context %s", eventType, contextID)
+}
+
+// GenerateDiagnosticReport assembles the resource usage, concurrency, variables
+// and diagnostics recorded for a context into a report, which is also stored
+// in ContextReports.
+func (l *EnvironmentSystemCoreLedger) GenerateDiagnosticReport(contextID string) (*ContextReport, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    report := ContextReport{
+        ContextID:     contextID,
+        ResourceUsage: make(map[string]int),
+        Variables:     make(map[string]interface{}),
+    }
+    found := false
+
+    if res, exists := l.PriorityResources[contextID]; exists {
+        report.ResourceUsage[res.ResourceType] = res.Amount
+        found = true
+    }
+    if mem, exists := l.ContextMemoryLimits[contextID]; exists {
+        report.ResourceUsage["memory"] = mem.MemoryLimit
+        found = true
+    }
+    if capacity, exists := l.ExecutionCapacities[contextID]; exists {
+        report.ResourceUsage["execution_capacity"] = capacity.Capacity
+        found = true
+    }
+    if conc, exists := l.ContextConcurrencies[contextID]; exists {
+        report.Concurrency = conc.ConcurrencyLevel
+        found = true
+    }
+    if cp, exists := l.ContextCheckpoints[contextID]; exists {
+        report.CheckpointTime = cp.SavedAt
+        found = true
+    }
+    if vars, exists := l.ContextVariables[contextID]; exists {
+        for k, v := range vars.Variables {
+            report.Variables[k] = v
+        }
+        found = true
+    }
+    for _, entry := range l.DiagnosticsLogs {
+        if entry.ContextID == contextID {
+            report.Diagnostics = append(report.Diagnostics, fmt.Sprintf("%s: %s", entry.Timestamp.Format(time.RFC3339), entry.Diagnostics))
+            found = true
+        }
+    }
+
+    if !found {
+        return nil, fmt.Errorf("no diagnostic data found for contextID: %s", contextID)
+    }
+
+    if l.ContextReports == nil {
+        l.ContextReports = make(map[string]ContextReport)
+    }
+    l.ContextReports[contextID] = report
+    return &report, nil
+}
+
+// SystemDiagnosticSummary returns the number of recorded diagnostic events by event type.
+func (l *EnvironmentSystemCoreLedger) SystemDiagnosticSummary() map[string]int {
+    l.Lock()
+    defer l.Unlock()
+
+    summary := make(map[string]int)
+    for _, event := range l.DiagnosticEvents {
+        summary[event.EventType]++
+    }
+    return summary
+}
+
 // Add a panic handler event to the ledger.
 func (l *EnvironmentSystemCoreLedger) RecordHandlerEvent(eventType string) error {
     event := PanicHandler{
@@ -1617,6 +2551,78 @@ func (l *EnvironmentSystemCoreLedger) RecordHandlerEvent(eventType string) error
     return nil
 }
 
+// RegisterPanicHandler registers fn under id to be invoked with the recovered
+// value whenever a goroutine started with SafeGo panics. Registering an
+// existing id replaces its handler.
+func (l *EnvironmentSystemCoreLedger) RegisterPanicHandler(id string, fn func(recovered interface{})) {
+    l.Lock()
+    defer l.Unlock()
+
+    if l.panicHandlers == nil {
+        l.panicHandlers = make(map[string]func(recovered interface{}))
+    }
+    l.panicHandlers[id] = fn
+    l.PanicHandlerConfigs = append(l.PanicHandlerConfigs, PanicHandler{
+        HandlerID: id,
+        Status:    "Registered",
+        Timestamp: time.Now(),
+    })
+}
+
+// SafeGo runs fn in a new goroutine. A panic in fn is recovered, recorded as
+// a PanicStatus and passed to every registered panic handler in ID order.
+func (l *EnvironmentSystemCoreLedger) SafeGo(fn func()) {
+    go func() {
+        defer func() {
+            if recovered := recover(); recovered != nil {
+                l.handlePanic(recovered)
+            }
+        }()
+        fn()
+    }()
+}
+
+// PanicReport returns the panics recovered by SafeGo, oldest first.
+func (l *EnvironmentSystemCoreLedger) PanicReport() []PanicStatus {
+    l.Lock()
+    defer l.Unlock()
+
+    return append([]PanicStatus(nil), l.PanicStatuses...)
+}
+
+// handlePanic records a recovered panic and invokes the registered handlers
+// outside the lock. A handler that itself panics is logged and skipped.
+func (l *EnvironmentSystemCoreLedger) handlePanic(recovered interface{}) {
+    l.Lock()
+    l.PanicStatuses = append(l.PanicStatuses, PanicStatus{
+        Status:    true,
+        Message:   fmt.Sprintf("%v", recovered),
+        Timestamp: time.Now(),
+    })
+    ids := make([]string, 0, len(l.panicHandlers))
+    for id := range l.panicHandlers {
+        ids = append(ids, id)
+    }
+    sort.Strings(ids)
+    handlers := make([]func(recovered interface{}), len(ids))
+    for i, id := range ids {
+        handlers[i] = l.panicHandlers[id]
+    }
+    l.Unlock()
+
+    log.Printf("[ERROR] Recovered panic: %v", recovered)
+    for i, handler := range handlers {
+        func() {
+            defer func() {
+                if r := recover(); r != nil {
+                    log.Printf("[ERROR] Panic handler %s panicked: %v", ids[i], r)
+                }
+            }()
+            handler(recovered)
+        }()
+    }
+}
+
 // Record an emergency override event in the ledger.
 func (l *EnvironmentSystemCoreLedger) RecordOverrideEvent(eventType, reason string) error {
     event := EmergencyOverride{
@@ -1696,6 +2702,127 @@ func (l *EnvironmentSystemCoreLedger) RecordHandoverEvent(action, contextID, tar
     return nil
 }
 
+const (
+    HandoverStatusInitiated    = "Initiated"
+    HandoverStatusAcknowledged = "Acknowledged"
+    HandoverStatusCompleted    = "Completed"
+    HandoverStatusTimedOut     = "TimedOut"
+
+    handoverAckTimeout = 30 * time.Second // Time the target node has to acknowledge a handover
+)
+
+// InitiateHandover starts handing contextID over to targetNodeID. The target
+// must acknowledge within handoverAckTimeout before the handover can complete.
+func (l *EnvironmentSystemCoreLedger) InitiateHandover(contextID, targetNodeID string, now time.Time) (*HandoverEvent, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    if contextID == "" || targetNodeID == "" {
+        return nil, fmt.Errorf("context ID and target node ID cannot be empty")
+    }
+    if l.activeHandovers == nil {
+        l.activeHandovers = make(map[string]*HandoverEvent)
+    }
+    if existing, ok := l.activeHandovers[contextID]; ok {
+        return nil, fmt.Errorf("context %s already has a handover to node %s in progress", contextID, existing.TargetNodeID)
+    }
+
+    handover := &HandoverEvent{
+        ContextID:    contextID,
+        TargetNodeID: targetNodeID,
+        Status:       HandoverStatusInitiated,
+        Timestamp:    now,
+    }
+    l.activeHandovers[contextID] = handover
+    l.HandoverEvents = append(l.HandoverEvents, *handover)
+    log.Printf("[INFO] Handover of context %s to node %s initiated", contextID, targetNodeID)
+
+    result := *handover
+    return &result, nil
+}
+
+// AckHandover records that targetNodeID has accepted the handover of
+// contextID. An acknowledgement after the timeout fails the handover.
+func (l *EnvironmentSystemCoreLedger) AckHandover(contextID, targetNodeID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    handover, ok := l.activeHandovers[contextID]
+    if !ok {
+        return fmt.Errorf("no handover in progress for context %s", contextID)
+    }
+    if handover.TargetNodeID != targetNodeID {
+        return fmt.Errorf("node %s is not the target of the handover for context %s", targetNodeID, contextID)
+    }
+    if handover.Status != HandoverStatusInitiated {
+        return fmt.Errorf("handover for context %s is already %s", contextID, handover.Status)
+    }
+
+    now := time.Now()
+    if now.Sub(handover.Timestamp) > handoverAckTimeout {
+        l.timeOutHandover(handover, now)
+        return fmt.Errorf("handover for context %s timed out before acknowledgement", contextID)
+    }
+
+    handover.Status = HandoverStatusAcknowledged
+    l.recordHandoverStatus(handover, now)
+    return nil
+}
+
+// CompleteHandover releases contextID from the source node. It fails unless
+// the target node has acknowledged the handover.
+func (l *EnvironmentSystemCoreLedger) CompleteHandover(contextID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    handover, ok := l.activeHandovers[contextID]
+    if !ok {
+        return fmt.Errorf("no handover in progress for context %s", contextID)
+    }
+    if handover.Status != HandoverStatusAcknowledged {
+        return fmt.Errorf("handover for context %s has not been acknowledged by node %s", contextID, handover.TargetNodeID)
+    }
+
+    handover.Status = HandoverStatusCompleted
+    delete(l.activeHandovers, contextID)
+    l.recordHandoverStatus(handover, time.Now())
+    return nil
+}
+
+// ExpireHandovers times out every handover still unacknowledged
+// handoverAckTimeout after it was initiated, leaving the context with its
+// source node. It returns the affected context IDs.
+func (l *EnvironmentSystemCoreLedger) ExpireHandovers(now time.Time) []string {
+    l.Lock()
+    defer l.Unlock()
+
+    var expired []string
+    for contextID, handover := range l.activeHandovers {
+        if handover.Status == HandoverStatusInitiated && now.Sub(handover.Timestamp) > handoverAckTimeout {
+            l.timeOutHandover(handover, now)
+            expired = append(expired, contextID)
+        }
+    }
+    sort.Strings(expired)
+    return expired
+}
+
+// timeOutHandover marks a handover timed out and drops it. The caller must hold the lock.
+func (l *EnvironmentSystemCoreLedger) timeOutHandover(handover *HandoverEvent, now time.Time) {
+    handover.Status = HandoverStatusTimedOut
+    delete(l.activeHandovers, handover.ContextID)
+    l.recordHandoverStatus(handover, now)
+}
+
+// recordHandoverStatus appends the handover's current status to HandoverEvents.
+// The caller must hold the lock.
+func (l *EnvironmentSystemCoreLedger) recordHandoverStatus(handover *HandoverEvent, now time.Time) {
+    event := *handover
+    event.Timestamp = now
+    l.HandoverEvents = append(l.HandoverEvents, event)
+    log.Printf("[INFO] Handover of context %s to node %s %s", handover.ContextID, handover.TargetNodeID, strings.ToLower(handover.Status))
+}
+
 // recordLoadBalancingEvent logs load balancing activities in the ledger.
 func (l *EnvironmentSystemCoreLedger) RecordLoadBalancingEvent(action string) error {
     log.Printf("Load balancing event recorded with action: %s.", action)
@@ -1788,6 +2915,77 @@ func (l *EnvironmentSystemCoreLedger) RemoveSystemHook(eventID string) error {
     return nil
 }
 
+// RegisterHook registers fn to run when eventID fires and returns the handler
+// ID used to unregister it. Hooks with a higher priority run first; hooks of
+// equal priority run in registration order.
+func (l *EnvironmentSystemCoreLedger) RegisterHook(eventID string, priority int, fn func(map[string]interface{}) error) (string, error) {
+    if eventID == "" {
+        return "", fmt.Errorf("event ID cannot be empty")
+    }
+    if fn == nil {
+        return "", fmt.Errorf("hook function cannot be nil")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    hook := registeredHook{
+        HookRecord: HookRecord{
+            EventID:   eventID,
+            HandlerID: generateUniqueID(),
+            Priority:  priority,
+            Timestamp: time.Now(),
+        },
+        fn: fn,
+    }
+    if l.hookHandlers == nil {
+        l.hookHandlers = make(map[string][]registeredHook)
+    }
+
+    hooks := l.hookHandlers[eventID]
+    i := sort.Search(len(hooks), func(i int) bool { return hooks[i].Priority < priority })
+    hooks = append(hooks, registeredHook{})
+    copy(hooks[i+1:], hooks[i:])
+    hooks[i] = hook
+    l.hookHandlers[eventID] = hooks
+
+    return hook.HandlerID, nil
+}
+
+// FireHooks invokes every hook registered for eventID in priority order,
+// passing ctx to each. A failing hook does not stop later hooks; its error is
+// collected and returned alongside the number of hooks invoked.
+func (l *EnvironmentSystemCoreLedger) FireHooks(eventID string, ctx map[string]interface{}) (int, []error) {
+    l.Lock()
+    hooks := append([]registeredHook(nil), l.hookHandlers[eventID]...)
+    l.Unlock()
+
+    var errs []error
+    for _, hook := range hooks {
+        if err := hook.fn(ctx); err != nil {
+            errs = append(errs, fmt.Errorf("hook %s for event %s failed: %w", hook.HandlerID, eventID, err))
+        }
+    }
+    return len(hooks), errs
+}
+
+// UnregisterHook removes a registered hook so it is no longer invoked.
+func (l *EnvironmentSystemCoreLedger) UnregisterHook(eventID string, handlerID string) {
+    l.Lock()
+    defer l.Unlock()
+
+    hooks := l.hookHandlers[eventID]
+    for i, hook := range hooks {
+        if hook.HandlerID == handlerID {
+            l.hookHandlers[eventID] = append(hooks[:i:i], hooks[i+1:]...)
+            break
+        }
+    }
+    if len(l.hookHandlers[eventID]) == 0 {
+        delete(l.hookHandlers, eventID)
+    }
+}
+
 
 
 
@@ -1844,3 +3042,359 @@ func (l *EnvironmentSystemCoreLedger) AddProfileRecord(profileID string) error {
     l.SystemProfiles = append(l.SystemProfiles, profile)
     return nil
 }
+
+// RegisterProfile adds a system profile that can later be activated.
+func (l *EnvironmentSystemCoreLedger) RegisterProfile(profile *SystemProfileManager) error {
+    if profile == nil || profile.ProfileID == "" {
+        return fmt.Errorf("profile must have an ID")
+    }
+
+    l.Lock()
+    defer l.Unlock()
+
+    if l.Profiles == nil {
+        l.Profiles = make(map[string]*SystemProfileManager)
+    }
+    if _, exists := l.Profiles[profile.ProfileID]; exists {
+        return fmt.Errorf("profile %s already registered", profile.ProfileID)
+    }
+    if profile.CreatedAt.IsZero() {
+        profile.CreatedAt = time.Now()
+    }
+    profile.IsActive = false
+    l.Profiles[profile.ProfileID] = profile
+    return nil
+}
+
+// ActivateProfile makes profileID the active system profile. The previously
+// active profile is deactivated and the configuration keys it applied are
+// removed, then the new profile's Configuration is applied to the environment
+// variables and its DefaultPermissions become the active permissions. Both
+// profiles receive an audit entry.
+func (l *EnvironmentSystemCoreLedger) ActivateProfile(profileID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    profile, exists := l.Profiles[profileID]
+    if !exists {
+        return fmt.Errorf("profile %s not found", profileID)
+    }
+    if profile.IsActive && l.ActiveProfileID == profileID {
+        return nil
+    }
+
+    now := time.Now()
+    if previous, ok := l.Profiles[l.ActiveProfileID]; ok {
+        previous.IsActive = false
+        previous.UpdatedAt = now
+        previous.AuditLogs = append(previous.AuditLogs, fmt.Sprintf("%s: deactivated in favour of profile %s", now.Format(time.RFC3339), profileID))
+        for key := range previous.Configuration {
+            delete(l.EnvironmentVars, key)
+        }
+    }
+
+    if l.EnvironmentVars == nil {
+        l.EnvironmentVars = make(map[string]string)
+    }
+    for key, value := range profile.Configuration {
+        l.EnvironmentVars[key] = value
+    }
+    l.ActivePermissions = append([]string(nil), profile.DefaultPermissions...)
+
+    profile.IsActive = true
+    profile.UpdatedAt = now
+    profile.AuditLogs = append(profile.AuditLogs, fmt.Sprintf("%s: activated, replacing profile %q", now.Format(time.RFC3339), l.ActiveProfileID))
+    l.ActiveProfileID = profileID
+    l.SystemProfiles = append(l.SystemProfiles, ProfileRecord{
+        ProfileID: profileID,
+        Timestamp: now,
+    })
+
+    log.Printf("[INFO] System profile %s activated", profileID)
+    return nil
+}
+
+// ActiveProfile returns the currently active system profile.
+func (l *EnvironmentSystemCoreLedger) ActiveProfile() (*SystemProfileManager, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    profile, exists := l.Profiles[l.ActiveProfileID]
+    if !exists {
+        return nil, fmt.Errorf("no system profile is active")
+    }
+    return profile, nil
+}
+
+const (
+    syncMaxRetriesKey     = "maxRetries" // RetryPolicy key for the number of retries after a failed sync
+    defaultSyncMaxRetries = 3            // Retries used when RetryPolicy does not set maxRetries
+)
+
+// SyncNode synchronizes a node by calling syncFn, retrying up to the
+// RetryPolicy's maxRetries with SyncIntervalDuration between attempts. The
+// node is held in PendingSyncNodes while it syncs and moved to NodesInSync on
+// success. Every failed attempt is written to SyncFailureLogs; a node that
+// exhausts its retries stays pending.
+func (s *SystemStateSynchronizer) SyncNode(nodeID string, syncFn func(string) error) error {
+    if !s.IsEnabled {
+        return fmt.Errorf("state synchronization is disabled")
+    }
+    if nodeID == "" || syncFn == nil {
+        return fmt.Errorf("node ID and sync function are required")
+    }
+
+    maxRetries, set := s.RetryPolicy[syncMaxRetriesKey]
+    if !set || maxRetries < 0 {
+        maxRetries = defaultSyncMaxRetries
+    }
+
+    s.NodesInSync = removeNodeID(s.NodesInSync, nodeID)
+    if !containsNodeID(s.PendingSyncNodes, nodeID) {
+        s.PendingSyncNodes = append(s.PendingSyncNodes, nodeID)
+    }
+
+    var err error
+    for attempt := 0; attempt <= maxRetries; attempt++ {
+        if attempt > 0 && s.SyncIntervalDuration > 0 {
+            time.Sleep(s.SyncIntervalDuration)
+        }
+        if err = syncFn(nodeID); err == nil {
+            s.PendingSyncNodes = removeNodeID(s.PendingSyncNodes, nodeID)
+            s.NodesInSync = append(s.NodesInSync, nodeID)
+            s.LastSyncTimestamp = time.Now()
+            return nil
+        }
+        s.SyncFailureLogs = append(s.SyncFailureLogs, fmt.Sprintf("%s: sync of node %s failed on attempt %d/%d: %v", time.Now().Format(time.RFC3339), nodeID, attempt+1, maxRetries+1, err))
+    }
+
+    s.SyncFailureLogs = append(s.SyncFailureLogs, fmt.Sprintf("%s: node %s exhausted %d retries and remains pending", time.Now().Format(time.RFC3339), nodeID, maxRetries))
+    return fmt.Errorf("failed to sync node %s after %d attempts: %w", nodeID, maxRetries+1, err)
+}
+
+// containsNodeID reports whether nodeID is in nodes.
+func containsNodeID(nodes []string, nodeID string) bool {
+    for _, node := range nodes {
+        if node == nodeID {
+            return true
+        }
+    }
+    return false
+}
+
+// removeNodeID returns nodes without nodeID.
+func removeNodeID(nodes []string, nodeID string) []string {
+    kept := nodes[:0]
+    for _, node := range nodes {
+        if node != nodeID {
+            kept = append(kept, node)
+        }
+    }
+    return kept
+}
+
+// CreateRecoveryPoint snapshots the CurrentState of the tracked system state
+// into its RecoveryPoint, replacing any earlier snapshot. It returns a copy of
+// the state as it stands after the snapshot.
+func (l *EnvironmentSystemCoreLedger) CreateRecoveryPoint(stateID string) (*SystemState, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    state := &l.SystemState
+    if state.StateID != stateID {
+        return nil, fmt.Errorf("system state %s not found", stateID)
+    }
+
+    state.RecoveryPoint = copyStateMap(state.CurrentState)
+    state.LastUpdated = time.Now()
+    log.Printf("[INFO] Recovery point created for system state %s (%d keys)", stateID, len(state.RecoveryPoint))
+    return cloneSystemState(state), nil
+}
+
+// RevertToRecoveryPoint restores CurrentState from the recovery point and
+// marks the state stable. If no recovery point exists the failure is appended
+// to the state's ErrorLogs and an error is returned.
+func (l *EnvironmentSystemCoreLedger) RevertToRecoveryPoint(stateID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    state := &l.SystemState
+    if state.StateID != stateID {
+        return fmt.Errorf("system state %s not found", stateID)
+    }
+
+    now := time.Now()
+    if state.RecoveryPoint == nil {
+        err := fmt.Errorf("no recovery point available for system state %s", stateID)
+        state.ErrorLogs = append(state.ErrorLogs, fmt.Sprintf("%s: revert failed: %v", now.Format(time.RFC3339), err))
+        log.Printf("[ERROR] %v", err)
+        return err
+    }
+
+    state.CurrentState = copyStateMap(state.RecoveryPoint)
+    state.IsStable = true
+    state.LastUpdated = now
+    log.Printf("[SUCCESS] System state %s reverted to recovery point", stateID)
+    return nil
+}
+
+// copyStateMap returns an independent copy of a state key-value map.
+func copyStateMap(src map[string]string) map[string]string {
+    dst := make(map[string]string, len(src))
+    for k, v := range src {
+        dst[k] = v
+    }
+    return dst
+}
+
+// cloneSystemState returns a deep copy of state so callers cannot mutate the
+// ledger's maps and slices.
+func cloneSystemState(state *SystemState) *SystemState {
+    clone := *state
+    clone.CurrentState = copyStateMap(state.CurrentState)
+    clone.RecoveryPoint = copyStateMap(state.RecoveryPoint)
+    clone.AssociatedTasks = append([]string(nil), state.AssociatedTasks...)
+    clone.ErrorLogs = append([]string(nil), state.ErrorLogs...)
+    return &clone
+}
+
+const (
+    schedulerOrderKey      = "order"    // SchedulerPolicies key selecting the run order
+    schedulerOrderPriority = "priority" // Run the highest priority operation first
+    schedulerOrderFIFO     = "fifo"     // Run operations in the order they were enqueued
+)
+
+// EnqueueOperation adds op to the queue with the given priority and returns
+// its operation ID. Higher priorities run first under the priority policy.
+func (m *OperationManager) EnqueueOperation(op func() error, priority int) (string, error) {
+    if op == nil {
+        return "", fmt.Errorf("operation cannot be nil")
+    }
+
+    opID := generateUniqueID()
+    m.queue = append(m.queue, queuedOperation{ID: opID, Priority: priority, Run: op})
+    m.QueueStatus = append(m.QueueStatus, opID)
+    return opID, nil
+}
+
+// RunNext runs the next queued operation according to the "order" scheduler
+// policy: "priority" picks the highest priority (earliest enqueued on ties),
+// anything else runs FIFO. The operation is listed in ActiveOperations while
+// it runs and then moved to CompletedOperations, or to ErrorLogs if it fails.
+// ran is false when the queue is empty.
+func (m *OperationManager) RunNext() (bool, error) {
+    if len(m.queue) == 0 {
+        return false, nil
+    }
+
+    next := 0
+    if strings.EqualFold(m.SchedulerPolicies[schedulerOrderKey], schedulerOrderPriority) {
+        for i, op := range m.queue {
+            if op.Priority > m.queue[next].Priority {
+                next = i
+            }
+        }
+    }
+    op := m.queue[next]
+    m.queue = append(m.queue[:next], m.queue[next+1:]...)
+    m.QueueStatus = removeFromSlice(m.QueueStatus, op.ID)
+
+    m.ActiveOperations = append(m.ActiveOperations, op.ID)
+    err := op.Run()
+    m.ActiveOperations = removeFromSlice(m.ActiveOperations, op.ID)
+    m.LastOperationTime = time.Now()
+
+    if err != nil {
+        m.ErrorLogs = append(m.ErrorLogs, fmt.Sprintf("%s: operation %s failed: %v", m.LastOperationTime.Format(time.RFC3339), op.ID, err))
+        return true, fmt.Errorf("operation %s failed: %w", op.ID, err)
+    }
+    m.CompletedOperations = append(m.CompletedOperations, op.ID)
+    return true, nil
+}
+
+// ApplyOverride applies the override rule ruleID. Rules are written as
+// "setting=value"; the setting names what the override targets.
+func (m *OverrideManager) ApplyOverride(ruleID string) error {
+    rule, exists := m.OverrideRules[ruleID]
+    if !exists {
+        return fmt.Errorf("override rule %s not found", ruleID)
+    }
+    if m.isApplied(ruleID) {
+        return fmt.Errorf("override rule %s is already applied", ruleID)
+    }
+
+    now := time.Now()
+    if m.AppliedAt == nil {
+        m.AppliedAt = make(map[string]time.Time)
+    }
+    m.AppliedOverrides = append(m.AppliedOverrides, ruleID)
+    m.AppliedAt[ruleID] = now
+    m.LastOverrideTime = now
+    m.IsOverrideActive = true
+    m.AuditTrail = append(m.AuditTrail, fmt.Sprintf("%s: applied override %s (%s)", now.Format(time.RFC3339), ruleID, rule))
+    return nil
+}
+
+// ResolveConflicts finds applied overrides that target the same setting and
+// keeps only the most recently applied one for each, reverting the rest. It
+// returns the IDs of the reverted overrides.
+func (m *OverrideManager) ResolveConflicts() ([]string, error) {
+    latest := make(map[string]string) // setting -> rule ID to keep
+    for _, ruleID := range m.AppliedOverrides {
+        rule, exists := m.OverrideRules[ruleID]
+        if !exists {
+            return nil, fmt.Errorf("applied override %s has no rule", ruleID)
+        }
+        setting := overrideSetting(rule)
+        kept, seen := latest[setting]
+        if !seen || m.AppliedAt[ruleID].After(m.AppliedAt[kept]) {
+            latest[setting] = ruleID
+        }
+    }
+
+    var reverted []string
+    for _, ruleID := range append([]string(nil), m.AppliedOverrides...) {
+        setting := overrideSetting(m.OverrideRules[ruleID])
+        if latest[setting] == ruleID {
+            continue
+        }
+        m.removeOverride(ruleID, fmt.Sprintf("superseded by override %s on %s", latest[setting], setting))
+        reverted = append(reverted, ruleID)
+    }
+    return reverted, nil
+}
+
+// RevertOverride removes an applied override.
+func (m *OverrideManager) RevertOverride(ruleID string) error {
+    if !m.isApplied(ruleID) {
+        return fmt.Errorf("override rule %s is not applied", ruleID)
+    }
+    m.removeOverride(ruleID, "reverted")
+    return nil
+}
+
+// isApplied reports whether ruleID is currently applied.
+func (m *OverrideManager) isApplied(ruleID string) bool {
+    for _, id := range m.AppliedOverrides {
+        if id == ruleID {
+            return true
+        }
+    }
+    return false
+}
+
+// removeOverride unapplies ruleID and records why in the audit trail.
+func (m *OverrideManager) removeOverride(ruleID, reason string) {
+    m.AppliedOverrides = removeFromSlice(m.AppliedOverrides, ruleID)
+    delete(m.AppliedAt, ruleID)
+    m.IsOverrideActive = len(m.AppliedOverrides) > 0
+    m.AuditTrail = append(m.AuditTrail, fmt.Sprintf("%s: override %s %s", time.Now().Format(time.RFC3339), ruleID, reason))
+}
+
+// overrideSetting returns the setting an override rule targets.
+func overrideSetting(rule string) string {
+    if i := strings.Index(rule, "="); i >= 0 {
+        return strings.TrimSpace(rule[:i])
+    }
+    return strings.TrimSpace(rule)
+}
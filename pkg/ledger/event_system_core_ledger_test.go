@@ -0,0 +1,978 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComputeNetworkMetricsFromActivityStream(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+
+	// Activity before the metrics window must not count.
+	l.LogChainActivity(ActivityTypeTransaction, "stale tx", now.Add(-networkMetricsWindow-time.Second))
+	for i := 0; i < 60; i++ {
+		l.LogChainActivity(ActivityTypeTransaction, "tx", now.Add(-time.Duration(i)*time.Second))
+	}
+	for i := 0; i < 4; i++ {
+		l.LogChainActivity(ActivityTypeBlockProduced, "block", now.Add(-time.Duration(i)*20*time.Second))
+	}
+
+	metrics := l.ComputeNetworkMetrics(now)
+	if want := 60 / networkMetricsWindow.Seconds(); metrics.TransactionRate != want {
+		t.Fatalf("TransactionRate = %v, want %v", metrics.TransactionRate, want)
+	}
+	if metrics.BlockLatency != 20*time.Second {
+		t.Fatalf("BlockLatency = %v, want 20s", metrics.BlockLatency)
+	}
+	if l.NetworkMetrics.BlockLatency != metrics.BlockLatency {
+		t.Fatal("expected the computed metrics to be stored on the ledger")
+	}
+}
+
+func TestActivityBreakdownCountsByType(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l.LogChainActivity(ActivityTypeTransaction, "tx-1", base)
+	l.LogChainActivity(ActivityTypeTransaction, "tx-2", base.Add(time.Minute))
+	l.LogChainActivity(ActivityTypeBlockProduced, "block-1", base.Add(2*time.Minute))
+	l.LogChainActivity(ActivityTypeTransaction, "tx-3", base.Add(time.Hour))
+
+	breakdown := l.ActivityBreakdown(base, base.Add(2*time.Minute))
+	if breakdown[ActivityTypeTransaction] != 2 || breakdown[ActivityTypeBlockProduced] != 1 || len(breakdown) != 2 {
+		t.Fatalf("ActivityBreakdown = %v, want 2 transactions and 1 block", breakdown)
+	}
+}
+
+func TestSubscribeToBlockReceivesBlockEvents(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	events, unsubscribe := l.SubscribeToBlock("block-7")
+	defer unsubscribe()
+
+	l.EmitBlockEvent(BlockEvent{BlockID: "block-7", EventType: BlockEventConfirmed})
+	l.EmitBlockEvent(BlockEvent{BlockID: "block-8", EventType: BlockEventConfirmed})
+	l.EmitBlockEvent(BlockEvent{BlockID: "block-7", EventType: BlockEventFinalized})
+
+	for _, want := range []string{BlockEventConfirmed, BlockEventFinalized} {
+		select {
+		case event := <-events:
+			if event.BlockID != "block-7" || event.EventType != want {
+				t.Fatalf("received %s %s, want block-7 %s", event.BlockID, event.EventType, want)
+			}
+		default:
+			t.Fatalf("expected a %s event for block-7", want)
+		}
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("received unexpected event %+v", event)
+	default:
+	}
+	if len(l.BlockEvents) != 3 {
+		t.Fatalf("logged %d block events, want 3", len(l.BlockEvents))
+	}
+}
+
+func TestUnsubscribeFromBlockStopsDelivery(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	events, unsubscribe := l.SubscribeToBlock("block-7")
+
+	unsubscribe()
+	unsubscribe()
+	l.EmitBlockEvent(BlockEvent{BlockID: "block-7", EventType: BlockEventOrphaned})
+
+	if event, open := <-events; open {
+		t.Fatalf("received %+v after unsubscribing", event)
+	}
+	if _, subscribed := l.BlockSubscribers["block-7"]; subscribed {
+		t.Fatal("expected the block's subscriber set to be removed")
+	}
+}
+
+func TestSetParameterRecordsValidUpdate(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+
+	if err := l.SetParameter("BlockDifficulty", "8", "governance"); err != nil {
+		t.Fatalf("SetParameter: %v", err)
+	}
+	if value, err := l.GetParameter("BlockDifficulty"); err != nil || value != "8" {
+		t.Fatalf("GetParameter = %q, %v; want 8, nil", value, err)
+	}
+	if len(l.ParameterChangeLog) != 1 {
+		t.Fatalf("change log has %d entries, want 1", len(l.ParameterChangeLog))
+	}
+	change := l.ParameterChangeLog[0]
+	if change.OldValue != "4" || change.NewValue != "8" || change.ChangedBy != "governance" {
+		t.Fatalf("change = %+v, want 4 -> 8 by governance", change)
+	}
+}
+
+func TestSetParameterRejectsOutOfRangeValue(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+
+	for _, value := range []string{"1.5", "-0.1", "lots"} {
+		if err := l.SetParameter("RewardRate", value, "governance"); err == nil {
+			t.Errorf("SetParameter(RewardRate, %s) succeeded, want rejection", value)
+		}
+	}
+	if err := l.SetParameter("BlockDifficulty", "2.5", "governance"); err == nil {
+		t.Error("expected a non-integer difficulty to be rejected")
+	}
+	if value, _ := l.GetParameter("RewardRate"); value != "0.05" {
+		t.Fatalf("RewardRate = %s, want the default 0.05 after rejected updates", value)
+	}
+	if len(l.ParameterChangeLog) != 0 {
+		t.Fatal("rejected updates must not be logged")
+	}
+}
+
+func TestUnknownParameterIsRejected(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+
+	if err := l.SetParameter("MaxValidators", "10", "governance"); err == nil {
+		t.Fatal("expected setting an unknown parameter to fail")
+	}
+	if _, err := l.GetParameter("MaxValidators"); err == nil {
+		t.Fatal("expected reading an unknown parameter to fail")
+	}
+}
+
+func requestTimes(start time.Time, n int) []time.Time {
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = start.Add(time.Duration(i) * time.Second)
+	}
+	return times
+}
+
+func TestGenerateTrafficReportSummarisesWindow(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	l.RecordTrafficData(TrafficData{SourceIP: "10.0.0.1", PeakRequestRate: 4, Timestamps: requestTimes(from, 5)})
+	l.RecordTrafficData(TrafficData{SourceIP: "10.0.0.2", PeakRequestRate: 9, Timestamps: requestTimes(from.Add(time.Minute), 12)})
+	l.RecordTrafficData(TrafficData{SourceIP: "10.0.0.1", PeakRequestRate: 2, Timestamps: requestTimes(from.Add(2*time.Minute), 3)})
+	// Traffic outside the window must not affect the report.
+	l.RecordTrafficData(TrafficData{SourceIP: "10.0.0.3", PeakRequestRate: 50, Timestamps: requestTimes(to.Add(time.Minute), 40)})
+	l.RecordTransferMetrics(DataTransferMetrics{PeakRateMBps: 80, Timestamp: from.Add(10 * time.Minute)})
+	l.RecordTransferMetrics(DataTransferMetrics{PeakRateMBps: 300, Timestamp: to.Add(time.Hour)})
+
+	report, err := l.GenerateTrafficReport(from, to)
+	if err != nil {
+		t.Fatalf("GenerateTrafficReport: %v", err)
+	}
+	if report.TotalRequests != 20 {
+		t.Errorf("TotalRequests = %d, want 20", report.TotalRequests)
+	}
+	if report.PeakRequestRate != 9 || report.PeakTransferMBps != 80 {
+		t.Errorf("peaks = %v req/s, %d MB/s; want 9, 80", report.PeakRequestRate, report.PeakTransferMBps)
+	}
+	if len(report.TopSources) != 2 || report.TopSources[0] != "10.0.0.2" || report.TopSources[1] != "10.0.0.1" {
+		t.Errorf("TopSources = %v, want [10.0.0.2 10.0.0.1]", report.TopSources)
+	}
+	if len(l.TrafficReports) != 1 {
+		t.Fatalf("stored %d reports, want 1", len(l.TrafficReports))
+	}
+}
+
+func TestGenerateTrafficReportRejectsInvertedWindow(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+
+	if _, err := l.GenerateTrafficReport(now, now.Add(-time.Minute)); err == nil {
+		t.Fatal("expected a window ending before it starts to be rejected")
+	}
+}
+
+func TestApplyEnvironmentAppliesValidConfig(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	cfg := EnvironmentConfig{
+		Variables: map[string]string{
+			"NETWORK":   "testnet",
+			"MAX_PEERS": "50",
+			"DEBUG":     "false",
+			"NODE_NAME": "node-01",
+		},
+		Constraints: map[string]string{
+			"NETWORK":   "oneof:mainnet|testnet",
+			"DEBUG":     "bool",
+			"NODE_NAME": "regex:^node-[0-9]+$",
+		},
+		Limits: map[string]int{"MAX_PEERS": 100},
+	}
+
+	if valid, violations := ValidateEnvironment(cfg); !valid {
+		t.Fatalf("ValidateEnvironment violations = %v, want none", violations)
+	}
+	if err := l.ApplyEnvironment(cfg); err != nil {
+		t.Fatalf("ApplyEnvironment: %v", err)
+	}
+	if l.EnvironmentVars["NETWORK"] != "testnet" || l.EnvironmentVars["MAX_PEERS"] != "50" {
+		t.Fatalf("EnvironmentVars = %v, want the config applied", l.EnvironmentVars)
+	}
+}
+
+func TestApplyEnvironmentRejectsViolatingConfig(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	cfg := EnvironmentConfig{
+		Variables: map[string]string{
+			"NETWORK":   "devnet",
+			"MAX_PEERS": "500",
+		},
+		Constraints: map[string]string{
+			"DATA_DIR": "required",
+			"NETWORK":  "oneof:mainnet|testnet",
+		},
+		Limits: map[string]int{"MAX_PEERS": 100},
+	}
+
+	valid, violations := ValidateEnvironment(cfg)
+	want := []string{
+		"DATA_DIR: required variable is missing",
+		`NETWORK: value "devnet" is not one of mainnet|testnet`,
+		"MAX_PEERS: value 500 exceeds limit 100",
+	}
+	if valid || len(violations) != len(want) {
+		t.Fatalf("ValidateEnvironment = %v, %v; want %v", valid, violations, want)
+	}
+	for i := range want {
+		if violations[i] != want[i] {
+			t.Errorf("violation %d = %q, want %q", i, violations[i], want[i])
+		}
+	}
+
+	if err := l.ApplyEnvironment(cfg); err == nil {
+		t.Fatal("expected ApplyEnvironment to reject the config")
+	}
+	if len(l.EnvironmentVars) != 0 {
+		t.Fatalf("EnvironmentVars = %v, want nothing applied", l.EnvironmentVars)
+	}
+}
+
+func newProcessTestLedger(start time.Time, timeout time.Duration) *EnvironmentSystemCoreLedger {
+	return &EnvironmentSystemCoreLedger{
+		ProcessStates: map[string]ProcessState{
+			"proc-1": {ProcessID: "proc-1", Status: ProcessStatusRunning, Timeout: timeout, LastUpdate: start},
+		},
+	}
+}
+
+func TestPauseResumeProcessTransitions(t *testing.T) {
+	start := time.Now()
+	l := newProcessTestLedger(start, time.Hour)
+
+	if err := l.ResumeProcess("proc-1", start); err == nil {
+		t.Fatal("expected resuming a running process to fail")
+	}
+	if err := l.PauseProcess("proc-1", start.Add(10*time.Minute)); err != nil {
+		t.Fatalf("PauseProcess: %v", err)
+	}
+	if err := l.PauseProcess("proc-1", start.Add(11*time.Minute)); err == nil {
+		t.Fatal("expected pausing a paused process to fail")
+	}
+	if err := l.ResumeProcess("proc-1", start.Add(50*time.Minute)); err != nil {
+		t.Fatalf("ResumeProcess: %v", err)
+	}
+
+	state := l.ProcessStates["proc-1"]
+	if state.Status != ProcessStatusRunning || state.Elapsed != 10*time.Minute {
+		t.Fatalf("state = %s with %v elapsed, want running with 10m elapsed", state.Status, state.Elapsed)
+	}
+}
+
+func TestEnforceProcessTimeoutsTerminatesOverdueProcess(t *testing.T) {
+	start := time.Now()
+	l := newProcessTestLedger(start, 30*time.Minute)
+	l.ProcessStates["proc-2"] = ProcessState{ProcessID: "proc-2", Status: ProcessStatusRunning, Timeout: 2 * time.Hour, LastUpdate: start}
+
+	// Time spent paused does not count towards the timeout.
+	if err := l.PauseProcess("proc-1", start.Add(20*time.Minute)); err != nil {
+		t.Fatalf("PauseProcess: %v", err)
+	}
+	if err := l.ResumeProcess("proc-1", start.Add(time.Hour)); err != nil {
+		t.Fatalf("ResumeProcess: %v", err)
+	}
+	if terminated := l.EnforceProcessTimeouts(start.Add(65 * time.Minute)); len(terminated) != 0 {
+		t.Fatalf("terminated %v after 25m of running time, want none", terminated)
+	}
+
+	terminated := l.EnforceProcessTimeouts(start.Add(75 * time.Minute))
+	if len(terminated) != 1 || terminated[0] != "proc-1" {
+		t.Fatalf("EnforceProcessTimeouts = %v, want [proc-1]", terminated)
+	}
+	if err := l.ResumeProcess("proc-1", start.Add(80*time.Minute)); err == nil {
+		t.Fatal("expected resuming a terminated process to fail")
+	}
+}
+
+func TestFireHooksRunsInPriorityOrder(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	var order []string
+	hook := func(name string, err error) func(map[string]interface{}) error {
+		return func(ctx map[string]interface{}) error {
+			order = append(order, name)
+			return err
+		}
+	}
+
+	for _, h := range []struct {
+		name     string
+		priority int
+		err      error
+	}{
+		{"low", 1, nil},
+		{"high", 10, fmt.Errorf("audit sink unavailable")},
+		{"medium-a", 5, nil},
+		{"medium-b", 5, nil},
+	} {
+		if _, err := l.RegisterHook("block.finalized", h.priority, hook(h.name, h.err)); err != nil {
+			t.Fatalf("RegisterHook(%s): %v", h.name, err)
+		}
+	}
+
+	fired, errs := l.FireHooks("block.finalized", map[string]interface{}{"height": 42})
+	if fired != 4 || len(errs) != 1 {
+		t.Fatalf("FireHooks = %d, %v; want 4 hooks fired and 1 error", fired, errs)
+	}
+	want := []string{"high", "medium-a", "medium-b", "low"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("invocation order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestUnregisterHookStopsInvocation(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	calls := 0
+	handlerID, err := l.RegisterHook("block.finalized", 1, func(map[string]interface{}) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterHook: %v", err)
+	}
+
+	l.UnregisterHook("block.finalized", handlerID)
+
+	if fired, _ := l.FireHooks("block.finalized", nil); fired != 0 || calls != 0 {
+		t.Fatalf("FireHooks fired %d hooks (%d calls) after unregistering, want 0", fired, calls)
+	}
+}
+
+func TestActivateProfileSwitchesActiveProfile(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	profiles := []*SystemProfileManager{
+		{
+			ProfileID:          "standard",
+			Configuration:      map[string]string{"LOG_LEVEL": "info", "CACHE_MB": "256"},
+			DefaultPermissions: []string{"read", "write"},
+		},
+		{
+			ProfileID:          "maintenance",
+			Configuration:      map[string]string{"LOG_LEVEL": "debug"},
+			DefaultPermissions: []string{"read"},
+		},
+	}
+	for _, profile := range profiles {
+		if err := l.RegisterProfile(profile); err != nil {
+			t.Fatalf("RegisterProfile(%s): %v", profile.ProfileID, err)
+		}
+	}
+	if _, err := l.ActiveProfile(); err == nil {
+		t.Fatal("expected no active profile before activation")
+	}
+
+	if err := l.ActivateProfile("standard"); err != nil {
+		t.Fatalf("ActivateProfile(standard): %v", err)
+	}
+	if err := l.ActivateProfile("maintenance"); err != nil {
+		t.Fatalf("ActivateProfile(maintenance): %v", err)
+	}
+
+	active, err := l.ActiveProfile()
+	if err != nil || active.ProfileID != "maintenance" {
+		t.Fatalf("ActiveProfile = %v, %v; want maintenance", active, err)
+	}
+	if profiles[0].IsActive || !profiles[1].IsActive {
+		t.Fatal("expected only the maintenance profile to be active")
+	}
+	if len(profiles[0].AuditLogs) != 2 || len(profiles[1].AuditLogs) != 1 {
+		t.Fatalf("audit entries = %d, %d; want 2 for standard and 1 for maintenance", len(profiles[0].AuditLogs), len(profiles[1].AuditLogs))
+	}
+
+	if l.EnvironmentVars["LOG_LEVEL"] != "debug" {
+		t.Fatalf("LOG_LEVEL = %q, want debug", l.EnvironmentVars["LOG_LEVEL"])
+	}
+	if _, kept := l.EnvironmentVars["CACHE_MB"]; kept {
+		t.Fatal("expected the previous profile's configuration to be removed")
+	}
+	if len(l.ActivePermissions) != 1 || l.ActivePermissions[0] != "read" {
+		t.Fatalf("ActivePermissions = %v, want [read]", l.ActivePermissions)
+	}
+}
+
+func TestSyncNodeSucceedsAfterRetries(t *testing.T) {
+	s := &SystemStateSynchronizer{
+		IsEnabled:            true,
+		RetryPolicy:          map[string]int{syncMaxRetriesKey: 3},
+		SyncIntervalDuration: time.Millisecond,
+	}
+	attempts := 0
+	syncFn := func(nodeID string) error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("peer %s busy", nodeID)
+		}
+		return nil
+	}
+
+	if err := s.SyncNode("node-1", syncFn); err != nil {
+		t.Fatalf("SyncNode: %v", err)
+	}
+	if attempts != 3 || len(s.SyncFailureLogs) != 2 {
+		t.Fatalf("attempts = %d with %d failures logged, want 3 and 2", attempts, len(s.SyncFailureLogs))
+	}
+	if len(s.PendingSyncNodes) != 0 || len(s.NodesInSync) != 1 || s.NodesInSync[0] != "node-1" {
+		t.Fatalf("pending = %v, in sync = %v; want node-1 in sync", s.PendingSyncNodes, s.NodesInSync)
+	}
+}
+
+func TestSyncNodeExhaustsRetries(t *testing.T) {
+	s := &SystemStateSynchronizer{
+		IsEnabled:   true,
+		RetryPolicy: map[string]int{syncMaxRetriesKey: 2},
+		NodesInSync: []string{"node-1"},
+	}
+	attempts := 0
+	err := s.SyncNode("node-1", func(string) error {
+		attempts++
+		return fmt.Errorf("connection refused")
+	})
+
+	if err == nil {
+		t.Fatal("expected SyncNode to fail after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3 (one try plus two retries)", attempts)
+	}
+	if len(s.SyncFailureLogs) != 4 {
+		t.Fatalf("failure logs = %d, want one per attempt plus the exhaustion entry", len(s.SyncFailureLogs))
+	}
+	if len(s.NodesInSync) != 0 || len(s.PendingSyncNodes) != 1 {
+		t.Fatalf("pending = %v, in sync = %v; want node-1 left pending", s.PendingSyncNodes, s.NodesInSync)
+	}
+}
+
+func TestRevertToRecoveryPointRestoresSnapshot(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	l.SystemState = SystemState{
+		StateID:      "state-1",
+		CurrentState: map[string]string{"mode": "normal", "height": "100"},
+	}
+
+	snapshot, err := l.CreateRecoveryPoint("state-1")
+	if err != nil {
+		t.Fatalf("CreateRecoveryPoint: %v", err)
+	}
+	if snapshot.RecoveryPoint["height"] != "100" {
+		t.Fatalf("RecoveryPoint = %v, want the current state", snapshot.RecoveryPoint)
+	}
+
+	l.SystemState.CurrentState["mode"] = "degraded"
+	l.SystemState.CurrentState["fork"] = "detected"
+	l.SystemState.IsStable = false
+	if snapshot.CurrentState["mode"] != "normal" {
+		t.Fatal("the returned snapshot must not alias the ledger's state")
+	}
+
+	if err := l.RevertToRecoveryPoint("state-1"); err != nil {
+		t.Fatalf("RevertToRecoveryPoint: %v", err)
+	}
+	state := l.SystemState
+	if state.CurrentState["mode"] != "normal" || len(state.CurrentState) != 2 || !state.IsStable {
+		t.Fatalf("state after revert = %v (stable %v), want the snapshot and stable", state.CurrentState, state.IsStable)
+	}
+}
+
+func TestRevertWithoutRecoveryPointLogsError(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	l.SystemState = SystemState{StateID: "state-1", CurrentState: map[string]string{"mode": "normal"}}
+
+	if err := l.RevertToRecoveryPoint("state-1"); err == nil {
+		t.Fatal("expected a revert without a recovery point to fail")
+	}
+	if len(l.SystemState.ErrorLogs) != 1 {
+		t.Fatalf("ErrorLogs = %v, want the failed revert recorded", l.SystemState.ErrorLogs)
+	}
+	if err := l.RevertToRecoveryPoint("state-unknown"); err == nil {
+		t.Fatal("expected an unknown state ID to be rejected")
+	}
+}
+
+func enqueueNamed(t *testing.T, m *OperationManager, order *[]string, name string, priority int) string {
+	t.Helper()
+	opID, err := m.EnqueueOperation(func() error {
+		*order = append(*order, name)
+		return nil
+	}, priority)
+	if err != nil {
+		t.Fatalf("EnqueueOperation(%s): %v", name, err)
+	}
+	return opID
+}
+
+func TestRunNextSchedulesByPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   []string
+	}{
+		{schedulerOrderPriority, []string{"urgent", "normal-1", "normal-2", "background"}},
+		{schedulerOrderFIFO, []string{"background", "normal-1", "urgent", "normal-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			m := &OperationManager{SchedulerPolicies: map[string]string{schedulerOrderKey: tt.policy}}
+			var order []string
+			enqueueNamed(t, m, &order, "background", 1)
+			enqueueNamed(t, m, &order, "normal-1", 5)
+			enqueueNamed(t, m, &order, "urgent", 10)
+			enqueueNamed(t, m, &order, "normal-2", 5)
+
+			for {
+				ran, err := m.RunNext()
+				if err != nil {
+					t.Fatalf("RunNext: %v", err)
+				}
+				if !ran {
+					break
+				}
+			}
+			for i := range tt.want {
+				if order[i] != tt.want[i] {
+					t.Fatalf("run order = %v, want %v", order, tt.want)
+				}
+			}
+			if len(m.CompletedOperations) != 4 || len(m.QueueStatus) != 0 || len(m.ActiveOperations) != 0 {
+				t.Fatalf("completed %d, queued %d, active %d; want 4, 0, 0",
+					len(m.CompletedOperations), len(m.QueueStatus), len(m.ActiveOperations))
+			}
+		})
+	}
+}
+
+func TestRunNextLogsFailingOperation(t *testing.T) {
+	m := &OperationManager{}
+	opID, err := m.EnqueueOperation(func() error { return fmt.Errorf("disk full") }, 1)
+	if err != nil {
+		t.Fatalf("EnqueueOperation: %v", err)
+	}
+
+	ran, err := m.RunNext()
+	if !ran || err == nil {
+		t.Fatalf("RunNext = %v, %v; want ran with an error", ran, err)
+	}
+	if len(m.ErrorLogs) != 1 || !strings.Contains(m.ErrorLogs[0], opID) {
+		t.Fatalf("ErrorLogs = %v, want the failed operation %s", m.ErrorLogs, opID)
+	}
+	if len(m.CompletedOperations) != 0 {
+		t.Fatal("a failed operation must not be marked completed")
+	}
+}
+
+func newOverrideTestManager() *OverrideManager {
+	return &OverrideManager{
+		OverrideRules: map[string]string{
+			"gas-low":  "BlockGasLimit=4000000",
+			"gas-high": "BlockGasLimit=12000000",
+			"fees-off": "FeesEnabled=false",
+		},
+	}
+}
+
+func TestApplyOverrideRecordsAudit(t *testing.T) {
+	m := newOverrideTestManager()
+
+	if err := m.ApplyOverride("gas-low"); err != nil {
+		t.Fatalf("ApplyOverride: %v", err)
+	}
+	if !m.IsOverrideActive || len(m.AppliedOverrides) != 1 || len(m.AuditTrail) != 1 {
+		t.Fatalf("manager = %+v, want gas-low applied and audited", m)
+	}
+	if err := m.ApplyOverride("gas-low"); err == nil {
+		t.Fatal("expected applying the same override twice to fail")
+	}
+	if err := m.ApplyOverride("unknown"); err == nil {
+		t.Fatal("expected an unknown override to be rejected")
+	}
+}
+
+func TestResolveConflictsKeepsMostRecentOverride(t *testing.T) {
+	m := newOverrideTestManager()
+	for _, ruleID := range []string{"gas-low", "fees-off", "gas-high"} {
+		if err := m.ApplyOverride(ruleID); err != nil {
+			t.Fatalf("ApplyOverride(%s): %v", ruleID, err)
+		}
+	}
+	base := time.Now()
+	m.AppliedAt["gas-low"] = base
+	m.AppliedAt["fees-off"] = base.Add(time.Second)
+	m.AppliedAt["gas-high"] = base.Add(2 * time.Second)
+
+	reverted, err := m.ResolveConflicts()
+	if err != nil {
+		t.Fatalf("ResolveConflicts: %v", err)
+	}
+	if len(reverted) != 1 || reverted[0] != "gas-low" {
+		t.Fatalf("reverted = %v, want [gas-low]", reverted)
+	}
+	if len(m.AppliedOverrides) != 2 || m.isApplied("gas-low") {
+		t.Fatalf("AppliedOverrides = %v, want fees-off and gas-high", m.AppliedOverrides)
+	}
+}
+
+func TestRevertOverrideDeactivates(t *testing.T) {
+	m := newOverrideTestManager()
+	if err := m.ApplyOverride("fees-off"); err != nil {
+		t.Fatalf("ApplyOverride: %v", err)
+	}
+
+	if err := m.RevertOverride("fees-off"); err != nil {
+		t.Fatalf("RevertOverride: %v", err)
+	}
+	if m.IsOverrideActive || len(m.AppliedOverrides) != 0 || len(m.AuditTrail) != 2 {
+		t.Fatalf("manager = %+v, want no active overrides and both actions audited", m)
+	}
+	if err := m.RevertOverride("fees-off"); err == nil {
+		t.Fatal("expected reverting an unapplied override to fail")
+	}
+}
+
+func TestReplayFinalityLogRebuildsState(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+
+	if _, err := l.RecordFinalization("block-1", now); err != nil {
+		t.Fatalf("RecordFinalization(block-1): %v", err)
+	}
+	if err := l.SetFinalityPending("block-2"); err != nil {
+		t.Fatalf("SetFinalityPending(block-2): %v", err)
+	}
+	if err := l.SetFinalityPending("block-3"); err != nil {
+		t.Fatalf("SetFinalityPending(block-3): %v", err)
+	}
+	if err := l.ResolveFinalityPending("block-3"); err != nil {
+		t.Fatalf("ResolveFinalityPending(block-3): %v", err)
+	}
+	if _, err := l.RecordFinalization("block-1", now); err == nil {
+		t.Fatal("expected finalizing block-1 twice to fail")
+	}
+
+	// Simulate a restart: only the finality log survives.
+	restarted := &EnvironmentSystemCoreLedger{FinalityLog: l.FinalityLog}
+	finalized, pending, err := restarted.ReplayFinalityLog()
+	if err != nil {
+		t.Fatalf("ReplayFinalityLog: %v", err)
+	}
+	if finalized != 2 || pending != 1 {
+		t.Fatalf("ReplayFinalityLog = %d finalized, %d pending; want 2, 1", finalized, pending)
+	}
+	if record := restarted.FinalizationRecords["block-2"]; !record.IsPending || record.IsResolved {
+		t.Fatalf("block-2 = %+v, want pending", record)
+	}
+	if record := restarted.FinalizationRecords["block-1"]; !record.IsResolved || !record.StartTime.Equal(now) {
+		t.Fatalf("block-1 = %+v, want finalized with its original start time", record)
+	}
+}
+
+func TestReplayFinalityLogRejectsUnknownEvent(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{
+		FinalityLog: []FinalityLogEntry{{EventID: "block-1", Description: "orphaned", Timestamp: time.Now()}},
+	}
+
+	if _, _, err := l.ReplayFinalityLog(); err == nil {
+		t.Fatal("expected an unknown finality event to fail the replay")
+	}
+}
+
+func TestCheckTrapTimeoutsEmitsEventForExpiredTrap(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+	if err := l.SetTrapTimeout("trap-1", time.Minute, now); err != nil {
+		t.Fatalf("SetTrapTimeout: %v", err)
+	}
+
+	if events := l.CheckTrapTimeouts(now.Add(30 * time.Second)); len(events) != 0 {
+		t.Fatalf("CheckTrapTimeouts before expiry = %v, want none", events)
+	}
+
+	events := l.CheckTrapTimeouts(now.Add(2 * time.Minute))
+	if len(events) != 1 || events[0].ErrorCode != TrapTimeoutErrorCode || !strings.Contains(events[0].Message, "trap-1") {
+		t.Fatalf("CheckTrapTimeouts = %+v, want one timeout event for trap-1", events)
+	}
+	if len(l.TrapEvents) != 1 {
+		t.Fatalf("TrapEvents = %d, want the event recorded", len(l.TrapEvents))
+	}
+	if again := l.CheckTrapTimeouts(now.Add(3 * time.Minute)); len(again) != 0 {
+		t.Fatal("an expired trap must only fire once")
+	}
+}
+
+func TestClearedTrapDoesNotTimeOut(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+	if err := l.SetTrapTimeout("trap-1", time.Minute, now); err != nil {
+		t.Fatalf("SetTrapTimeout: %v", err)
+	}
+
+	l.ClearTrapTimeout("trap-1")
+
+	if events := l.CheckTrapTimeouts(now.Add(time.Hour)); len(events) != 0 {
+		t.Fatalf("CheckTrapTimeouts = %+v, want none for a cleared trap", events)
+	}
+}
+
+func TestGroupExceptionsClustersSimilarDescriptions(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+
+	l.RecordException("Timeout after 30s contacting peer 10", now.Add(-time.Minute))
+	l.RecordException("timeout after 45s contacting   peer 22", now.Add(-2*time.Minute))
+	l.RecordException("Nonce 0x1f already used", now.Add(-3*time.Minute))
+	l.RecordException("Timeout after 12s contacting peer 3", now.Add(-2*time.Hour))
+
+	groups := l.GroupExceptions(time.Hour, now)
+	if len(groups) != 2 {
+		t.Fatalf("GroupExceptions returned %d groups, want 2: %v", len(groups), groups)
+	}
+	if got := groups["timeout after #s contacting peer #"]; len(got) != 2 {
+		t.Fatalf("timeout group has %d entries, want 2 within the window", len(got))
+	}
+	if got := groups["nonce # already used"]; len(got) != 1 {
+		t.Fatalf("nonce group has %d entries, want 1", len(got))
+	}
+}
+
+func TestTopExceptionsRanksByFrequency(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		l.RecordException(fmt.Sprintf("disk write failed on shard %d", i), now)
+	}
+	for i := 0; i < 2; i++ {
+		l.RecordException(fmt.Sprintf("peer %d disconnected", i), now)
+	}
+	l.RecordException("invalid signature", now)
+
+	top := l.TopExceptions(2)
+	if len(top) != 2 {
+		t.Fatalf("TopExceptions returned %d patterns, want 2", len(top))
+	}
+	if top[0].Pattern != "disk write failed on shard #" || top[0].Count != 3 {
+		t.Errorf("top[0] = %+v, want the disk write pattern with 3", top[0])
+	}
+	if top[1].Pattern != "peer # disconnected" || top[1].Count != 2 {
+		t.Errorf("top[1] = %+v, want the peer pattern with 2", top[1])
+	}
+}
+
+func TestSafeGoRecoversPanicAndInvokesHandlers(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	received := make(chan interface{}, 2)
+	l.RegisterPanicHandler("alerting", func(recovered interface{}) {
+		received <- recovered
+	})
+	l.RegisterPanicHandler("broken", func(recovered interface{}) {
+		panic("handler failure")
+	})
+
+	l.SafeGo(func() {
+		panic("validator crashed")
+	})
+
+	select {
+	case value := <-received:
+		if value != "validator crashed" {
+			t.Fatalf("handler received %v, want the panic value", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("panic handler was not invoked")
+	}
+
+	report := l.PanicReport()
+	if len(report) != 1 || !report[0].Status || report[0].Message != "validator crashed" {
+		t.Fatalf("PanicReport = %+v, want the recovered panic", report)
+	}
+}
+
+func TestSafeGoWithoutPanicReportsNothing(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	done := make(chan struct{})
+
+	l.SafeGo(func() { close(done) })
+	<-done
+
+	if report := l.PanicReport(); len(report) != 0 {
+		t.Fatalf("PanicReport = %+v, want none", report)
+	}
+}
+
+func TestCoordinatedHandoverCompletes(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+
+	if _, err := l.InitiateHandover("ctx-1", "node-b", time.Now()); err != nil {
+		t.Fatalf("InitiateHandover: %v", err)
+	}
+	if err := l.CompleteHandover("ctx-1"); err == nil {
+		t.Fatal("expected completion before acknowledgement to fail")
+	}
+	if err := l.AckHandover("ctx-1", "node-c"); err == nil {
+		t.Fatal("expected an acknowledgement from another node to be rejected")
+	}
+	if err := l.AckHandover("ctx-1", "node-b"); err != nil {
+		t.Fatalf("AckHandover: %v", err)
+	}
+	if err := l.CompleteHandover("ctx-1"); err != nil {
+		t.Fatalf("CompleteHandover: %v", err)
+	}
+
+	var statuses []string
+	for _, event := range l.HandoverEvents {
+		statuses = append(statuses, event.Status)
+	}
+	want := []string{HandoverStatusInitiated, HandoverStatusAcknowledged, HandoverStatusCompleted}
+	if len(statuses) != len(want) {
+		t.Fatalf("handover events = %v, want %v", statuses, want)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("handover events = %v, want %v", statuses, want)
+		}
+	}
+}
+
+func TestUnacknowledgedHandoverTimesOut(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+
+	if _, err := l.InitiateHandover("ctx-1", "node-b", now); err != nil {
+		t.Fatalf("InitiateHandover: %v", err)
+	}
+	if expired := l.ExpireHandovers(now.Add(handoverAckTimeout / 2)); len(expired) != 0 {
+		t.Fatalf("ExpireHandovers before the timeout = %v, want none", expired)
+	}
+
+	expired := l.ExpireHandovers(now.Add(2 * handoverAckTimeout))
+	if len(expired) != 1 || expired[0] != "ctx-1" {
+		t.Fatalf("ExpireHandovers = %v, want [ctx-1]", expired)
+	}
+	if last := l.HandoverEvents[len(l.HandoverEvents)-1]; last.Status != HandoverStatusTimedOut {
+		t.Fatalf("last handover event = %s, want %s", last.Status, HandoverStatusTimedOut)
+	}
+	if err := l.AckHandover("ctx-1", "node-b"); err == nil {
+		t.Fatal("expected a late acknowledgement to fail")
+	}
+	if err := l.CompleteHandover("ctx-1"); err == nil {
+		t.Fatal("expected a timed-out handover not to complete")
+	}
+}
+
+func newEmergencyTestLedger() *EnvironmentSystemCoreLedger {
+	return &EnvironmentSystemCoreLedger{
+		NodeStatuses: map[string]NodeStatus{"node-a": {}, "node-b": {}},
+	}
+}
+
+func TestBroadcastEmergencyActivatesStatus(t *testing.T) {
+	l := newEmergencyTestLedger()
+
+	alert, err := l.BroadcastEmergency("halt block production", time.Now())
+	if err != nil {
+		t.Fatalf("BroadcastEmergency: %v", err)
+	}
+	if !alert.IsActive || !l.EmergencyStatus.Active {
+		t.Fatal("expected the emergency to be active after broadcast")
+	}
+	if rate := l.EmergencyAcknowledgmentRate(); rate != 0 {
+		t.Fatalf("EmergencyAcknowledgmentRate = %v, want 0", rate)
+	}
+	if _, err := (&EnvironmentSystemCoreLedger{}).BroadcastEmergency("halt", time.Now()); err == nil {
+		t.Fatal("expected a broadcast with no nodes to fail")
+	}
+}
+
+func TestAcknowledgeEmergencyClearsOnceAllNodesAck(t *testing.T) {
+	l := newEmergencyTestLedger()
+	if _, err := l.BroadcastEmergency("halt block production", time.Now()); err != nil {
+		t.Fatalf("BroadcastEmergency: %v", err)
+	}
+
+	if err := l.AcknowledgeEmergency("node-z"); err == nil {
+		t.Fatal("expected an acknowledgement from an unknown node to be rejected")
+	}
+	if err := l.AcknowledgeEmergency("node-a"); err != nil {
+		t.Fatalf("AcknowledgeEmergency(node-a): %v", err)
+	}
+	if rate := l.EmergencyAcknowledgmentRate(); rate != 0.5 || !l.EmergencyStatus.Active {
+		t.Fatalf("after one ack: rate %v, active %v; want 0.5 and still active", rate, l.EmergencyStatus.Active)
+	}
+
+	if err := l.AcknowledgeEmergency("node-b"); err != nil {
+		t.Fatalf("AcknowledgeEmergency(node-b): %v", err)
+	}
+	if l.EmergencyStatus.Active || l.EmergencyAlert.IsActive {
+		t.Fatal("expected the emergency to clear once every node acknowledged")
+	}
+	if rate := l.EmergencyAcknowledgmentRate(); rate != 1 {
+		t.Fatalf("EmergencyAcknowledgmentRate = %v, want 1", rate)
+	}
+}
+
+func TestGenerateDiagnosticReportAssemblesContextData(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{
+		ContextConcurrencies: map[string]ContextConcurrency{"ctx-1": {ContextID: "ctx-1", ConcurrencyLevel: 4}},
+		ContextVariables: map[string]ContextVariables{
+			"ctx-1": {ContextID: "ctx-1", Variables: map[string]interface{}{"mode": "fast"}},
+		},
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	l.RecordDiagnostic("ctx-1", "timeout", now)
+	l.RecordDiagnostic("ctx-2", "overflow", now)
+
+	report, err := l.GenerateDiagnosticReport("ctx-1")
+	if err != nil {
+		t.Fatalf("GenerateDiagnosticReport: %v", err)
+	}
+	if report.Concurrency != 4 {
+		t.Fatalf("Concurrency = %d, want 4", report.Concurrency)
+	}
+	if report.Variables["mode"] != "fast" {
+		t.Fatalf("Variables[mode] = %v, want fast", report.Variables["mode"])
+	}
+	if len(report.Diagnostics) != 1 || !strings.HasSuffix(report.Diagnostics[0], "timeout") {
+		t.Fatalf("Diagnostics = %v, want a single timeout entry", report.Diagnostics)
+	}
+	if _, ok := l.ContextReports["ctx-1"]; !ok {
+		t.Fatal("expected the report to be stored in ContextReports")
+	}
+	if _, err := l.GenerateDiagnosticReport("ctx-missing"); err == nil {
+		t.Fatal("expected an error for a context with no recorded data")
+	}
+}
+
+func TestSystemDiagnosticSummaryCountsByEventType(t *testing.T) {
+	l := &EnvironmentSystemCoreLedger{}
+	now := time.Now()
+	l.RecordDiagnostic("ctx-1", "timeout", now)
+	l.RecordDiagnostic("ctx-2", "timeout", now)
+	l.RecordDiagnostic("", "overflow", now)
+
+	summary := l.SystemDiagnosticSummary()
+	if summary["timeout"] != 2 || summary["overflow"] != 1 {
+		t.Fatalf("SystemDiagnosticSummary = %v, want timeout:2 overflow:1", summary)
+	}
+}
@@ -1,6 +1,7 @@
 package ledger
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -155,15 +156,105 @@ func (l *GovernanceLedger) RecordGovernanceTransactionFee(blockIndex uint64, fee
 	// Check if the governance record for the block exists
 	if _, exists := l.GovernanceRecords[blockKey]; !exists {
 		l.GovernanceRecords[blockKey] = GovernanceRecord{
-			TransactionFees: make(map[uint64]float64),
+			TransactionFees:   make(map[uint64]float64),
+			TransactionCounts: make(map[uint64]int),
 		}
 	}
+	record := l.GovernanceRecords[blockKey]
+	if record.TransactionCounts == nil {
+		record.TransactionCounts = make(map[uint64]int)
+		l.GovernanceRecords[blockKey] = record
+	}
 
 	// Add the fee to the transaction fees for the block
-	l.GovernanceRecords[blockKey].TransactionFees[blockIndex] += fee
+	record.TransactionFees[blockIndex] += fee
+	record.TransactionCounts[blockIndex]++
 
 	fmt.Printf("Transaction fee of %.2f recorded for block %d\n", fee, blockIndex)
 	return nil
 }
 
+const (
+	// feePerByte is the base fee charged per byte of encoded transaction.
+	feePerByte = 0.00001
+	// minTransactionFee is the lowest fee EstimateFee returns.
+	minTransactionFee = 0.001
+	// defaultMempoolCapacity is the mempool depth treated as full when the
+	// transaction pool has no configured maximum.
+	defaultMempoolCapacity = 10000
+	// maxCongestionMultiplier caps the fee multiplier at a full mempool.
+	maxCongestionMultiplier = 5.0
+)
+
+// EstimateFee estimates the fee for tx from its encoded size and the current
+// mempool depth. The per-byte fee scales linearly from 1x with an empty
+// mempool to maxCongestionMultiplier when it is full.
+func (l *Ledger) EstimateFee(tx Transaction) (float64, error) {
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to size transaction: %w", err)
+	}
+
+	depth, capacity := l.mempoolDepth()
+	congestion := float64(depth) / float64(capacity)
+	if congestion > 1 {
+		congestion = 1
+	}
+	multiplier := 1 + congestion*(maxCongestionMultiplier-1)
 
+	fee := float64(len(encoded)) * feePerByte * multiplier
+	if fee < minTransactionFee {
+		fee = minTransactionFee
+	}
+	return fee, nil
+}
+
+// FeeHistory returns the average transaction fee of each of the last blocks
+// blocks, oldest first. Blocks with no recorded fees report zero.
+func (l *Ledger) FeeHistory(blocks int) []float64 {
+	l.BlockchainConsensusCoinLedger.Lock()
+	latest := l.BlockchainConsensusCoinLedger.BlockIndex
+	l.BlockchainConsensusCoinLedger.Unlock()
+
+	if blocks <= 0 || latest < 0 {
+		return nil
+	}
+	if blocks > latest+1 {
+		blocks = latest + 1
+	}
+
+	l.GovernanceLedger.Lock()
+	defer l.GovernanceLedger.Unlock()
+
+	history := make([]float64, 0, blocks)
+	for block := latest - blocks + 1; block <= latest; block++ {
+		record := l.GovernanceLedger.GovernanceRecords[fmt.Sprintf("block_%d", block)]
+		count := record.TransactionCounts[uint64(block)]
+		if count == 0 {
+			history = append(history, 0)
+			continue
+		}
+		history = append(history, record.TransactionFees[uint64(block)]/float64(count))
+	}
+	return history
+}
+
+// mempoolDepth returns the number of transactions awaiting inclusion and the
+// depth at which the mempool counts as full.
+func (l *Ledger) mempoolDepth() (depth, capacity int) {
+	l.BlockchainConsensusCoinLedger.Lock()
+	depth = len(l.BlockchainConsensusCoinLedger.PendingTransactions)
+	pool := l.BlockchainConsensusCoinLedger.TransactionPool
+	l.BlockchainConsensusCoinLedger.Unlock()
+
+	capacity = defaultMempoolCapacity
+	if pool != nil {
+		pool.mu.Lock()
+		depth += len(pool.transactions)
+		if pool.maxPoolSize > 0 {
+			capacity = pool.maxPoolSize
+		}
+		pool.mu.Unlock()
+	}
+	return depth, capacity
+}
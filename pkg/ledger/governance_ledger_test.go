@@ -0,0 +1,68 @@
+package ledger
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEstimateFeeScalesWithTransactionSize(t *testing.T) {
+	l := &Ledger{}
+	small := Transaction{TransactionID: "tx-small", EncryptedData: strings.Repeat("a", 1000)}
+	large := Transaction{TransactionID: "tx-large", EncryptedData: strings.Repeat("a", 10000)}
+
+	smallFee, err := l.EstimateFee(small)
+	if err != nil {
+		t.Fatalf("EstimateFee(small): %v", err)
+	}
+	largeFee, err := l.EstimateFee(large)
+	if err != nil {
+		t.Fatalf("EstimateFee(large): %v", err)
+	}
+	if largeFee <= smallFee {
+		t.Fatalf("large fee %v, small fee %v; want the larger transaction to cost more", largeFee, smallFee)
+	}
+	if fee, _ := l.EstimateFee(Transaction{}); fee != minTransactionFee {
+		t.Fatalf("EstimateFee(empty) = %v, want the minimum fee %v", fee, minTransactionFee)
+	}
+}
+
+func TestEstimateFeeScalesWithCongestion(t *testing.T) {
+	tx := Transaction{TransactionID: "tx-1", EncryptedData: strings.Repeat("a", 5000)}
+	l := &Ledger{}
+	l.BlockchainConsensusCoinLedger.TransactionPool = &TransactionPool{maxPoolSize: 10}
+
+	idle, err := l.EstimateFee(tx)
+	if err != nil {
+		t.Fatalf("EstimateFee: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		l.BlockchainConsensusCoinLedger.PendingTransactions = append(l.BlockchainConsensusCoinLedger.PendingTransactions, &Transaction{})
+	}
+	congested, err := l.EstimateFee(tx)
+	if err != nil {
+		t.Fatalf("EstimateFee: %v", err)
+	}
+
+	want := 1 + 0.5*(maxCongestionMultiplier-1)
+	if ratio := congested / idle; math.Abs(ratio-want) > 1e-9 {
+		t.Fatalf("congested/idle fee ratio = %v, want %v at a half-full mempool", ratio, want)
+	}
+}
+
+func TestFeeHistoryAveragesRecentBlocks(t *testing.T) {
+	l := &Ledger{}
+	l.GovernanceLedger.GovernanceRecords = make(map[string]GovernanceRecord)
+	l.BlockchainConsensusCoinLedger.BlockIndex = 2
+	l.GovernanceLedger.RecordGovernanceTransactionFee(0, 9)
+	l.GovernanceLedger.RecordGovernanceTransactionFee(2, 1)
+	l.GovernanceLedger.RecordGovernanceTransactionFee(2, 3)
+
+	history := l.FeeHistory(2)
+	if len(history) != 2 || history[0] != 0 || history[1] != 2 {
+		t.Fatalf("FeeHistory(2) = %v, want [0 2]", history)
+	}
+	if history := l.FeeHistory(10); len(history) != 3 || history[0] != 9 {
+		t.Fatalf("FeeHistory(10) = %v, want three blocks starting at 9", history)
+	}
+}
@@ -2,7 +2,11 @@ package ledger
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -109,6 +113,48 @@ func (l *HighAvailabilityLedger) MonitorSnapshotStatus() (SnapshotStatus, error)
     return l.SnapshotStatus, nil
 }
 
+// defaultMaxConcurrentSnapshots limits running snapshots when
+// SnapshotStatus.MaxConcurrent is unset.
+const defaultMaxConcurrentSnapshots = 2
+
+// BeginSnapshot registers a new snapshot as running and returns its ID. It is
+// rejected when the maximum number of concurrent snapshots is already running.
+func (l *HighAvailabilityLedger) BeginSnapshot() (snapshotID string, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    limit := l.SnapshotStatus.MaxConcurrent
+    if limit <= 0 {
+        limit = defaultMaxConcurrentSnapshots
+    }
+    if l.SnapshotStatus.ActiveSnapshots >= limit {
+        return "", fmt.Errorf("snapshot limit reached: %d snapshots already running", l.SnapshotStatus.ActiveSnapshots)
+    }
+
+    snapshotID = generateUniqueID()
+    if l.SnapshotStatus.InFlight == nil {
+        l.SnapshotStatus.InFlight = make(map[string]time.Time)
+    }
+    l.SnapshotStatus.InFlight[snapshotID] = time.Now()
+    l.SnapshotStatus.ActiveSnapshots++
+    return snapshotID, nil
+}
+
+// CompleteSnapshot marks a running snapshot as finished at now.
+func (l *HighAvailabilityLedger) CompleteSnapshot(snapshotID string, now time.Time) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if _, running := l.SnapshotStatus.InFlight[snapshotID]; !running {
+        return fmt.Errorf("snapshot %s is not running", snapshotID)
+    }
+    delete(l.SnapshotStatus.InFlight, snapshotID)
+    l.SnapshotStatus.ActiveSnapshots--
+    l.SnapshotStatus.LastSnapshotID = snapshotID
+    l.SnapshotStatus.LastSnapshotTime = now
+    return nil
+}
+
 // EnableDataMirroring activates data mirroring.
 func (l *HighAvailabilityLedger) EnableDataMirroring() error {
     l.DataMirroringEnabled = true
@@ -606,6 +652,37 @@ func (l *HighAvailabilityLedger) SetLogRetention(period int) error {
         return fmt.Errorf("retention period must be positive")
     }
     l.WriteAheadLogConfig.RetentionDays = period
+    l.LogRetentionConfig.RetentionPeriod = period
+    l.LogRetentionConfig.ConfiguredAt = time.Now()
+    return nil
+}
+
+// PlaceLegalHold exempts a log entry, or a whole log stream, from pruning.
+// id is an entry ID (EventID, EntryID or LogID) or the key a stream is stored
+// under (event ID, audit subject or service ID).
+func (l *HighAvailabilityLedger) PlaceLegalHold(id, reason string) error {
+    if id == "" {
+        return fmt.Errorf("legal hold ID cannot be empty")
+    }
+    l.Lock()
+    defer l.Unlock()
+
+    if l.LogRetentionConfig.LegalHolds == nil {
+        l.LogRetentionConfig.LegalHolds = make(map[string]string)
+    }
+    l.LogRetentionConfig.LegalHolds[id] = reason
+    return nil
+}
+
+// ReleaseLegalHold lifts a legal hold so the held logs age out normally.
+func (l *HighAvailabilityLedger) ReleaseLegalHold(id string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if _, held := l.LogRetentionConfig.LegalHolds[id]; !held {
+        return fmt.Errorf("no legal hold on %s", id)
+    }
+    delete(l.LogRetentionConfig.LegalHolds, id)
     return nil
 }
 
@@ -902,6 +979,116 @@ func (l *HighAvailabilityLedger) MonitorBackupStatus() (BackupStatus, error) {
     return latest, nil
 }
 
+// computeBlockchainBackupHash hashes the full contents of every block in a backup,
+// including its sub-blocks and their transactions.
+func computeBlockchainBackupHash(blocks []Block) (string, error) {
+    hasher := sha256.New()
+    encoder := json.NewEncoder(hasher)
+    for _, block := range blocks {
+        if err := encoder.Encode(block); err != nil {
+            return "", fmt.Errorf("failed to encode block %s: %v", block.BlockID, err)
+        }
+    }
+    return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// RecordBlockchainBackup records a backup of the given blocks taken by nodeID, stamping it
+// with the hash that VerifyBackup and LastValidBackup check it against.
+func (l *HighAvailabilityLedger) RecordBlockchainBackup(nodeID string, blocks []Block, now time.Time) (BlockchainBackup, error) {
+    if len(blocks) == 0 {
+        return BlockchainBackup{}, fmt.Errorf("backup must contain at least one block")
+    }
+
+    // Round-trip through JSON so the backup shares no sub-blocks or transactions with the chain
+    data, err := json.Marshal(blocks)
+    if err != nil {
+        return BlockchainBackup{}, fmt.Errorf("failed to encode backup blocks: %v", err)
+    }
+    var snapshot []Block
+    if err := json.Unmarshal(data, &snapshot); err != nil {
+        return BlockchainBackup{}, fmt.Errorf("failed to copy backup blocks: %v", err)
+    }
+    hash, err := computeBlockchainBackupHash(snapshot)
+    if err != nil {
+        return BlockchainBackup{}, err
+    }
+
+    backup := BlockchainBackup{
+        BackupID:   generateUniqueID(),
+        Timestamp:  now,
+        Blocks:     snapshot,
+        NodeID:     nodeID,
+        BackupSize: int64(len(data)),
+        BackupHash: hash,
+    }
+
+    l.Lock()
+    defer l.Unlock()
+    l.BackupLogs = append(l.BackupLogs, backup)
+    return backup, nil
+}
+
+// VerifyBackup recomputes the hash of a blockchain backup over its stored blocks and
+// reports whether it matches the recorded BackupHash.
+func (l *HighAvailabilityLedger) VerifyBackup(backupID string) (valid bool, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    for _, backup := range l.BackupLogs {
+        if backup.BackupID != backupID {
+            continue
+        }
+        if len(backup.Blocks) == 0 {
+            return false, fmt.Errorf("backup %s contains no blocks", backupID)
+        }
+        hash, err := computeBlockchainBackupHash(backup.Blocks)
+        if err != nil {
+            return false, err
+        }
+        return hash == backup.BackupHash, nil
+    }
+    return false, fmt.Errorf("backup %s not found", backupID)
+}
+
+// RecordBackupEvent records an event against a blockchain backup.
+func (l *HighAvailabilityLedger) RecordBackupEvent(backupID, desc string, now time.Time) {
+    l.Lock()
+    defer l.Unlock()
+
+    if l.BackupEvents == nil {
+        l.BackupEvents = make(map[string]BackupEvent)
+    }
+    l.BackupEvents[generateUniqueID()] = BackupEvent{
+        BackupID:    backupID,
+        Description: desc,
+        PerformedAt: now,
+    }
+}
+
+// LastValidBackup returns the most recent blockchain backup whose hash still verifies.
+func (l *HighAvailabilityLedger) LastValidBackup() (*BlockchainBackup, error) {
+    l.Lock()
+    defer l.Unlock()
+
+    var latest *BlockchainBackup
+    for i := range l.BackupLogs {
+        backup := l.BackupLogs[i]
+        if len(backup.Blocks) == 0 {
+            continue
+        }
+        if hash, err := computeBlockchainBackupHash(backup.Blocks); err != nil || hash != backup.BackupHash {
+            continue
+        }
+        if latest == nil || backup.Timestamp.After(latest.Timestamp) {
+            latest = &backup
+        }
+    }
+    if latest == nil {
+        return nil, fmt.Errorf("no valid backups available")
+    }
+    return latest, nil
+}
+
 // EnableSnapshot enables the snapshot feature.
 func (l *HighAvailabilityLedger) EnableSnapshot() error {
     l.SnapshotEnabled = true
@@ -991,31 +1178,137 @@ func (l *HighAvailabilityLedger) GetFailoverGroupPolicy() (string, error) {
 
 // AddFailoverGroupMember adds a member to a failover group.
 func (l *HighAvailabilityLedger) AddFailoverGroupMember(member, groupID string) error {
+    return l.AddGroupMember(groupID, member)
+}
+
+// RemoveFailoverGroupMember removes a member from a failover group.
+func (l *HighAvailabilityLedger) RemoveFailoverGroupMember(member, groupID string) error {
+    return l.RemoveGroupMember(groupID, member)
+}
+
+// defaultMemberStaleness is how old a member's metrics may be before it is
+// considered unhealthy when FailoverThreshold.MaxAllowedDowntime is unset.
+const defaultMemberStaleness = time.Minute
+
+// maxHealthyCPUUsage is the CPU usage percentage at which a member is no
+// longer eligible for promotion.
+const maxHealthyCPUUsage = 95.0
+
+// AddGroupMember adds nodeID to a failover group. The first member added
+// becomes the group's primary.
+func (l *HighAvailabilityLedger) AddGroupMember(groupID, nodeID string) error {
+    l.Lock()
+    defer l.Unlock()
+
     group, exists := l.FailoverGroups[groupID]
     if !exists {
         return fmt.Errorf("failover group %s does not exist", groupID)
     }
-    group.Members = append(group.Members, member)
+    for _, m := range group.Members {
+        if m == nodeID {
+            return fmt.Errorf("node %s is already a member of failover group %s", nodeID, groupID)
+        }
+    }
+    group.Members = append(group.Members, nodeID)
+    if group.Primary == "" {
+        group.Primary = nodeID
+    }
     group.LastUpdated = time.Now()
     l.FailoverGroups[groupID] = group
     return nil
 }
 
-// RemoveFailoverGroupMember removes a member from a failover group.
-func (l *HighAvailabilityLedger) RemoveFailoverGroupMember(member, groupID string) error {
+// RemoveGroupMember removes nodeID from a failover group. Removing the
+// primary leaves the group without one until PromoteWithinGroup is called.
+func (l *HighAvailabilityLedger) RemoveGroupMember(groupID, nodeID string) error {
+    l.Lock()
+    defer l.Unlock()
+
     group, exists := l.FailoverGroups[groupID]
     if !exists {
         return fmt.Errorf("failover group %s does not exist", groupID)
     }
     for i, m := range group.Members {
-        if m == member {
+        if m == nodeID {
             group.Members = append(group.Members[:i], group.Members[i+1:]...)
+            if group.Primary == nodeID {
+                group.Primary = ""
+            }
             group.LastUpdated = time.Now()
             l.FailoverGroups[groupID] = group
             return nil
         }
     }
-    return fmt.Errorf("member %s not found in failover group %s", member, groupID)
+    return fmt.Errorf("member %s not found in failover group %s", nodeID, groupID)
+}
+
+// PromoteWithinGroup replaces a failed primary with the healthiest remaining
+// member. The group policy chooses the measure: "least_cpu" and "least_disk"
+// rank by that usage alone, anything else by combined CPU and disk usage.
+// It fails if the current primary is still healthy or no member qualifies.
+func (l *HighAvailabilityLedger) PromoteWithinGroup(groupID string) (newPrimary string, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    group, exists := l.FailoverGroups[groupID]
+    if !exists {
+        return "", fmt.Errorf("failover group %s does not exist", groupID)
+    }
+    now := time.Now()
+    if group.Primary != "" && l.memberHealthy(group.Primary, now) {
+        return "", fmt.Errorf("primary %s of failover group %s is healthy", group.Primary, groupID)
+    }
+
+    bestScore := 0.0
+    for _, m := range group.Members {
+        if m == group.Primary || !l.memberHealthy(m, now) {
+            continue
+        }
+        score := memberLoad(l.NodeMetrics[m], group.Policy)
+        if newPrimary == "" || score < bestScore {
+            newPrimary, bestScore = m, score
+        }
+    }
+    if newPrimary == "" {
+        return "", fmt.Errorf("no healthy member available for promotion in failover group %s", groupID)
+    }
+
+    previous := group.Primary
+    group.Primary = newPrimary
+    group.LastUpdated = now
+    l.FailoverGroups[groupID] = group
+    l.FailoverStatus = FailoverStatus{
+        CurrentStatus: fmt.Sprintf("group %s promoted %s", groupID, newPrimary),
+        LastUpdated:   now,
+    }
+    fmt.Printf("Failover group %s promoted %s to primary (previous: %s)\n", groupID, newPrimary, previous)
+    return newPrimary, nil
+}
+
+// memberHealthy reports whether nodeID has recent metrics below the CPU limit.
+// The caller must hold the lock.
+func (l *HighAvailabilityLedger) memberHealthy(nodeID string, now time.Time) bool {
+    metrics, exists := l.NodeMetrics[nodeID]
+    if !exists {
+        return false
+    }
+    staleness := l.FailoverThreshold.MaxAllowedDowntime
+    if staleness <= 0 {
+        staleness = defaultMemberStaleness
+    }
+    return now.Sub(metrics.LastUpdated) <= staleness && metrics.CPUUsage < maxHealthyCPUUsage
+}
+
+// memberLoad scores a member's load under a failover group policy; lower is healthier.
+func memberLoad(metrics NodeMetrics, policy string) float64 {
+    switch policy {
+    case "least_cpu":
+        return metrics.CPUUsage
+    case "least_disk":
+        return metrics.DiskUsage
+    default:
+        return metrics.CPUUsage + metrics.DiskUsage
+    }
 }
 
 // EnableHAProxy enables HA Proxy services.
@@ -1187,21 +1480,118 @@ func (l *HighAvailabilityLedger) DisableCluster() error {
 
 // AddClusterNode adds a node to the cluster.
 func (l *HighAvailabilityLedger) AddClusterNode(nodeID string) error {
+    return l.JoinCluster(nodeID)
+}
+
+// RemoveClusterNode removes a node from the cluster.
+func (l *HighAvailabilityLedger) RemoveClusterNode(nodeID string) error {
+    return l.LeaveCluster(nodeID)
+}
+
+// SetNodeCapabilities records the capabilities a node advertises.
+func (l *HighAvailabilityLedger) SetNodeCapabilities(nodeID string, capabilities []string) {
+    l.Lock()
+    defer l.Unlock()
+
+    if l.NodeCapabilities == nil {
+        l.NodeCapabilities = make(map[string][]string)
+    }
+    l.NodeCapabilities[nodeID] = capabilities
+}
+
+// JoinCluster adds nodeID to the cluster if the cluster policy allows it: the
+// cluster must have room under MaxNodes and the node must advertise every
+// required capability.
+func (l *HighAvailabilityLedger) JoinCluster(nodeID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if nodeID == "" {
+        return fmt.Errorf("node ID cannot be empty")
+    }
+    for _, node := range l.ClusterConfig.Nodes {
+        if node == nodeID {
+            return fmt.Errorf("node %s is already in the cluster", nodeID)
+        }
+    }
+    policy := l.ClusterPolicy
+    if policy.MaxNodes > 0 && len(l.ClusterConfig.Nodes) >= policy.MaxNodes {
+        return fmt.Errorf("cluster is full: policy %s allows at most %d nodes", policy.PolicyName, policy.MaxNodes)
+    }
+    for _, required := range policy.RequiredCapabilities {
+        if !hasCapability(l.NodeCapabilities[nodeID], required) {
+            return fmt.Errorf("node %s lacks required capability %s", nodeID, required)
+        }
+    }
+
     l.ClusterConfig.Nodes = append(l.ClusterConfig.Nodes, nodeID)
     l.ClusterConfig.LastUpdated = time.Now()
+    fmt.Printf("Node %s joined the cluster\n", nodeID)
     return nil
 }
 
-// RemoveClusterNode removes a node from the cluster.
-func (l *HighAvailabilityLedger) RemoveClusterNode(nodeID string) error {
+// LeaveCluster removes nodeID from the cluster unless the healthy nodes left
+// behind would fall below quorum. Quorum is the policy's MinQuorum, or a
+// majority of the current cluster when that is unset.
+func (l *HighAvailabilityLedger) LeaveCluster(nodeID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    index := -1
     for i, node := range l.ClusterConfig.Nodes {
         if node == nodeID {
-            l.ClusterConfig.Nodes = append(l.ClusterConfig.Nodes[:i], l.ClusterConfig.Nodes[i+1:]...)
-            l.ClusterConfig.LastUpdated = time.Now()
-            return nil
+            index = i
+            break
+        }
+    }
+    if index < 0 {
+        return fmt.Errorf("node %s not found in cluster", nodeID)
+    }
+
+    quorum := l.ClusterPolicy.MinQuorum
+    if quorum <= 0 {
+        quorum = len(l.ClusterConfig.Nodes)/2 + 1
+    }
+    now := time.Now()
+    remainingHealthy := 0
+    for _, node := range l.ClusterConfig.Nodes {
+        if node != nodeID && l.memberHealthy(node, now) {
+            remainingHealthy++
+        }
+    }
+    if remainingHealthy < quorum {
+        return fmt.Errorf("removing node %s would break quorum: %d healthy nodes would remain, %d required", nodeID, remainingHealthy, quorum)
+    }
+
+    l.ClusterConfig.Nodes = append(l.ClusterConfig.Nodes[:index], l.ClusterConfig.Nodes[index+1:]...)
+    l.ClusterConfig.LastUpdated = now
+    fmt.Printf("Node %s left the cluster\n", nodeID)
+    return nil
+}
+
+// ClusterHealth returns how many cluster nodes are healthy, judged by the
+// same node metrics used for failover promotion, and the cluster size.
+func (l *HighAvailabilityLedger) ClusterHealth() (healthy int, total int) {
+    l.Lock()
+    defer l.Unlock()
+
+    now := time.Now()
+    for _, node := range l.ClusterConfig.Nodes {
+        if l.memberHealthy(node, now) {
+            healthy++
         }
     }
-    return fmt.Errorf("node %s not found in cluster", nodeID)
+    return healthy, len(l.ClusterConfig.Nodes)
+}
+
+// hasCapability reports whether capabilities contains capability.
+func hasCapability(capabilities []string, capability string) bool {
+    for _, c := range capabilities {
+        if c == capability {
+            return true
+        }
+    }
+    return false
 }
 
 // ListClusterNodes lists all nodes in the cluster.
@@ -1548,6 +1938,92 @@ func (l *HighAvailabilityLedger) DisableColdStandby() error {
     return nil
 }
 
+// defaultStandbyWarmUp is used for cold standbys when StandbyConfig.WarmUpPeriod is unset.
+const defaultStandbyWarmUp = 2 * time.Minute
+
+// ActivateStandby brings a standby node online and makes it the active
+// primary. Hot standbys are ready immediately; cold standbys become ready
+// once the configured warm-up period has elapsed.
+func (l *HighAvailabilityLedger) ActivateStandby(nodeID string, mode string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if nodeID == "" {
+        return fmt.Errorf("node ID cannot be empty")
+    }
+    if _, active := l.StandbyNodes[nodeID]; active {
+        return fmt.Errorf("standby %s is already active", nodeID)
+    }
+
+    now := time.Now()
+    standby := StandbyNode{
+        NodeID:          nodeID,
+        Mode:            mode,
+        ActivatedAt:     now,
+        ReplacedPrimary: l.ActivePrimary,
+    }
+    switch mode {
+    case "hot":
+        standby.ReadyAt = now
+    case "cold":
+        warmUp := l.StandbyConfig.WarmUpPeriod
+        if warmUp <= 0 {
+            warmUp = defaultStandbyWarmUp
+        }
+        standby.ReadyAt = now.Add(warmUp)
+    default:
+        return fmt.Errorf("unsupported standby mode %q", mode)
+    }
+
+    if l.StandbyNodes == nil {
+        l.StandbyNodes = make(map[string]StandbyNode)
+    }
+    l.StandbyNodes[nodeID] = standby
+    l.ActivePrimary = nodeID
+    l.FailoverStatus = FailoverStatus{
+        CurrentStatus: fmt.Sprintf("%s standby %s activated", mode, nodeID),
+        LastUpdated:   now,
+    }
+    fmt.Printf("%s standby %s activated, ready at %s\n", mode, nodeID, standby.ReadyAt.Format(time.RFC3339))
+    return nil
+}
+
+// StandbyReady reports whether an activated standby has finished warming up.
+func (l *HighAvailabilityLedger) StandbyReady(nodeID string) bool {
+    l.Lock()
+    defer l.Unlock()
+
+    standby, exists := l.StandbyNodes[nodeID]
+    return exists && !time.Now().Before(standby.ReadyAt)
+}
+
+// Failback returns service from an activated standby to the original primary
+// and stands the standby down. The primary must be healthy again.
+func (l *HighAvailabilityLedger) Failback(fromStandby, toPrimary string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    if _, exists := l.StandbyNodes[fromStandby]; !exists {
+        return fmt.Errorf("standby %s is not active", fromStandby)
+    }
+    if l.ActivePrimary != fromStandby {
+        return fmt.Errorf("standby %s is not the active primary", fromStandby)
+    }
+    now := time.Now()
+    if !l.memberHealthy(toPrimary, now) {
+        return fmt.Errorf("primary %s is not healthy enough to fail back to", toPrimary)
+    }
+
+    delete(l.StandbyNodes, fromStandby)
+    l.ActivePrimary = toPrimary
+    l.FailoverStatus = FailoverStatus{
+        CurrentStatus: fmt.Sprintf("failed back from %s to %s", fromStandby, toPrimary),
+        LastUpdated:   now,
+    }
+    fmt.Printf("Failed back from standby %s to primary %s\n", fromStandby, toPrimary)
+    return nil
+}
+
 // SaveSimulationResults saves simulation results to the ledger.
 func (l *HighAvailabilityLedger) SaveSimulationResults(simulationID string, results string) error {
     if simulationID == "" || results == "" {
@@ -1580,6 +2056,125 @@ func (l *HighAvailabilityLedger) ListSimulationResults() ([]string, error) {
     return resultIDs, nil
 }
 
+// haSimulationState is the live failover state captured before a disaster
+// simulation so it can be restored afterwards.
+type haSimulationState struct {
+    failoverGroups    map[string]FailoverGroup
+    nodeMetrics       map[string]NodeMetrics
+    failoverStatus    FailoverStatus
+    loadBalancerNodes map[string]bool
+    readReplicas      map[string]bool
+}
+
+// RunDisasterSimulation runs a failure scenario and records how long it took
+// to recover and whether it succeeded. The scenario runs sandboxed: failover
+// groups, node metrics, failover status, load balancer nodes and read replicas
+// are restored once it returns, so the simulation never affects live state.
+// A scenario that panics is recorded as a failed simulation.
+func (l *HighAvailabilityLedger) RunDisasterSimulation(cfg DisasterSimulationConfig, scenario func() error) (*SimulationResult, error) {
+    if scenario == nil {
+        return nil, fmt.Errorf("simulation scenario cannot be nil")
+    }
+    if cfg.Mode == "disabled" {
+        return nil, fmt.Errorf("disaster simulation is disabled")
+    }
+
+    l.Lock()
+    saved := l.captureSimulationState()
+    l.Unlock()
+
+    start := time.Now()
+    scenarioErr := runSimulationScenario(scenario)
+    elapsed := time.Since(start)
+
+    l.Lock()
+    defer l.Unlock()
+    l.restoreSimulationState(saved)
+
+    result := SimulationResult{
+        ID:           generateUniqueID(),
+        Mode:         cfg.Mode,
+        Parameters:   cfg.Parameters,
+        Succeeded:    scenarioErr == nil,
+        RecoveryTime: elapsed,
+        CreatedAt:    start,
+        UpdatedAt:    time.Now(),
+    }
+    if scenarioErr != nil {
+        result.Error = scenarioErr.Error()
+        result.Results = fmt.Sprintf("scenario failed after %s: %v", elapsed, scenarioErr)
+    } else {
+        result.Results = fmt.Sprintf("scenario recovered in %s", elapsed)
+    }
+
+    if l.SimulationResults == nil {
+        l.SimulationResults = make(map[string]SimulationResult)
+    }
+    l.SimulationResults[result.ID] = result
+    fmt.Printf("Disaster simulation %s: %s\n", result.ID, result.Results)
+    return &result, nil
+}
+
+// SimulationHistory returns all recorded simulation results, oldest first.
+func (l *HighAvailabilityLedger) SimulationHistory() []SimulationResult {
+    l.Lock()
+    defer l.Unlock()
+
+    history := make([]SimulationResult, 0, len(l.SimulationResults))
+    for _, result := range l.SimulationResults {
+        history = append(history, result)
+    }
+    sort.Slice(history, func(i, j int) bool {
+        return history[i].CreatedAt.Before(history[j].CreatedAt)
+    })
+    return history
+}
+
+// runSimulationScenario runs scenario, converting a panic into an error.
+func runSimulationScenario(scenario func() error) (err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("scenario panicked: %v", r)
+        }
+    }()
+    return scenario()
+}
+
+// captureSimulationState copies the live failover state. The caller must hold the lock.
+func (l *HighAvailabilityLedger) captureSimulationState() haSimulationState {
+    state := haSimulationState{
+        failoverGroups:    make(map[string]FailoverGroup, len(l.FailoverGroups)),
+        nodeMetrics:       make(map[string]NodeMetrics, len(l.NodeMetrics)),
+        failoverStatus:    l.FailoverStatus,
+        loadBalancerNodes: make(map[string]bool, len(l.LoadBalancerNodes)),
+        readReplicas:      make(map[string]bool, len(l.ReadReplicas)),
+    }
+    for id, group := range l.FailoverGroups {
+        group.Members = append([]string(nil), group.Members...)
+        state.failoverGroups[id] = group
+    }
+    for id, metrics := range l.NodeMetrics {
+        state.nodeMetrics[id] = metrics
+    }
+    for id, v := range l.LoadBalancerNodes {
+        state.loadBalancerNodes[id] = v
+    }
+    for id, v := range l.ReadReplicas {
+        state.readReplicas[id] = v
+    }
+    return state
+}
+
+// restoreSimulationState reinstates state captured by captureSimulationState.
+// The caller must hold the lock.
+func (l *HighAvailabilityLedger) restoreSimulationState(state haSimulationState) {
+    l.FailoverGroups = state.failoverGroups
+    l.NodeMetrics = state.nodeMetrics
+    l.FailoverStatus = state.failoverStatus
+    l.LoadBalancerNodes = state.loadBalancerNodes
+    l.ReadReplicas = state.readReplicas
+}
+
 // EnableResourceQuotas enables resource quotas.
 func (l *HighAvailabilityLedger) EnableResourceQuotas() error {
     l.ResourceQuotaConfig.IsEnabled = true
@@ -1741,3 +2336,106 @@ func (l *HighAvailabilityLedger) DeleteArchivedData(archiveID string) error {
     delete(l.ArchivedData, archiveID)
     return nil
 }
+
+// PruneLogs deletes event, audit and integration log entries older than the
+// configured retention period. Entries under legal hold, or in a held stream,
+// are kept regardless of age.
+func (l *Ledger) PruneLogs(now time.Time) (pruned int, err error) {
+    l.HighAvailabilityLedger.Lock()
+    period := l.HighAvailabilityLedger.LogRetentionConfig.RetentionPeriod
+    holds := make(map[string]bool, len(l.HighAvailabilityLedger.LogRetentionConfig.LegalHolds))
+    for id := range l.HighAvailabilityLedger.LogRetentionConfig.LegalHolds {
+        holds[id] = true
+    }
+    l.HighAvailabilityLedger.Unlock()
+
+    if period <= 0 {
+        return 0, fmt.Errorf("log retention period is not configured")
+    }
+    cutoff := now.AddDate(0, 0, -period)
+    expired := func(stream, entryID string, ts time.Time) bool {
+        return ts.Before(cutoff) && !holds[stream] && !holds[entryID]
+    }
+
+    l.EnvironmentSystemCoreLedger.Lock()
+    for stream, entries := range l.EnvironmentSystemCoreLedger.EventLogs {
+        kept := entries[:0]
+        for _, e := range entries {
+            if expired(stream, e.EventID, e.Timestamp) {
+                pruned++
+                continue
+            }
+            kept = append(kept, e)
+        }
+        l.EnvironmentSystemCoreLedger.EventLogs[stream] = kept
+    }
+    l.EnvironmentSystemCoreLedger.Unlock()
+
+    l.ComplianceLedger.Lock()
+    for stream, entries := range l.ComplianceLedger.AuditHistory {
+        kept := entries[:0]
+        for _, e := range entries {
+            if expired(stream, e.EntryID, e.Timestamp) {
+                pruned++
+                continue
+            }
+            kept = append(kept, e)
+        }
+        l.ComplianceLedger.AuditHistory[stream] = kept
+    }
+    l.ComplianceLedger.Unlock()
+
+    l.IntegrationLedger.Lock()
+    for stream, entries := range l.IntegrationLedger.IntegrationLogs {
+        kept := entries[:0]
+        for _, e := range entries {
+            if expired(stream, e.LogID, e.Timestamp) {
+                pruned++
+                continue
+            }
+            kept = append(kept, e)
+        }
+        l.IntegrationLedger.IntegrationLogs[stream] = kept
+    }
+    l.IntegrationLedger.Unlock()
+
+    fmt.Printf("Pruned %d log entries older than %s\n", pruned, cutoff.Format(time.RFC3339))
+    return pruned, nil
+}
+
+// LogStorageStats returns the number of event, audit and integration log
+// entries held and the timestamp of the oldest one.
+func (l *Ledger) LogStorageStats() (count int, oldestEntry time.Time) {
+    observe := func(ts time.Time) {
+        count++
+        if oldestEntry.IsZero() || ts.Before(oldestEntry) {
+            oldestEntry = ts
+        }
+    }
+
+    l.EnvironmentSystemCoreLedger.Lock()
+    for _, entries := range l.EnvironmentSystemCoreLedger.EventLogs {
+        for _, e := range entries {
+            observe(e.Timestamp)
+        }
+    }
+    l.EnvironmentSystemCoreLedger.Unlock()
+
+    l.ComplianceLedger.Lock()
+    for _, entries := range l.ComplianceLedger.AuditHistory {
+        for _, e := range entries {
+            observe(e.Timestamp)
+        }
+    }
+    l.ComplianceLedger.Unlock()
+
+    l.IntegrationLedger.Lock()
+    for _, entries := range l.IntegrationLedger.IntegrationLogs {
+        for _, e := range entries {
+            observe(e.Timestamp)
+        }
+    }
+    l.IntegrationLedger.Unlock()
+
+    return count, oldestEntry
+}
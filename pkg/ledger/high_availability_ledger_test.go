@@ -0,0 +1,429 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func backupTestBlocks() []Block {
+	return []Block{
+		{BlockID: "block-0", Index: 0, Hash: "hash-0"},
+		{
+			BlockID:  "block-1",
+			Index:    1,
+			PrevHash: "hash-0",
+			Hash:     "hash-1",
+			SubBlocks: []SubBlock{{
+				SubBlockID:   "sub-1",
+				Transactions: []Transaction{{TransactionID: "tx-1", Amount: 10}},
+			}},
+		},
+	}
+}
+
+func TestVerifyBackupAcceptsRecordedBackup(t *testing.T) {
+	l := &HighAvailabilityLedger{}
+	backup, err := l.RecordBlockchainBackup("node-1", backupTestBlocks(), time.Now())
+	if err != nil {
+		t.Fatalf("RecordBlockchainBackup: %v", err)
+	}
+	if backup.BackupHash == "" || backup.BackupSize == 0 {
+		t.Fatalf("backup = %+v, want its hash and size set", backup)
+	}
+
+	valid, err := l.VerifyBackup(backup.BackupID)
+	if err != nil || !valid {
+		t.Fatalf("VerifyBackup = %v, %v; want true, nil", valid, err)
+	}
+}
+
+func TestVerifyBackupDetectsCorruptedTransaction(t *testing.T) {
+	l := &HighAvailabilityLedger{}
+	backup, err := l.RecordBlockchainBackup("node-1", backupTestBlocks(), time.Now())
+	if err != nil {
+		t.Fatalf("RecordBlockchainBackup: %v", err)
+	}
+
+	// Corrupt a transaction without touching any block header field.
+	l.BackupLogs[0].Blocks[1].SubBlocks[0].Transactions[0].Amount = 1000
+
+	valid, err := l.VerifyBackup(backup.BackupID)
+	if err != nil {
+		t.Fatalf("VerifyBackup: %v", err)
+	}
+	if valid {
+		t.Fatal("expected a backup with a corrupted transaction to fail verification")
+	}
+}
+
+func TestLastValidBackupSkipsCorruptedBackup(t *testing.T) {
+	l := &HighAvailabilityLedger{}
+	now := time.Now()
+	older, err := l.RecordBlockchainBackup("node-1", backupTestBlocks(), now)
+	if err != nil {
+		t.Fatalf("RecordBlockchainBackup: %v", err)
+	}
+	if _, err := l.RecordBlockchainBackup("node-1", backupTestBlocks(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("RecordBlockchainBackup: %v", err)
+	}
+	l.BackupLogs[1].Blocks[0].Hash = "tampered"
+
+	latest, err := l.LastValidBackup()
+	if err != nil {
+		t.Fatalf("LastValidBackup: %v", err)
+	}
+	if latest.BackupID != older.BackupID {
+		t.Fatalf("LastValidBackup = %s, want the older intact backup %s", latest.BackupID, older.BackupID)
+	}
+}
+
+func newFailoverTestLedger(policy string, metrics map[string]NodeMetrics) *HighAvailabilityLedger {
+	l := &HighAvailabilityLedger{
+		FailoverGroups: map[string]FailoverGroup{"group-1": {GroupID: "group-1", Policy: policy}},
+		NodeMetrics:    metrics,
+	}
+	for _, node := range []string{"node-a", "node-b", "node-c"} {
+		l.AddGroupMember("group-1", node)
+	}
+	return l
+}
+
+func TestPromoteWithinGroupSelectsHealthiestMember(t *testing.T) {
+	now := time.Now()
+	l := newFailoverTestLedger("least_cpu", map[string]NodeMetrics{
+		"node-a": {NodeID: "node-a", CPUUsage: 99, LastUpdated: now},
+		"node-b": {NodeID: "node-b", CPUUsage: 60, LastUpdated: now},
+		"node-c": {NodeID: "node-c", CPUUsage: 20, LastUpdated: now},
+	})
+	if primary := l.FailoverGroups["group-1"].Primary; primary != "node-a" {
+		t.Fatalf("initial primary = %q, want node-a", primary)
+	}
+
+	newPrimary, err := l.PromoteWithinGroup("group-1")
+	if err != nil {
+		t.Fatalf("PromoteWithinGroup: %v", err)
+	}
+	if newPrimary != "node-c" || l.FailoverGroups["group-1"].Primary != "node-c" {
+		t.Fatalf("promoted %q, want node-c", newPrimary)
+	}
+	if _, err := l.PromoteWithinGroup("group-1"); err == nil {
+		t.Fatal("expected promotion to be refused while the primary is healthy")
+	}
+}
+
+func TestPromoteWithinGroupRefusesWithoutHealthyMember(t *testing.T) {
+	now := time.Now()
+	l := newFailoverTestLedger("", map[string]NodeMetrics{
+		"node-a": {NodeID: "node-a", CPUUsage: 99, LastUpdated: now},
+		"node-b": {NodeID: "node-b", CPUUsage: 10, LastUpdated: now.Add(-time.Hour)},
+	})
+
+	if _, err := l.PromoteWithinGroup("group-1"); err == nil {
+		t.Fatal("expected promotion to fail when no member is healthy")
+	}
+	if primary := l.FailoverGroups["group-1"].Primary; primary != "node-a" {
+		t.Fatalf("primary = %q, want node-a left in place", primary)
+	}
+}
+
+func TestRemoveGroupMemberClearsPrimary(t *testing.T) {
+	l := newFailoverTestLedger("", nil)
+
+	if err := l.AddGroupMember("group-1", "node-a"); err == nil {
+		t.Fatal("expected adding a duplicate member to fail")
+	}
+	if err := l.RemoveGroupMember("group-1", "node-a"); err != nil {
+		t.Fatalf("RemoveGroupMember: %v", err)
+	}
+	group := l.FailoverGroups["group-1"]
+	if group.Primary != "" || len(group.Members) != 2 {
+		t.Fatalf("group = %+v, want no primary and two members", group)
+	}
+}
+
+func TestRunDisasterSimulationLeavesLiveStateUntouched(t *testing.T) {
+	l := newFailoverTestLedger("", nil)
+	cfg := DisasterSimulationConfig{Mode: "primary_loss", Parameters: "group-1"}
+
+	result, err := l.RunDisasterSimulation(cfg, func() error {
+		if err := l.RemoveGroupMember("group-1", "node-a"); err != nil {
+			return err
+		}
+		return l.AddGroupMember("group-1", "node-d")
+	})
+	if err != nil {
+		t.Fatalf("RunDisasterSimulation: %v", err)
+	}
+	if !result.Succeeded || result.Mode != "primary_loss" || result.Parameters != "group-1" {
+		t.Fatalf("result = %+v, want a successful primary_loss run", result)
+	}
+
+	group := l.FailoverGroups["group-1"]
+	if group.Primary != "node-a" || len(group.Members) != 3 || group.Members[2] != "node-c" {
+		t.Fatalf("live group = %+v, want it unchanged by the simulation", group)
+	}
+}
+
+func TestRunDisasterSimulationRecordsFailures(t *testing.T) {
+	l := &HighAvailabilityLedger{}
+	cfg := DisasterSimulationConfig{Mode: "region_outage"}
+
+	failed, err := l.RunDisasterSimulation(cfg, func() error { return fmt.Errorf("replica unreachable") })
+	if err != nil {
+		t.Fatalf("RunDisasterSimulation: %v", err)
+	}
+	if failed.Succeeded || failed.Error != "replica unreachable" {
+		t.Fatalf("result = %+v, want the scenario error recorded", failed)
+	}
+	panicked, err := l.RunDisasterSimulation(cfg, func() error { panic("disk lost") })
+	if err != nil {
+		t.Fatalf("RunDisasterSimulation: %v", err)
+	}
+	if panicked.Succeeded || !strings.Contains(panicked.Error, "disk lost") {
+		t.Fatalf("result = %+v, want the panic recorded as a failure", panicked)
+	}
+
+	if history := l.SimulationHistory(); len(history) != 2 {
+		t.Fatalf("SimulationHistory has %d results, want 2", len(history))
+	}
+	if _, err := l.RunDisasterSimulation(DisasterSimulationConfig{Mode: "disabled"}, func() error { return nil }); err == nil {
+		t.Fatal("expected a disabled simulation to be refused")
+	}
+}
+
+func newClusterTestLedger(nodes ...string) *HighAvailabilityLedger {
+	l := &HighAvailabilityLedger{
+		ClusterConfig: ClusterConfig{Nodes: nodes},
+		NodeMetrics:   make(map[string]NodeMetrics),
+	}
+	for _, node := range nodes {
+		l.NodeMetrics[node] = NodeMetrics{NodeID: node, CPUUsage: 10, LastUpdated: time.Now()}
+	}
+	return l
+}
+
+func TestJoinClusterValidatesPolicy(t *testing.T) {
+	l := newClusterTestLedger("node-a")
+	l.ClusterPolicy = ClusterPolicy{MaxNodes: 2, RequiredCapabilities: []string{"storage"}}
+
+	if err := l.JoinCluster("node-b"); err == nil {
+		t.Fatal("expected a node without the required capability to be rejected")
+	}
+	l.SetNodeCapabilities("node-b", []string{"storage", "compute"})
+	if err := l.JoinCluster("node-b"); err != nil {
+		t.Fatalf("JoinCluster(node-b): %v", err)
+	}
+	if len(l.ClusterConfig.Nodes) != 2 {
+		t.Fatalf("cluster nodes = %v, want two", l.ClusterConfig.Nodes)
+	}
+
+	l.SetNodeCapabilities("node-c", []string{"storage"})
+	if err := l.JoinCluster("node-c"); err == nil {
+		t.Fatal("expected a join beyond MaxNodes to be rejected")
+	}
+}
+
+func TestLeaveClusterRejectsQuorumBreak(t *testing.T) {
+	l := newClusterTestLedger("node-a", "node-b", "node-c")
+	delete(l.NodeMetrics, "node-c")
+
+	if err := l.LeaveCluster("node-a"); err == nil {
+		t.Fatal("expected a leave that breaks quorum to be rejected")
+	}
+	if err := l.LeaveCluster("node-c"); err != nil {
+		t.Fatalf("LeaveCluster(node-c): %v", err)
+	}
+	if len(l.ClusterConfig.Nodes) != 2 {
+		t.Fatalf("cluster nodes = %v, want two", l.ClusterConfig.Nodes)
+	}
+}
+
+func TestClusterHealthCountsHealthyNodes(t *testing.T) {
+	l := newClusterTestLedger("node-a", "node-b", "node-c")
+	l.NodeMetrics["node-b"] = NodeMetrics{NodeID: "node-b", CPUUsage: 99, LastUpdated: time.Now()}
+
+	healthy, total := l.ClusterHealth()
+	if healthy != 2 || total != 3 {
+		t.Fatalf("ClusterHealth = %d, %d; want 2, 3", healthy, total)
+	}
+}
+
+func TestActivateStandbyHotIsImmediatelyReady(t *testing.T) {
+	l := &HighAvailabilityLedger{ActivePrimary: "primary-1"}
+
+	if err := l.ActivateStandby("standby-1", "hot"); err != nil {
+		t.Fatalf("ActivateStandby: %v", err)
+	}
+	if !l.StandbyReady("standby-1") {
+		t.Fatal("expected a hot standby to be ready immediately")
+	}
+	if l.ActivePrimary != "standby-1" || l.StandbyNodes["standby-1"].ReplacedPrimary != "primary-1" {
+		t.Fatalf("ActivePrimary = %q, standby = %+v; want standby-1 replacing primary-1", l.ActivePrimary, l.StandbyNodes["standby-1"])
+	}
+	if err := l.ActivateStandby("standby-2", "warm"); err == nil {
+		t.Fatal("expected an unsupported standby mode to be rejected")
+	}
+}
+
+func TestActivateStandbyColdRequiresWarmUp(t *testing.T) {
+	l := &HighAvailabilityLedger{StandbyConfig: StandbyConfig{WarmUpPeriod: time.Hour}}
+
+	if err := l.ActivateStandby("standby-1", "cold"); err != nil {
+		t.Fatalf("ActivateStandby: %v", err)
+	}
+	if l.StandbyReady("standby-1") {
+		t.Fatal("expected a cold standby not to be ready before warm-up")
+	}
+
+	standby := l.StandbyNodes["standby-1"]
+	standby.ReadyAt = time.Now().Add(-time.Second)
+	l.StandbyNodes["standby-1"] = standby
+	if !l.StandbyReady("standby-1") {
+		t.Fatal("expected a cold standby to be ready once warm-up has elapsed")
+	}
+}
+
+func TestFailbackRestoresPrimary(t *testing.T) {
+	l := &HighAvailabilityLedger{
+		ActivePrimary: "primary-1",
+		NodeMetrics:   map[string]NodeMetrics{"primary-1": {NodeID: "primary-1", CPUUsage: 95, LastUpdated: time.Now()}},
+	}
+	if err := l.ActivateStandby("standby-1", "hot"); err != nil {
+		t.Fatalf("ActivateStandby: %v", err)
+	}
+
+	if err := l.Failback("standby-1", "primary-1"); err == nil {
+		t.Fatal("expected failback to an unhealthy primary to be rejected")
+	}
+	l.NodeMetrics["primary-1"] = NodeMetrics{NodeID: "primary-1", CPUUsage: 20, LastUpdated: time.Now()}
+	if err := l.Failback("standby-1", "primary-1"); err != nil {
+		t.Fatalf("Failback: %v", err)
+	}
+	if l.ActivePrimary != "primary-1" || l.StandbyReady("standby-1") {
+		t.Fatalf("ActivePrimary = %q, want primary-1 with the standby stood down", l.ActivePrimary)
+	}
+}
+
+func newLogRetentionTestLedger(now time.Time) *Ledger {
+	old, recent := now.AddDate(0, 0, -30), now.AddDate(0, 0, -1)
+	l := &Ledger{}
+	l.HighAvailabilityLedger.SetLogRetention(7)
+	l.EnvironmentSystemCoreLedger.EventLogs = map[string][]EventLog{
+		"event-1": {{EventID: "ev-old", Timestamp: old}, {EventID: "ev-new", Timestamp: recent}},
+	}
+	l.ComplianceLedger.AuditHistory = map[string][]AuditLog{
+		"user-1": {{EntryID: "audit-old", Timestamp: old}},
+	}
+	l.IntegrationLedger.IntegrationLogs = map[string][]IntegrationLog{
+		"service-1": {{LogID: "int-old", Timestamp: old}, {LogID: "int-new", Timestamp: recent}},
+	}
+	return l
+}
+
+func TestPruneLogsRemovesExpiredEntries(t *testing.T) {
+	now := time.Now()
+	l := newLogRetentionTestLedger(now)
+
+	pruned, err := l.PruneLogs(now)
+	if err != nil {
+		t.Fatalf("PruneLogs: %v", err)
+	}
+	if pruned != 3 {
+		t.Fatalf("pruned = %d, want 3", pruned)
+	}
+	count, oldest := l.LogStorageStats()
+	if count != 2 || !oldest.Equal(now.AddDate(0, 0, -1)) {
+		t.Fatalf("LogStorageStats = %d, %v; want 2 entries from a day ago", count, oldest)
+	}
+}
+
+func TestPruneLogsPreservesLegalHolds(t *testing.T) {
+	now := time.Now()
+	l := newLogRetentionTestLedger(now)
+	l.HighAvailabilityLedger.PlaceLegalHold("ev-old", "litigation")
+	l.HighAvailabilityLedger.PlaceLegalHold("user-1", "regulator request")
+
+	pruned, err := l.PruneLogs(now)
+	if err != nil {
+		t.Fatalf("PruneLogs: %v", err)
+	}
+	if pruned != 1 {
+		t.Fatalf("pruned = %d, want only the unheld integration log", pruned)
+	}
+	if got := len(l.EnvironmentSystemCoreLedger.EventLogs["event-1"]); got != 2 {
+		t.Fatalf("event logs kept = %d, want 2", got)
+	}
+	if got := len(l.ComplianceLedger.AuditHistory["user-1"]); got != 1 {
+		t.Fatalf("audit logs kept = %d, want the held stream intact", got)
+	}
+	if count, _ := l.LogStorageStats(); count != 4 {
+		t.Fatalf("LogStorageStats count = %d, want 4", count)
+	}
+
+	if _, err := (&Ledger{}).PruneLogs(now); err == nil {
+		t.Fatal("expected pruning without a retention period to fail")
+	}
+}
+
+func TestBeginSnapshotCountsConcurrentSnapshots(t *testing.T) {
+	l := &HighAvailabilityLedger{SnapshotStatus: SnapshotStatus{MaxConcurrent: 8}}
+
+	var wg sync.WaitGroup
+	ids := make(chan string, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := l.BeginSnapshot()
+			if err != nil {
+				t.Errorf("BeginSnapshot: %v", err)
+				return
+			}
+			ids <- id
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	if l.SnapshotStatus.ActiveSnapshots != 8 {
+		t.Fatalf("ActiveSnapshots = %d, want 8", l.SnapshotStatus.ActiveSnapshots)
+	}
+
+	now := time.Now()
+	var last string
+	for id := range ids {
+		if err := l.CompleteSnapshot(id, now); err != nil {
+			t.Fatalf("CompleteSnapshot: %v", err)
+		}
+		last = id
+	}
+	if l.SnapshotStatus.ActiveSnapshots != 0 || l.SnapshotStatus.LastSnapshotID != last || !l.SnapshotStatus.LastSnapshotTime.Equal(now) {
+		t.Fatalf("SnapshotStatus = %+v, want none running and the last completion recorded", l.SnapshotStatus)
+	}
+	if err := l.CompleteSnapshot(last, now); err == nil {
+		t.Fatal("expected completing a finished snapshot to fail")
+	}
+}
+
+func TestBeginSnapshotEnforcesLimit(t *testing.T) {
+	l := &HighAvailabilityLedger{}
+	first, err := l.BeginSnapshot()
+	if err != nil {
+		t.Fatalf("BeginSnapshot: %v", err)
+	}
+	if _, err := l.BeginSnapshot(); err != nil {
+		t.Fatalf("BeginSnapshot: %v", err)
+	}
+
+	if _, err := l.BeginSnapshot(); err == nil {
+		t.Fatal("expected a snapshot beyond the default limit to be rejected")
+	}
+	if err := l.CompleteSnapshot(first, time.Now()); err != nil {
+		t.Fatalf("CompleteSnapshot: %v", err)
+	}
+	if _, err := l.BeginSnapshot(); err != nil {
+		t.Fatalf("BeginSnapshot after completion: %v", err)
+	}
+}
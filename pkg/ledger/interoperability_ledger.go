@@ -60,6 +60,23 @@ func (l *InteroperabilityLedger) RecordAtomicSwapExpiration(swapID string) {
 	fmt.Printf("Atomic Swap %s expired.\n", swapID)
 }
 
+// RecordAtomicSwapRefund logs the refund of an expired atomic swap to its initiator.
+func (l *InteroperabilityLedger) RecordAtomicSwapRefund(swapID, initiator string, amount float64) {
+	l.Lock()
+	defer l.Unlock()
+
+	swapDetails := fmt.Sprintf("Atomic Swap Refunded: ID: %s, Initiator: %s, Amount: %f", swapID, initiator, amount)
+
+	l.InteropLogs = append(l.InteropLogs, InteroperabilityLog{
+		EventType: "AtomicSwapRefund",
+		Timestamp: time.Now(),
+		Details:   swapDetails,
+		Status:    "Refunded",
+	})
+
+	fmt.Printf("Atomic Swap %s refunded to %s.\n", swapID, initiator)
+}
+
 // RecordCrossChainTransaction logs the initiation of a cross-chain transaction.
 func (l *InteroperabilityLedger) RecordCrossChainTransaction(txID, sender, receiver, sourceChainID, targetChainID string, amount float64) {
 	l.Lock()
@@ -1,6 +1,16 @@
 package ledger
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // GetLedgerInstance returns the instance of the current ledger.
@@ -10,3 +20,354 @@ func (l *Ledger) GetLedgerInstance() *Ledger {
 
 	return l
 }
+
+// LedgerMutation is a serialisable change to the ledger. Kind selects the
+// applier registered with RegisterMutationApplier; Payload is its input.
+type LedgerMutation struct {
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// MutationApplier applies a mutation payload to the ledger. It runs with the
+// ledger lock held, so it must not call methods that take it.
+type MutationApplier func(l *Ledger, payload []byte) error
+
+var (
+	mutationAppliersMu sync.RWMutex
+	mutationAppliers   = make(map[string]MutationApplier)
+)
+
+// mutationKindContractState updates a contract's state in the VirtualMachineLedger.
+const mutationKindContractState = "contract_state"
+
+func init() {
+	RegisterMutationApplier(mutationKindContractState, applyContractState)
+}
+
+// RegisterMutationApplier registers the function that applies mutations of kind.
+func RegisterMutationApplier(kind string, apply MutationApplier) error {
+	if kind == "" || apply == nil {
+		return errors.New("mutation kind and applier are required")
+	}
+	mutationAppliersMu.Lock()
+	defer mutationAppliersMu.Unlock()
+	mutationAppliers[kind] = apply
+	return nil
+}
+
+// contractStateMutation is the payload of a contract_state mutation.
+type contractStateMutation struct {
+	ContractID  string                 `json:"contract_id"`
+	StateUpdate map[string]interface{} `json:"state_update"`
+}
+
+// SubmitContractState records a contract execution's state update. With
+// write-behind buffering enabled it is logged and batched through
+// SubmitMutation; otherwise it is applied to the VirtualMachineLedger
+// directly, without serialising the update or taking the ledger lock.
+func (l *Ledger) SubmitContractState(contractID string, stateUpdate map[string]interface{}) error {
+	if contractID == "" {
+		return errors.New("contract ID cannot be empty")
+	}
+
+	l.writeBehindMu.RLock()
+	buffered := l.writeBehind != nil
+	l.writeBehindMu.RUnlock()
+	if !buffered {
+		return l.VirtualMachineLedger.RecordContractExecution(contractID, stateUpdate)
+	}
+
+	payload, err := json.Marshal(contractStateMutation{ContractID: contractID, StateUpdate: stateUpdate})
+	if err != nil {
+		return fmt.Errorf("failed to encode contract state: %w", err)
+	}
+	return l.SubmitMutation(LedgerMutation{Kind: mutationKindContractState, Payload: payload})
+}
+
+// applyContractState applies a contract_state mutation.
+func applyContractState(l *Ledger, payload []byte) error {
+	var m contractStateMutation
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return fmt.Errorf("invalid contract state payload: %w", err)
+	}
+	return l.VirtualMachineLedger.RecordContractExecution(m.ContractID, m.StateUpdate)
+}
+
+// writeBehindBuffer holds mutations that have been written to the
+// write-ahead log but not yet applied to the ledger.
+type writeBehindBuffer struct {
+	mu        sync.Mutex
+	pending   []LedgerMutation
+	batchSize int
+	wal       *os.File
+	written   atomic.Uint64 // Mutations written to the log
+	stop      chan struct{}
+	done      chan struct{}
+
+	syncMu sync.Mutex
+	synced uint64 // Mutations known to be on stable storage
+}
+
+// EnableWriteBehind buffers mutations submitted with SubmitMutation and
+// applies them in batches under a single ledger lock, once batchSize
+// mutations are pending or every flushInterval. Each mutation is synced to
+// the write-ahead log at WriteAheadLogConfig.Path, which must be absolute,
+// before SubmitMutation returns; concurrent submitters share one sync.
+// Mutations left in the log by a crash are replayed here before buffering
+// starts.
+func (l *Ledger) EnableWriteBehind(batchSize int, flushInterval time.Duration) error {
+	if batchSize <= 0 || flushInterval <= 0 {
+		return errors.New("batch size and flush interval must be positive")
+	}
+
+	l.writeBehindMu.Lock()
+	defer l.writeBehindMu.Unlock()
+
+	if l.writeBehind != nil {
+		return errors.New("write-behind buffering is already enabled")
+	}
+
+	path := l.HighAvailabilityLedger.WriteAheadLogConfig.Path
+	if path == "" {
+		return errors.New("write-ahead log path is not configured")
+	}
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("write-ahead log path %q must be absolute", path)
+	}
+	recovered, err := readWriteBehindWAL(path)
+	if err != nil {
+		return fmt.Errorf("failed to read write-ahead log: %w", err)
+	}
+	wal, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	wb := &writeBehindBuffer{
+		pending:   recovered,
+		batchSize: batchSize,
+		wal:       wal,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if err := l.flushWriteBehind(wb); err != nil {
+		log.Printf("[WARNING] Replaying write-ahead log: %v", err)
+	}
+	if len(recovered) > 0 {
+		log.Printf("[INFO] Replayed %d buffered ledger mutations from %s", len(recovered), path)
+	}
+
+	l.writeBehind = wb
+	go l.runWriteBehind(wb, flushInterval)
+	return nil
+}
+
+// DisableWriteBehind flushes pending mutations and returns to applying
+// mutations synchronously.
+func (l *Ledger) DisableWriteBehind() error {
+	l.writeBehindMu.Lock()
+	wb := l.writeBehind
+	l.writeBehind = nil
+	l.writeBehindMu.Unlock()
+
+	if wb == nil {
+		return errors.New("write-behind buffering is not enabled")
+	}
+	close(wb.stop)
+	<-wb.done
+
+	flushErr := l.flushWriteBehind(wb)
+	if err := wb.wal.Close(); err != nil && flushErr == nil {
+		return err
+	}
+	return flushErr
+}
+
+// SubmitMutation applies m to the ledger. With write-behind buffering enabled
+// it is logged and buffered, and applied at the next flush; otherwise it is
+// applied immediately. Mutations of an unregistered kind are rejected.
+func (l *Ledger) SubmitMutation(m LedgerMutation) error {
+	mutationAppliersMu.RLock()
+	_, registered := mutationAppliers[m.Kind]
+	mutationAppliersMu.RUnlock()
+	if !registered {
+		return fmt.Errorf("no applier registered for mutation kind %q", m.Kind)
+	}
+
+	l.writeBehindMu.RLock()
+	defer l.writeBehindMu.RUnlock()
+
+	wb := l.writeBehind
+	if wb == nil {
+		l.Lock()
+		defer l.Unlock()
+		return applyMutation(l, m)
+	}
+
+	line, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode mutation: %w", err)
+	}
+
+	wb.mu.Lock()
+	if _, err := wb.wal.Write(append(line, '\n')); err != nil {
+		wb.mu.Unlock()
+		return fmt.Errorf("failed to write mutation to write-ahead log: %w", err)
+	}
+	seq := wb.written.Add(1)
+	wb.pending = append(wb.pending, m)
+	full := len(wb.pending) >= wb.batchSize
+	wb.mu.Unlock()
+
+	if err := wb.syncThrough(seq); err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	if full {
+		return l.flushWriteBehind(wb)
+	}
+	return nil
+}
+
+// syncThrough returns once the first seq mutations written to the log are on
+// stable storage. A single fsync covers every mutation written before it
+// starts, so concurrent submitters are committed as a group.
+func (wb *writeBehindBuffer) syncThrough(seq uint64) error {
+	wb.syncMu.Lock()
+	defer wb.syncMu.Unlock()
+
+	if wb.synced >= seq {
+		return nil
+	}
+	target := wb.written.Load()
+	if err := wb.wal.Sync(); err != nil {
+		return err
+	}
+	if target > wb.synced {
+		wb.synced = target
+	}
+	return nil
+}
+
+// FlushNow applies every buffered mutation to the ledger immediately.
+func (l *Ledger) FlushNow() error {
+	l.writeBehindMu.RLock()
+	wb := l.writeBehind
+	l.writeBehindMu.RUnlock()
+
+	if wb == nil {
+		return nil
+	}
+	return l.flushWriteBehind(wb)
+}
+
+// runWriteBehind flushes wb every interval until it is stopped.
+func (l *Ledger) runWriteBehind(wb *writeBehindBuffer, interval time.Duration) {
+	defer close(wb.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.flushWriteBehind(wb); err != nil {
+				log.Printf("[ERROR] Write-behind flush failed: %v", err)
+			}
+		case <-wb.stop:
+			return
+		}
+	}
+}
+
+// flushWriteBehind applies wb's pending mutations under one ledger lock and
+// then truncates the write-ahead log. A mutation whose applier fails is
+// dropped and logged rather than retried, so it cannot block the mutations
+// behind it; the first such failure is returned.
+func (l *Ledger) flushWriteBehind(wb *writeBehindBuffer) error {
+	wb.mu.Lock()
+	defer wb.mu.Unlock()
+
+	if len(wb.pending) == 0 {
+		return nil
+	}
+
+	l.Lock()
+	var applyErr error
+	failed := 0
+	for _, m := range wb.pending {
+		if err := applyMutation(l, m); err != nil {
+			failed++
+			log.Printf("[ERROR] Dropping buffered %s mutation: %v", m.Kind, err)
+			if applyErr == nil {
+				applyErr = err
+			}
+		}
+	}
+	l.Unlock()
+
+	wb.pending = nil
+	if err := rewriteWriteBehindWAL(wb.wal, nil); err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+	wb.syncMu.Lock()
+	wb.synced = wb.written.Load()
+	wb.syncMu.Unlock()
+
+	if applyErr != nil {
+		return fmt.Errorf("failed to apply %d buffered mutations: %w", failed, applyErr)
+	}
+	return nil
+}
+
+// applyMutation runs the registered applier for m. The caller must hold the ledger lock.
+func applyMutation(l *Ledger, m LedgerMutation) error {
+	mutationAppliersMu.RLock()
+	apply, exists := mutationAppliers[m.Kind]
+	mutationAppliersMu.RUnlock()
+	if !exists {
+		return fmt.Errorf("no applier registered for mutation kind %q", m.Kind)
+	}
+	return apply(l, m.Payload)
+}
+
+// readWriteBehindWAL returns the mutations recorded in the log at path.
+func readWriteBehindWAL(path string) ([]LedgerMutation, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mutations []LedgerMutation
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var m LedgerMutation
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			// A torn final write from a crash; everything before it is intact.
+			log.Printf("[WARNING] Discarding unreadable write-ahead log entry: %v", err)
+			break
+		}
+		mutations = append(mutations, m)
+	}
+	return mutations, scanner.Err()
+}
+
+// rewriteWriteBehindWAL replaces the contents of wal with pending.
+func rewriteWriteBehindWAL(wal *os.File, pending []LedgerMutation) error {
+	if err := wal.Truncate(0); err != nil {
+		return err
+	}
+	for _, m := range pending {
+		line, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if _, err := wal.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return wal.Sync()
+}
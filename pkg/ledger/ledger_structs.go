@@ -76,6 +76,7 @@ type Account struct {
 	ExternalAccountID     string          // Link to an external account
 	Authorizations        []Authorization // List of authorizations for this account
 	VerificationHold      float64         // Holds funds for verification purposes
+	VerificationReason    string          // Reason the current verification hold was placed
 	LockedBalance         float64         // Balance that is locked
 	Approvals             []Approval      // List of approved transactions
 	LastTransactionID     string          // ID of the last transaction affecting this account
@@ -84,6 +85,9 @@ type Account struct {
 	LastUpdated           time.Time       // Last time the account was updated
 	RequiresReview        bool            // Indicates if the account is flagged for review
 	Allocations           []Allocation    // List of allocations for specific purposes
+	PreciseBalance        *big.Float      // High-precision balance kept in step with Balance
+	preciseBalanceOf      float64         // Balance value PreciseBalance was last synchronised with
+	CurrencyBalances      map[string]float64 // Balances held in currencies other than the native coin
 }
 
 type Pool struct {
@@ -97,6 +101,7 @@ type BalanceLock struct {
 	AccountID string    // The account to which this lock applies
 	Amount    float64   // Amount locked
 	UnlockAt  time.Time // Time when the lock can be released
+	Escrowed  bool      // Amount is held in LockedBalance rather than Balance; false on legacy locks
 }
 
 // BalanceStatus represents the current status of an account's balance.
@@ -604,10 +609,11 @@ type AnomalyEvent struct {
 
 // AggregationValidation represents the validation of an aggregation in the ledger.
 type AggregationValidation struct {
-	ID         string
-	Aggregator string
-	Validated  bool
-	Timestamp  time.Time
+	ID            string
+	Aggregator    string
+	Validated     bool
+	Timestamp     time.Time
+	Confirmations []string // Validators that have confirmed the aggregation
 }
 
 // NodeStatus represents the status of a node in the network.
@@ -642,6 +648,10 @@ type DetectionStatus struct {
 	DetectedAnomalies int       // Count of detected anomalies
 }
 
+// DetectionProbe checks a detection subsystem, returning the anomalies found since the last
+// probe or an error when the subsystem is not operational.
+type DetectionProbe func() (anomalies int, err error)
+
 // RateLimitingStatus represents the status of rate limiting for a node or API.
 type RateLimitingStatus struct {
 	NodeID       string    // Unique identifier for the node
@@ -651,6 +661,14 @@ type RateLimitingStatus struct {
 	Status       string    // Status of rate limiting (e.g., Active, Suspended)
 }
 
+// RateLimiter enforces per-node usage limits over a fixed window.
+type RateLimiter struct {
+	mu           sync.Mutex
+	Statuses     map[string]*RateLimitingStatus // Node ID -> current usage window
+	DefaultLimit int                            // Limit applied to nodes without their own
+	Window       time.Duration                  // Length of each usage window
+}
+
 // EventMonitoringStatus represents the status of monitoring events in the system.
 type EventMonitoringStatus struct {
 	MonitorID   string    // Unique identifier for the monitor
@@ -675,6 +693,13 @@ type ActiveSession struct {
 	Timeout       time.Duration // Session timeout duration
 }
 
+// SessionStore tracks active sessions and expires those left idle past their timeout.
+type SessionStore struct {
+	mu          sync.Mutex
+	Sessions    map[string]*ActiveSession // Session ID -> session
+	TimeoutLogs []SessionTimeoutLog       // Sessions expired for inactivity
+}
+
 // NodeAccessLimitPolicy defines the access limits for a node.
 type NodeAccessLimitPolicy struct {
 	NodeID          string      // Unique identifier for the node
@@ -731,6 +756,9 @@ type AlertResponse struct {
 	Responder       string    // Identifier of the responder
 }
 
+// AlertActionHandler executes a named response action against an alert.
+type AlertActionHandler func(alertID string) error
+
 // ThreatEvent represents an event related to a detected threat.
 type ThreatEvent struct {
 	EventID         string    // Unique identifier for the event
@@ -739,6 +767,7 @@ type ThreatEvent struct {
 	Description     string    // Detailed description of the event
 	AffectedSystems []string  // Systems affected by the threat
 	MitigationSteps []string  // Mitigation steps taken
+	CompletedSteps  []bool    // Whether each mitigation step has been executed
 }
 
 // IncidentStatus represents the status of an incident.
@@ -761,8 +790,11 @@ type HealthVerification struct {
 
 // SecurityPolicyRecord represents a record of a security level policy change.
 type SecurityPolicyRecord struct {
-	Policy    string
-	Timestamp string
+	Policy        string
+	Timestamp     string
+	PreviousLevel string // Security level before the change
+	Level         string // Security level after the change
+	ChangedBy     string // Who made the change
 }
 
 // ApplicationHardeningEvent records the status of application hardening.
@@ -821,6 +853,7 @@ type DataTransmission struct {
 
 type IncidentActivation struct {
 	IncidentID string
+	ProtocolID string // Incident protocol activated for this incident
 	Timestamp  string
 }
 
@@ -828,6 +861,7 @@ type IncidentReport struct {
 	IncidentID string
 	Details    string
 	Timestamp  string
+	Status     string // "Open" until a resolution is linked, then the resolution status
 }
 
 type IncidentResolution struct {
@@ -876,14 +910,17 @@ type TrafficPattern struct {
 
 type ProtocolDeviation struct {
 	DeviationID string
+	NodeID      string // Node whose behaviour deviated from the protocol
 	Description string
 	DetectedAt  time.Time
 }
 
 type APIUsageRecord struct {
-	APIID      string
-	UsageCount int
-	LastUsedAt time.Time
+	APIID         string
+	UsageCount    int
+	LastUsedAt    time.Time
+	WindowStart   time.Time // Start of the current quota window
+	PreviousCount int       // Calls counted in the window before WindowStart
 }
 
 type ConsensusAnomaly struct {
@@ -908,6 +945,7 @@ type IncidentProtocol struct {
 
 type IncidentEvent struct {
 	EventID     string
+	IncidentID  string // Incident the event belongs to
 	Description string
 	RecordedAt  time.Time
 	Resolution  string
@@ -928,15 +966,19 @@ type ComplianceAudit struct {
 }
 
 type EscalationProtocol struct {
-	ProtocolID  string
-	Description string
-	SetAt       time.Time
+	ProtocolID   string
+	Description  string
+	SetAt        time.Time
+	Stages       [][]string // Actions to perform at each escalation stage, in order
+	CurrentStage int        // Next stage to be executed
 }
 
 type IsolationIncident struct {
 	IncidentID  string
+	EntityID    string // Node, account or service placed in quarantine
 	Description string
 	IsolatedAt  time.Time
+	ReleasedAt  *time.Time // Set once the entity has been released from quarantine
 }
 
 // Supporting structs for threats, activities, rate limits, health metrics, and maintenance events
@@ -973,15 +1015,20 @@ type HealthMetric struct {
 }
 
 type MaintenanceEvent struct {
-	EventID     string
-	Description string
-	PerformedAt time.Time
+	EventID        string
+	Description    string
+	PerformedAt    time.Time
+	ScheduledStart time.Time // Start of the maintenance window
+	ScheduledEnd   time.Time // End of the maintenance window
+	Completed      bool      // Whether the maintenance has been completed
 }
 
 type HealthEvent struct {
 	EventID     string
 	Description string
 	RecordedAt  time.Time
+	Severity    string  // One of HealthSeverityHealthy, HealthSeverityDegraded or HealthSeverityCritical
+	Score       float64 // Health score derived from Severity, from 0 (failing) to 1 (healthy)
 }
 
 type BackupEvent struct {
@@ -1002,6 +1049,15 @@ type MigrationCompliance struct {
 	RecordedAt       time.Time
 }
 
+// MigrationDetails describes how a migration handles data, for compliance checks.
+type MigrationDetails struct {
+	Description        string // Free-text summary; recorded but never matched against rules
+	EncryptedInTransit bool   // Data is encrypted while it is moved
+	PlaintextPII       bool   // Personal data is moved or stored unencrypted
+	PurgesHistory      bool   // Historical records are dropped by the migration
+	AuditTrail         bool   // The migration writes an audit trail
+}
+
 type AccessLimit struct {
 	NodeID      string
 	MaxAccesses int
@@ -1009,9 +1065,10 @@ type AccessLimit struct {
 }
 
 type AccessFrequency struct {
-	AccessID   string
-	Frequency  int
-	LastAccess time.Time
+	AccessID    string
+	Frequency   int
+	LastAccess  time.Time
+	WindowStart time.Time // Start of the window Frequency is counted over
 }
 
 type SecurityLevelPolicy struct {
@@ -1054,6 +1111,7 @@ type AlertManager struct {
 	AcknowledgedBy         string           // Identifier of the entity that acknowledged the alert.
 	AcknowledgedAt         time.Time        // Timestamp of when the alert was acknowledged.
 	EscalationPolicy       EscalationPolicy // Policy for escalating unacknowledged alerts.
+	LastEscalatedAt        time.Time        // When the alert was raised or last escalated.
 	AlertLogs              []AlertLog       // Logs for tracking alert lifecycle and actions.
 }
 
@@ -1433,9 +1491,31 @@ type MultiSigWallet struct {
 	WalletID     string
 	Owners       []string
 	RequiredSigs int
+	OwnerKeys    map[string]*ecdsa.PublicKey // Registered signing key per owner
 	CreatedAt    time.Time
 }
 
+// MultiSigProposal is a transaction awaiting owner signatures on a multi-signature wallet.
+type MultiSigProposal struct {
+	ProposalID string
+	WalletID   string
+	Tx         Transaction
+	Proposer   string
+	Signatures map[string][]byte // Owner -> signature
+	Executed   bool
+	CreatedAt  time.Time
+	ExecutedAt time.Time
+}
+
+// MultiSigSignature records an owner's signature on a multi-signature proposal.
+type MultiSigSignature struct {
+	WalletID   string
+	ProposalID string
+	Owner      string
+	Signature  []byte
+	SignedAt   time.Time
+}
+
 type RoleAssignment struct {
 	AssignmentID string
 	Role         string
@@ -1569,6 +1649,7 @@ type PrunedBlockchain struct {
 	Ledger            *Ledger           // Reference to the ledger to ensure consistency across pruned and full chains
 	SnapshotTimestamp time.Time         // Timestamp of the last snapshot taken before pruning
 	ValidationHash    string            // Hash representing the current state of the pruned blockchain for validation
+	PruneBoundaries   []int             // Pruned block height after each prune, used to replay ValidationHash
 }
 
 // BlockListener represents an entity listening to block-related events.
@@ -1752,6 +1833,7 @@ type ConsensusState struct {
 	ValidatorPunishments map[string]Punishment // Punishments applied to validators, mapped by validator ID
 	ValidatorRewards     map[string]float64    // Rewards assigned to validators, mapped by validator ID
 	MinerRewards         map[string]float64    // Rewards assigned to miners, mapped by miner ID
+	BlockRewards         map[int]float64       // PoW reward issued at each block height
 	ParticipantRewards   map[string]float64    // Rewards assigned to non-validator participants, mapped by participant ID
 	SubBlockCount        int                   // Number of sub-blocks processed
 	FinalizedSubBlocks   []SubBlock            // List of finalized sub-blocks
@@ -2024,11 +2106,16 @@ type BugReport struct {
 
 // AMLSystem defines the Anti-Money Laundering (AML) system
 type AMLSystem struct {
-	SuspiciousActivityThreshold float64           // Threshold for suspicious activity
-	BlockedWallets              map[string]bool   // List of blocked wallets
-	ReportedTransactions        map[string]string // Map of reported transactions
-	LedgerInstance              *Ledger           // Instance of the ledger for transaction logging
-	mutex                       sync.Mutex        // Mutex for thread-safe operations
+	SuspiciousActivityThreshold float64                // Threshold for suspicious activity
+	BlockedWallets              map[string]bool        // List of blocked wallets
+	ReportedTransactions        map[string]string      // Map of reported transactions
+	BlockReasons                map[string]string      // Reason each wallet was blocked
+	StructuringWindow           time.Duration          // Window in which near-threshold transfers are counted (zero uses the default)
+	StructuringCount            int                    // Near-threshold transfers within the window that indicate structuring (zero uses the default)
+	AutoBlock                   bool                   // Whether the sender of a flagged transaction is blocked automatically
+	LedgerInstance              *Ledger                // Instance of the ledger for transaction logging
+	nearThreshold               map[string][]time.Time // Sender -> times of recent near-threshold transfers
+	mutex                       sync.Mutex             // Mutex for thread-safe operations
 }
 
 // AuditTrailEntry represents a single entry in the audit trail
@@ -2636,12 +2723,14 @@ type KYCRecord struct {
 	VerifiedAt   time.Time // Timestamp of verification
 	DataHash     string    // Hash of KYC data
 	EncryptedKYC []byte
+	ExpiresAt    time.Time // Time after which the KYC must be refreshed
 }
 
 // KYCManager handles KYC verification and maintains records
 type KYCManager struct {
 	Records        map[string]KYCRecord // Stores KYC records by UserID
 	LedgerInstance *Ledger              // Reference to the ledger for recording KYC actions
+	Validity       time.Duration        // How long a verification stays valid (zero uses the default)
 	mutex          sync.Mutex           // Mutex for thread-safe operations
 }
 
@@ -2950,7 +3039,8 @@ type DAORecord struct {
 	Proposals        map[string]DAOProposal
 	Transactions     map[string]TransactionRecord
 	GovernanceStakes map[string]float64
-	RoleAssignments  map[string]string // Maps members to roles
+	RoleAssignments  map[string]string          // Maps members to roles
+	ProposalVotes    map[string]map[string]bool // Votes per proposal, keyed by member (true approves)
 }
 
 // DAO represents a decentralized autonomous organization on the blockchain.
@@ -3134,6 +3224,16 @@ type InsurancePolicy struct {
 	ClaimFee       float64
 }
 
+// InsurancePayout records the payout of an approved insurance claim.
+type InsurancePayout struct {
+	ClaimID   string
+	PolicyID  string
+	Recipient string
+	Amount    float64 // Amount paid to the recipient
+	Fee       float64 // Claim fee deducted from the claim amount
+	PaidAt    time.Time
+}
+
 type YieldFarmingRecord struct {
 	RecordID      string    // Unique identifier for the record
 	ParticipantID string    // ID of the participant
@@ -3193,6 +3293,7 @@ type YieldFarmPool struct {
     APY              float64
     IsLocked         bool
     LastDistributed  time.Time
+    Earnings         map[string]*YieldFarmEarning // UserID -> accrued earnings
 }
 
 type YieldFarmEarning struct {
@@ -3950,6 +4051,12 @@ type ExceptionLogEntry struct {
 	Timestamp   time.Time
 }
 
+// ExceptionPattern counts logged exceptions sharing a normalized description.
+type ExceptionPattern struct {
+	Pattern string
+	Count   int
+}
+
 type InterruptHandler struct {
 	ID      string
 	Handler func() error
@@ -4035,15 +4142,40 @@ type BlockchainParameter struct {
 	Value string
 }
 
+// ParameterSpec describes the type and allowed range of a governed blockchain parameter.
+type ParameterSpec struct {
+	Name    string  // Parameter name
+	Kind    string  // Value type: "int" or "float"
+	Min     float64 // Smallest allowed value
+	Max     float64 // Largest allowed value
+	Default string  // Value reported before the parameter is first set
+}
+
+// ParameterChange records an update to a governed blockchain parameter.
+type ParameterChange struct {
+	Name      string
+	OldValue  string
+	NewValue  string
+	ChangedBy string
+	Timestamp time.Time
+}
+
 // NetworkTrafficReport represents a report on network traffic.
 type NetworkTrafficReport struct {
-	Report    string
-	Timestamp time.Time
+	Report           string
+	Timestamp        time.Time
+	From             time.Time // Start of the reporting window
+	To               time.Time // End of the reporting window
+	TotalRequests    int       // Requests observed in the window
+	PeakRequestRate  float64   // Highest request rate reported by any source
+	PeakTransferMBps int       // Highest data transfer rate in the window
+	TopSources       []string  // Source IPs with the most requests, busiest first
 }
 
 // BlockValidationResult represents the result of block validation.
 type BlockValidationResult struct {
 	BlockID   string
+	Hash      string // Hash of the block contents that were validated
 	IsValid   bool
 	Timestamp time.Time
 }
@@ -4051,9 +4183,10 @@ type BlockValidationResult struct {
 // ProcessState represents the state of a specific process in the system.
 type ProcessState struct {
 	ProcessID  string
-	Status     string        // e.g., "paused", "running"
+	Status     string        // e.g., "paused", "running", "terminated"
 	Timeout    time.Duration // Timeout duration for the process.
 	LastUpdate time.Time     // Last update timestamp.
+	Elapsed    time.Duration // Running time accumulated up to LastUpdate.
 }
 
 // SystemLockState represents the lock status of the system.
@@ -4076,6 +4209,15 @@ type MaintenanceLog struct {
 	Timestamp   time.Time
 }
 
+// DeferredOperation is an operation held back by a maintenance window and replayed when
+// that maintenance completes.
+type DeferredOperation struct {
+	Name       string
+	EventID    string       // Maintenance event the operation is waiting on
+	DeferredAt time.Time
+	Run        func() error `json:"-"`
+}
+
 // RecoveryEvent represents a recovery-related event for logging in the ledger.
 type RecoveryEvent struct {
 	EventID   string
@@ -4086,6 +4228,7 @@ type RecoveryEvent struct {
 // DiagnosticEvent represents a diagnostic-related event for logging in the ledger.
 type DiagnosticEvent struct {
 	EventID   string
+	ContextID string // Empty for system-wide events
 	EventType string
 	Timestamp time.Time
 }
@@ -4108,6 +4251,7 @@ type EmergencyOverride struct {
 // PanicStatus represents the panic status for system assessment.
 type PanicStatus struct {
 	Status    bool
+	Message   string // Recovered panic value
 	Timestamp time.Time
 }
 
@@ -4169,10 +4313,17 @@ type SystemEvent struct {
 // HookRecord represents a registered system hook in the ledger.
 type HookRecord struct {
 	EventID   string
+	HandlerID string // Identifies a registered handler; empty for ledger-only records
 	Priority  int
 	Timestamp time.Time
 }
 
+// registeredHook pairs a hook record with the handler it invokes.
+type registeredHook struct {
+	HookRecord
+	fn func(map[string]interface{}) error
+}
+
 // RoleRecord represents a system role and its permissions stored in the ledger.
 type RoleRecord struct {
 	RoleName    string
@@ -4234,12 +4385,13 @@ type SystemManager struct {
 
 // OverrideManager handles override configurations and operations.
 type OverrideManager struct {
-	OverrideID       string            // Unique identifier for the override instance.
-	OverrideRules    map[string]string // Rules for override configurations.
-	AppliedOverrides []string          // List of overrides currently applied.
-	LastOverrideTime time.Time         // Timestamp of the last override applied.
-	IsOverrideActive bool              // Indicates if the override is active.
-	AuditTrail       []string          // Logs of override actions.
+	OverrideID       string               // Unique identifier for the override instance.
+	OverrideRules    map[string]string    // Rules for override configurations.
+	AppliedOverrides []string             // List of overrides currently applied.
+	LastOverrideTime time.Time            // Timestamp of the last override applied.
+	IsOverrideActive bool                 // Indicates if the override is active.
+	AuditTrail       []string             // Logs of override actions.
+	AppliedAt        map[string]time.Time // Rule ID -> time the override was applied.
 }
 
 // OperationManager manages system-level operations and processes.
@@ -4251,6 +4403,14 @@ type OperationManager struct {
 	QueueStatus         []string          // Status of operations in the queue.
 	SchedulerPolicies   map[string]string // Scheduling policies for operations.
 	LastOperationTime   time.Time         // Timestamp of the last operation execution.
+	queue               []queuedOperation // Operations waiting to run, in enqueue order.
+}
+
+// queuedOperation is an operation waiting in an OperationManager queue.
+type queuedOperation struct {
+	ID       string
+	Priority int
+	Run      func() error
 }
 
 // AutomationManager facilitates and monitors automation processes.
@@ -4418,9 +4578,10 @@ type ReputationVoting struct {
 
 // GovernanceRecord holds governance-specific data for the ledger.
 type GovernanceRecord struct {
-	Proposals       map[string]GovernanceProposal // Governance proposals
-	TransactionFees map[uint64]float64            // Tracks transaction fees per block
-	Delegations     map[string]string             // Tracks delegations from one user to another
+	Proposals         map[string]GovernanceProposal // Governance proposals
+	TransactionFees   map[uint64]float64            // Tracks transaction fees per block
+	TransactionCounts map[uint64]int                // Number of fees recorded per block
+	Delegations       map[string]string             // Tracks delegations from one user to another
 }
 
 // ************** High Availability Structs **************
@@ -4635,6 +4796,8 @@ type Snapshot struct {
 // SnapshotStatus represents the current status of ongoing snapshots.
 type SnapshotStatus struct {
 	ActiveSnapshots  int
+	MaxConcurrent    int                  // Limit on ActiveSnapshots; zero uses the default
+	InFlight         map[string]time.Time // Snapshot ID -> start time for running snapshots
 	LastSnapshotID   string
 	LastSnapshotTime time.Time
 }
@@ -4729,11 +4892,13 @@ type DisasterRecoveryBackup struct {
 type WriteAheadLogConfig struct {
 	Enabled       bool
 	RetentionDays int
+	Path          string // File backing buffered ledger mutations
 }
 
 // LogRetentionConfig represents the configuration for log retention.
 type LogRetentionConfig struct {
-	RetentionPeriod int
+	RetentionPeriod int               // Days to keep log entries
+	LegalHolds      map[string]string // Held log entry or stream ID -> reason; never pruned
 	ConfiguredAt    time.Time
 }
 
@@ -4788,6 +4953,7 @@ type SnapshotDetails struct {
 type FailoverGroup struct {
 	GroupID     string
 	Members     []string
+	Primary     string // Member currently serving as primary
 	Policy      string
 	LastUpdated time.Time
 }
@@ -4802,6 +4968,9 @@ type HAProxyConfig struct {
 // ResourcePool represents the resource pooling configuration.
 type ResourcePool struct {
 	Enabled     bool
+	Capacity    ResourceRequirements            // Total resources the pool can hand out
+	Allocated   ResourceRequirements            // Resources currently reserved
+	Allocations map[string]ResourceRequirements // Allocation ID -> reserved resources
 	LastUpdated time.Time
 }
 
@@ -4846,9 +5015,12 @@ type HighAvailabilityConfig struct {
 
 // ClusterPolicy represents a clustering policy with configuration details.
 type ClusterPolicy struct {
-	PolicyName  string
-	Rules       string
-	LastUpdated time.Time
+	PolicyName           string
+	Rules                string
+	MaxNodes             int      // Maximum cluster size; zero means unlimited
+	MinQuorum            int      // Healthy nodes required; zero means a majority
+	RequiredCapabilities []string // Capabilities every joining node must have
+	LastUpdated          time.Time
 }
 
 // HeartbeatConfig stores configuration for heartbeat monitoring.
@@ -4902,10 +5074,20 @@ type DeduplicationConfig struct {
 
 // StandbyConfig stores settings for standby modes.
 type StandbyConfig struct {
-	Mode        string // "hot", "cold", or "none"
-	Policy      string
-	IsEnabled   bool
-	LastUpdated time.Time
+	Mode         string // "hot", "cold", or "none"
+	Policy       string
+	IsEnabled    bool
+	WarmUpPeriod time.Duration // Time a cold standby needs before it can serve
+	LastUpdated  time.Time
+}
+
+// StandbyNode tracks a standby node that has been brought online.
+type StandbyNode struct {
+	NodeID          string
+	Mode            string    // "hot" or "cold"
+	ActivatedAt     time.Time // When activation began
+	ReadyAt         time.Time // When the node can serve traffic
+	ReplacedPrimary string    // Primary the standby took over from
 }
 
 // SelfHealingConfig represents self-healing settings and their intervals.
@@ -4925,10 +5107,15 @@ type ArchivedData struct {
 
 // SimulationResult represents simulation results stored in the ledger.
 type SimulationResult struct {
-	ID        string
-	Results   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           string
+	Results      string
+	Mode         string        // Simulation mode the scenario ran under
+	Parameters   string        // Simulation parameters the scenario ran with
+	Succeeded    bool          // Whether the scenario recovered without error
+	Error        string        // Failure reported by the scenario, if any
+	RecoveryTime time.Duration // Time the scenario took to recover
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 // ResourceQuotaConfig represents resource quota settings.
@@ -8982,12 +9169,6 @@ type TransactionVolumeLog struct {
     Timestamp time.Time
 }
 
-// ContractExecutionLog struct for logging contract execution metrics
-type ContractExecutionLog struct {
-    Metrics   map[string]interface{}
-    Timestamp time.Time
-}
-
 // FunctionExecutionTimeLog struct for tracking function execution times
 type FunctionExecutionTimeLog struct {
     ExecutionTimes map[string]time.Duration
@@ -14364,6 +14545,9 @@ type CurrencyExchange struct {
 	ExchangedAmount *big.Int  // Amount received after the exchange
 	ExchangeRate    float64   // Exchange rate applied
 	ExecutedAt      time.Time // Timestamp of the exchange
+	Quantity        float64   // Amount debited in the source currency
+	Received        float64   // Amount credited in the target currency after fees
+	Fee             float64   // Fee deducted in the target currency
 }
 
 // ConnectionEvent represents an event related to wallet connections.
@@ -14374,6 +14558,8 @@ type ConnectionEvent struct {
 	EventType    string    // Type of event (e.g., "connection", "disconnection")
 	EventTime    time.Time // Timestamp of the event
 	Details      string    // Additional details related to the event
+	IPAddress    string    // IP address the connection originated from
+	Device       string    // Device identifier used for the connection
 }
 
 // MintRecord represents a record of token minting.
@@ -14383,6 +14569,7 @@ type MintRecord struct {
 	Amount    *big.Int  // Amount of tokens minted
 	MintedBy  string    // Address of the entity that minted the tokens
 	Timestamp time.Time // Timestamp of the minting
+	Quantity  float64   // Amount credited to the account balance
 }
 
 // BurnRecord represents a record of token burning.
@@ -14392,17 +14579,21 @@ type BurnRecord struct {
 	Amount    *big.Int  // Amount of tokens burned
 	BurnedBy  string    // Address of the entity that burned the tokens
 	Timestamp time.Time // Timestamp of the burning
+	Quantity  float64   // Amount debited from the account balance
 }
 
 // ContractExecutionLog represents a log of a smart contract execution.
 type ContractExecutionLog struct {
-	LogID         string    // Unique identifier for the log
-	ContractID    string    // ID of the contract being executed
-	ExecutedBy    string    // Address of the entity executing the contract
-	ExecutionTime time.Time // Timestamp of the execution
-	InputData     string    // Input data for the contract execution
-	OutputData    string    // Output data from the contract execution
-	Status        string    // Status of the execution (e.g., "success", "failure")
+	LogID         string                 // Unique identifier for the log
+	ContractID    string                 // ID of the contract being executed
+	ExecutedBy    string                 // Address of the entity executing the contract
+	ExecutionTime time.Time              // Timestamp of the execution
+	InputData     string                 // Input data for the contract execution
+	OutputData    string                 // Output data from the contract execution
+	Status        string                 // Status of the execution (e.g., "success", "failure")
+	GasUsed       int                    // Gas consumed by the execution
+	Metrics       map[string]interface{} // Performance metrics captured for the execution
+	Timestamp     time.Time              // Time the metrics were recorded
 }
 
 // ************** ZK-Proof Structs **************
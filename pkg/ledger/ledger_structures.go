@@ -48,6 +48,8 @@ type Ledger struct {
 	UtilityLedger                 UtilityLedger                 // System utilities, emergency protocols, and maintenance tools
 	VirtualMachineLedger          VirtualMachineLedger          // VM resource tracking, execution states, and fault recovery
 	RollupLedger				  RollupLedger
+	writeBehindMu                 sync.RWMutex                  // Guards writeBehind
+	writeBehind                   *writeBehindBuffer            // Buffered mutations awaiting a batched flush, if enabled
 }
 
 
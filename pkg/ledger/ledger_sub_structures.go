@@ -27,9 +27,12 @@ type AccountsWalletLedger struct {
 	EncryptedKeys             map[string][]byte              // Encrypted keys
 	WalletBalances            map[string]*big.Int            // Wallet balances
 	Mnemonic                  map[string][]Mnemonic
-	Identities                map[string]Identity      // Map each wallet ID to a single Identity
-	MultiSigWallets           MultiSigWallets          // Multi-signature wallet data
-	SYN900tokens              tokenledgers.SYN900Token // SYN900 token mappings
+	Identities                map[string]Identity          // Map each wallet ID to a single Identity
+	MultiSigWallets           map[string]MultiSigWallet    // Multi-signature wallets by wallet ID
+	MultiSigProposals         map[string]*MultiSigProposal // Multi-signature transaction proposals by proposal ID
+	MultiSigSignatures        []MultiSigSignature          // Every signature collected on a multi-signature proposal
+	SYN900tokens              tokenledgers.SYN900Token     // SYN900 token mappings
+	ExchangeFeeRate           float64                      // Fraction of converted amounts charged on currency exchanges
 }
 
 type AccountsWalletLedgerState struct {
@@ -61,20 +64,29 @@ type AdvancedSecurityLedger struct {
 	sync.Mutex
 	ThreatDetectionStatus                    map[string]ThreatDetectionStatus // Threat detection statuses
 	ThreatLevels                             map[string]int                   // Identified threat levels
+	ThreatLevelHistory                       []ThreatLevel                    // Recorded threat level transitions
+	ThreatLevelThresholds                    map[int]string                   // Threat level thresholds mapped to mitigation plan IDs
 	SecurityThresholds                       map[string]int                   // Security thresholds
 	IncidentProtocols                        map[string]IncidentProtocol      // Incident response protocols
 	IntrusionDetectionStatus                 map[string]DetectionStatus       // Intrusion detection statuses
+	DetectionSystems                         map[string]DetectionStatus       // Operational status of each probed detection system
+	DetectionProbes                          map[string]DetectionProbe        // Probes used to check detection systems
 	DetectedThreats                          map[string]ThreatDetails         // Detected threats
 	SuspiciousActivityLog                    []SuspiciousActivityRecord       // Suspicious activity logs
 	UnauthorizedAccessRecords                map[string]UnauthorizedAccess    // Unauthorized access records
 	AnomalyEvents                            map[string][]AnomalyEvent        // Detected anomalies
+	anomalySubscribers                       map[int]*anomalySubscription     // Real-time anomaly event subscribers
+	nextAnomalySubscriberID                  int                              // ID assigned to the next anomaly subscriber
 	IntegrityViolations                      map[string]string                // Integrity violation records
 	IncidentEvents                           map[string]IncidentEvent         // Incident events
 	SystemThreatLevels                       map[string]string                // System-wide threat levels
 	ConsensusAnomalies                       map[string]ConsensusAnomaly      // Consensus anomalies
+	CorrelatedAnomalies                      map[string]string                // Consensus anomaly IDs mapped to the incident they were correlated into
+	AnomalyCorrelationThreshold              int                              // Related anomalies needed to raise an incident
 	RateLimitPolicies                        map[string]RateLimitPolicy       // Rate limit policies
 	RateLimitingStatus                       map[string]RateLimitingStatus    // Rate limiting statuses
 	APILimits                                map[string]int                   // API rate limits
+	APIUsageWindow                           time.Duration                    // Rolling window over which API quotas apply
 	HealthMetrics                            map[string]HealthMetric          // System health metrics
 	SecurityAudits                           []SecurityAudit                  // Security audit records
 	SecurityProfiles                         map[string]SecurityProfile       // Security profiles
@@ -86,7 +98,12 @@ type AdvancedSecurityLedger struct {
 	MetricsData                              map[string]MitigationMetrics     // Metrics for mitigation
 	NodeAccessLimits                         map[string]NodeAccessLimitPolicy // Node access limits
 	AccessFrequencies                        map[string]AccessFrequencyPolicy // Access frequency policies
+	NodeAccessQuotas                         map[string]AccessLimit           // Maximum accesses allowed per node within AccessLimitWindow
+	NodeAccessCounts                         map[string]AccessFrequency       // Accesses counted per node in the current window
+	AccessLimitWindow                        time.Duration                    // Window over which node access limits apply
 	SecurityPolicies                         []SecurityPolicyRecord           // Security policy records
+	SecurityLevels                           map[string]SecurityLevelPolicy   // Current security level per policy
+	DisabledOperations                       map[string]string                // Operations disabled by security level, mapped to the policy disabling them
 	AccessLogs                               map[string]AccessLog             // Access logs
 	FirmwareCheckStatus                      map[string]FirmwareCheckStatus   // Firmware check statuses
 	ConsensusAnomalyDetectionStatus          map[string]DetectionStatus       // Consensus anomaly detection statuses
@@ -94,17 +111,22 @@ type AdvancedSecurityLedger struct {
 	EventLogs                                map[string]EventLog              // Event logs
 	SessionTimeoutLogs                       map[string]SessionTimeoutLog     // Session timeout logs
 	IsolationIncidents                       map[string]IsolationIncident     // Isolation incidents
+	IsolatedEntities                         map[string]string                // Quarantined entity IDs mapped to their isolation incident
 	ApplicationHardeningEvents               map[string]HardeningEvent        // Application hardening events
 	AlertStatusLogs                          map[string]AlertStatusLog        // Alert status logs
 	HealthStatusLogs                         map[string]HealthStatusLog       // Health status logs
+	HealthEvents                             []HealthEvent                    // Scored health events in recording order
+	HealthTrendWindow                        time.Duration                    // Window over which degradation alerts evaluate the health trend
 	HealthLog                                map[string]HealthLog             // General health log
 	APILog                                   map[string]APIUsageRecord        // API usage records
 	TrafficPatterns                          map[string]TrafficPattern        // Network traffic patterns
 	ProtocolDeviations                       map[string]ProtocolDeviation     // Protocol deviations
+	NodeDeviationCounts                      map[string]int                   // Protocol deviations detected per node
 	AnomalyAlertLevel                        map[string]int                   // Anomaly alert levels
 	AlertTimestamp                           map[string]time.Time             // Alert timestamps
 	NetworkAlerts                            map[string]Alert                 // Network-wide alerts
 	AlertResponses                           map[string]AlertResponse         // Alert response records
+	AlertActionHandlers                      map[string]AlertActionHandler    // Handlers for alert response actions, by action name
 	TrafficAnomalies                         map[string]TrafficAnomaly        // Traffic anomalies
 	ThreatEvents                             map[string]ThreatEvent           // Threat events
 	SecurityThreshold                        map[string]int                   // Security thresholds
@@ -117,6 +139,7 @@ type AdvancedSecurityLedger struct {
 	DDoSScore                                map[string]float64               // DDoS threat scores
 	IncidentReports                          map[string]IncidentReport        // Incident reports
 	RetentionPolicies                        map[string]RetentionPolicy       // Retention policies
+	SystemAlerts                             map[string]SystemAlert           // System alerts raised by security checks
 	IncidentActivations                      map[string]IncidentActivation    // Incident activation records
 	HealthThreshold                          map[string]int                   // Health thresholds
 	HealthThresholdTimestamp                 map[string]time.Time             // Health threshold timestamps
@@ -163,6 +186,7 @@ type AiMLMLedger struct {
 	DecryptionLogs      map[string]DecryptionLog            // Decryption logs
 	Checkpoints         map[string]ModelCheckpoint          // Model checkpoints
 	CacheRecords        map[string]CacheData                // Cache records
+	RecommendationKey   []byte                              // AES key for cached recommendations
 	DataTransfers       map[string][]DataBlock              // Data transfers
 	CustomFunctions     map[string][]CustomFunction         // Custom functions
 	AnalyticsTools      map[string][]AnalyticsConfig        // Analytics tools
@@ -228,6 +252,8 @@ type AuthorizationLedger struct {
 	TimeBasedAuthorizations   map[string]TimeBasedAuthorization  // Time-based authorizations
 	SignerPriorities          map[string]SignerPriority          // Priority levels for signers
 	RoleManager               RoleManager                        // Manages user roles, permissions, and role hierarchies.
+	Roles                     map[string]*RoleManager            // Registered roles by role ID
+	RoleAssignmentLogs        []RoleAssignmentLog                // History of user assignments to roles
 	AccessManager             AccessManager                      // Handles access control policies, rights, and restrictions.
 
 }
@@ -235,106 +261,107 @@ type AuthorizationLedger struct {
 // BlockchainConsensusCoinLedger manages consensus mechanisms, rewards, and staking.
 type BlockchainConsensusCoinLedger struct {
 	sync.Mutex
-	BlockchainConsensusCoinState      BlockchainConsensusCoinState    // State information of the consensus mechanism
-	Blocks                            []Block                         // List of blocks
-	SubBlocks                         []SubBlock                      // List of sub-blocks
-	FinalizedBlocks                   []Block                         // List of finalized blocks
-	RejectedBlocks                    []Block                         // List of rejected blocks
-	ConsensusState                    ConsensusState                  // Current consensus state
-	SynthronBalance                   float64                         // Total system balance
-	BlockIndex                        int                             // Block creation index
-	ValidatorBansEnabled              bool                            // Validator banning flag
-	ValidatorPunishments              map[string][]PunishmentRecord   // Validator punishments
-	ValidatorRewardRecords            map[string][]RewardRecord       // Validator rewards
-	ValidatorPenalties                map[string]ValidatorPenalty     // Validator penalties
-	EpochLogs                         []EpochLog                      // Epoch logs
-	ConsensusHealthLogs               []HealthLog                     // Consensus health logs
-	ConsensusMechanisms               map[string]*ConsensusMechanism  // Consensus mechanisms
-	StrategyHops                      map[string]*ConsensusStrategy   // Consensus strategies
-	ConsensusLayers                   map[string]*ConsensusLayer      // Consensus layers
-	CollaborationNodes                map[string]*CollaborationNode   // Collaboration nodes
-	ConsensusThresholds               map[string]int                  // Consensus thresholds
-	ConsensusAnomalyDetectionStatus   string                          // Consensus anomaly detection status
-	ValidatorSelectionMode            ValidatorSelectionMode          // Validator selection mode
-	RewardDistributionMode            RewardDistributionMode          // Reward distribution mode
-	AdaptiveDifficulty                int                             // Adaptive difficulty level
-	StakeChanges                      []StakeChange                   // Stake changes
-	StakeLogs                         []StakeLog                      // Stake logs
-	ValidatorActivityLogs             []ValidatorActivityLog          // Validator activity logs
-	ValidatorRewardHistory            map[string][]RewardRecord       // Validator reward history
-	ConsensusAuditLogs                []ConsensusAuditLog             // Consensus audit logs
-	FinalityCheckLogs                 []FinalityCheckLog              // Finality check logs
-	ValidatorStakes                   map[string]float64              // Validator stakes
-	BlockLimit                        int                             // Limit for block generation
-	PunitiveMeasureLogs               []PunitiveMeasureRecord         // Logs of punitive measures
-	PunishmentReevaluationInterval    time.Duration                   // Interval for reevaluating punishments
-	PunishmentAdjustmentLogs          []PunishmentAdjustmentLog       // Logs for punitive measure adjustments
-	AdaptiveRewardDistributionEnabled bool                            // Flag for adaptive reward distribution
-	DifficultyAdjustmentLogs          []DifficultyAdjustmentLog       // Logs for difficulty adjustments
-	BlockGenerationLogs               []BlockGenerationLog            // Logs for block generation
-	PoHParticipationThreshold         float64                         // Threshold for Proof of History participation
-	DynamicStakeAdjustment            bool                            // Flag for dynamic stake adjustments
-	ConsensusMonitoringEnabled        bool                            // Flag for monitoring consensus
-	FinalityCheckEnabled              bool                            // Flag for enabling finality checks
-	EpochTimeout                      time.Duration                   // Epoch timeout duration
-	ReinforcementPolicy               ReinforcementPolicy             // Reinforcement policy
-	RedundantValidation               bool                            // Flag for redundant validation
-	BannedValidators                  map[string]ValidatorBanRecord   // Banned validator records
-	AutoPunishmentRate                float64                         // Automatic punishment escalation rate
-	ValidatorReinforcementEnabled     bool                            // Validator reinforcement mechanism flag
-	PoHValidationWindow               time.Duration                   // Duration of PoH validation window
-	PoHValidationLogs                 []PoHLog                        // Logs for PoH validations
-	PoHFailureThreshold               int                             // Threshold for PoH validation failures
-	PoWHalvingEnabled                 bool                            // Flag for enabling PoW reward halving
-	PoWHalvingInterval                time.Duration                   // Interval for PoW halving
-	SubblockCapacity                  int                             // Maximum capacity for sub-blocks
-	SubblockCapacities                map[string]int                  // Current sub-block capacity usage
-	SubblockCapacityHistory           map[string][]int                // Sub-block capacity history
-	SynthronCoinDenomination          string                          // Current denomination of Synthron Coin
-	CoinDenominationHistory           map[string]string               // Historical denomination changes
-	SubblockCacheLimit                int                             // Cache limit for sub-blocks
-	BlockCompressionEnabled           bool                            // Block compression flag
-	BlockCompressionLevel             int                             // Block compression level
-	EncryptionEnabled                 bool                            // Encryption flag
-	EncryptionKey                     string                          // Encryption key
-	SubblockCompressionEnabled        bool                            // Sub-block compression flag
-	SubblockValidationCriteria        string                          // Validation criteria for sub-blocks
-	ValidationInterval                time.Duration                   // Validation interval
-	BlockTransactionLimit             int                             // Transaction limit per block
-	RejectedTransactions              map[string]Transaction          // List of rejected transactions
-	BlockListeners                    map[string]BlockListener        // List of block listeners
-	Transactions                      map[string]TransactionRecord    // Tracks all transactions
-	TransactionCache                  map[string]Transaction          // Cache of pending transactions
-	ReversalRequests                  map[string]ReversalRequest      // Transaction reversal requests
-	EscrowTransactions                map[string]EscrowTransaction    // Escrow transactions
-	PrivateTransactions               map[string]PrivateTransaction   // Private transactions
-	PendingTransactions               []*Transaction                  // List of pending transactions
-	TransactionPool                   *TransactionPool                // Manages pending and unconfirmed transactions
-	FeeManager                        *FeeManager                     // Manages transaction fees
-	ReversalManager                   *TransactionReversalManager     // Manages transaction reversals
-	PrivateTransactionManager         *PrivateTransactionManager      // Manages private transactions
-	CancellationManager               *TransactionCancellationManager // Handles transaction cancellations
-	TransactionDistributionManager    *TransactionDistributionManager // Distributes fees across nodes
-	CancellationRequests              map[string]*CancellationRequest // Stores transaction cancellation requests
-	TransactionThreshold              int                             // Transaction threshold
-	TransactionThresholdTimestamp     time.Time                       // Timestamp for transaction threshold changes
-	TransactionRetryLimit             int                             // Maximum retry attempts for transactions
-	TransactionRetryData              map[string]int                  // Tracks transaction retry counts
-	Layer2ConsensusLogs               []Layer2ConsensusLog            // Logs for Layer 2 consensus events
-	TransactionMetrics                map[string]TransactionMetric    // Metrics for transactions
-	EscrowLogs                        map[string]EscrowLog            // Logs for escrow transactions
-	BlockMetrics                      map[string]BlockMetric          // Metrics for blocks
-	SubBlockMetrics                   map[string]SubBlockMetric       // Metrics for sub-blocks
-	FinalizedTransactions             map[string]TransactionRecord    // Finalized transactions
-	Punishments                       map[string]PunishmentRecord     // List of punishments
-	EncryptedPunishments              map[string]string               // Encrypted punishments
-	EncryptedRewards                  map[string]string               // Encrypted rewards
-	ParticipantRewards                map[string]float64              // Rewards for participants
-	ConsensusThreshold                int                             // Consensus threshold for validations
-	ConsensusThresholdTimestamp       time.Time                       // Timestamp for threshold changes
-	Validators                        map[string]Validator            // Validator details
-	MerkleRoot                        string                          // Merkle root of the current state
-	BlockSyncLogs                     []BlockSyncLog                  // Logs for block synchronization
+	BlockchainConsensusCoinState      BlockchainConsensusCoinState     // State information of the consensus mechanism
+	Blocks                            []Block                          // List of blocks
+	SubBlocks                         []SubBlock                       // List of sub-blocks
+	FinalizedBlocks                   []Block                          // List of finalized blocks
+	RejectedBlocks                    []Block                          // List of rejected blocks
+	ConsensusState                    ConsensusState                   // Current consensus state
+	SynthronBalance                   float64                          // Total system balance
+	BlockIndex                        int                              // Block creation index
+	ValidatorBansEnabled              bool                             // Validator banning flag
+	ValidatorPunishments              map[string][]PunishmentRecord    // Validator punishments
+	ValidatorRewardRecords            map[string][]RewardRecord        // Validator rewards
+	ValidatorPenalties                map[string]ValidatorPenalty      // Validator penalties
+	EpochLogs                         []EpochLog                       // Epoch logs
+	ConsensusHealthLogs               []HealthLog                      // Consensus health logs
+	ConsensusMechanisms               map[string]*ConsensusMechanism   // Consensus mechanisms
+	StrategyHops                      map[string]*ConsensusStrategy    // Consensus strategies
+	ConsensusLayers                   map[string]*ConsensusLayer       // Consensus layers
+	CollaborationNodes                map[string]*CollaborationNode    // Collaboration nodes
+	ConsensusThresholds               map[string]int                   // Consensus thresholds
+	ConsensusAnomalyDetectionStatus   string                           // Consensus anomaly detection status
+	ValidatorSelectionMode            ValidatorSelectionMode           // Validator selection mode
+	RewardDistributionMode            RewardDistributionMode           // Reward distribution mode
+	AdaptiveDifficulty                int                              // Adaptive difficulty level
+	StakeChanges                      []StakeChange                    // Stake changes
+	StakeLogs                         []StakeLog                       // Stake logs
+	ValidatorActivityLogs             []ValidatorActivityLog           // Validator activity logs
+	ValidatorRewardHistory            map[string][]RewardRecord        // Validator reward history
+	ConsensusAuditLogs                []ConsensusAuditLog              // Consensus audit logs
+	ValidationCache                   map[string]BlockValidationResult // Cached block validation results by block ID and hash
+	FinalityCheckLogs                 []FinalityCheckLog               // Finality check logs
+	ValidatorStakes                   map[string]float64               // Validator stakes
+	BlockLimit                        int                              // Limit for block generation
+	PunitiveMeasureLogs               []PunitiveMeasureRecord          // Logs of punitive measures
+	PunishmentReevaluationInterval    time.Duration                    // Interval for reevaluating punishments
+	PunishmentAdjustmentLogs          []PunishmentAdjustmentLog        // Logs for punitive measure adjustments
+	AdaptiveRewardDistributionEnabled bool                             // Flag for adaptive reward distribution
+	DifficultyAdjustmentLogs          []DifficultyAdjustmentLog        // Logs for difficulty adjustments
+	BlockGenerationLogs               []BlockGenerationLog             // Logs for block generation
+	PoHParticipationThreshold         float64                          // Threshold for Proof of History participation
+	DynamicStakeAdjustment            bool                             // Flag for dynamic stake adjustments
+	ConsensusMonitoringEnabled        bool                             // Flag for monitoring consensus
+	FinalityCheckEnabled              bool                             // Flag for enabling finality checks
+	EpochTimeout                      time.Duration                    // Epoch timeout duration
+	ReinforcementPolicy               ReinforcementPolicy              // Reinforcement policy
+	RedundantValidation               bool                             // Flag for redundant validation
+	BannedValidators                  map[string]ValidatorBanRecord    // Banned validator records
+	AutoPunishmentRate                float64                          // Automatic punishment escalation rate
+	ValidatorReinforcementEnabled     bool                             // Validator reinforcement mechanism flag
+	PoHValidationWindow               time.Duration                    // Duration of PoH validation window
+	PoHValidationLogs                 []PoHLog                         // Logs for PoH validations
+	PoHFailureThreshold               int                              // Threshold for PoH validation failures
+	PoWHalvingEnabled                 bool                             // Flag for enabling PoW reward halving
+	PoWHalvingInterval                time.Duration                    // Interval for PoW halving
+	SubblockCapacity                  int                              // Maximum capacity for sub-blocks
+	SubblockCapacities                map[string]int                   // Current sub-block capacity usage
+	SubblockCapacityHistory           map[string][]int                 // Sub-block capacity history
+	SynthronCoinDenomination          string                           // Current denomination of Synthron Coin
+	CoinDenominationHistory           map[string]string                // Historical denomination changes
+	SubblockCacheLimit                int                              // Cache limit for sub-blocks
+	BlockCompressionEnabled           bool                             // Block compression flag
+	BlockCompressionLevel             int                              // Block compression level
+	EncryptionEnabled                 bool                             // Encryption flag
+	EncryptionKey                     string                           // Encryption key
+	SubblockCompressionEnabled        bool                             // Sub-block compression flag
+	SubblockValidationCriteria        string                           // Validation criteria for sub-blocks
+	ValidationInterval                time.Duration                    // Validation interval
+	BlockTransactionLimit             int                              // Transaction limit per block
+	RejectedTransactions              map[string]Transaction           // List of rejected transactions
+	BlockListeners                    map[string]BlockListener         // List of block listeners
+	Transactions                      map[string]TransactionRecord     // Tracks all transactions
+	TransactionCache                  map[string]Transaction           // Cache of pending transactions
+	ReversalRequests                  map[string]ReversalRequest       // Transaction reversal requests
+	EscrowTransactions                map[string]EscrowTransaction     // Escrow transactions
+	PrivateTransactions               map[string]PrivateTransaction    // Private transactions
+	PendingTransactions               []*Transaction                   // List of pending transactions
+	TransactionPool                   *TransactionPool                 // Manages pending and unconfirmed transactions
+	FeeManager                        *FeeManager                      // Manages transaction fees
+	ReversalManager                   *TransactionReversalManager      // Manages transaction reversals
+	PrivateTransactionManager         *PrivateTransactionManager       // Manages private transactions
+	CancellationManager               *TransactionCancellationManager  // Handles transaction cancellations
+	TransactionDistributionManager    *TransactionDistributionManager  // Distributes fees across nodes
+	CancellationRequests              map[string]*CancellationRequest  // Stores transaction cancellation requests
+	TransactionThreshold              int                              // Transaction threshold
+	TransactionThresholdTimestamp     time.Time                        // Timestamp for transaction threshold changes
+	TransactionRetryLimit             int                              // Maximum retry attempts for transactions
+	TransactionRetryData              map[string]int                   // Tracks transaction retry counts
+	Layer2ConsensusLogs               []Layer2ConsensusLog             // Logs for Layer 2 consensus events
+	TransactionMetrics                map[string]TransactionMetric     // Metrics for transactions
+	EscrowLogs                        map[string]EscrowLog             // Logs for escrow transactions
+	BlockMetrics                      map[string]BlockMetric           // Metrics for blocks
+	SubBlockMetrics                   map[string]SubBlockMetric        // Metrics for sub-blocks
+	FinalizedTransactions             map[string]TransactionRecord     // Finalized transactions
+	Punishments                       map[string]PunishmentRecord      // List of punishments
+	EncryptedPunishments              map[string]string                // Encrypted punishments
+	EncryptedRewards                  map[string]string                // Encrypted rewards
+	ParticipantRewards                map[string]float64               // Rewards for participants
+	ConsensusThreshold                int                              // Consensus threshold for validations
+	ConsensusThresholdTimestamp       time.Time                        // Timestamp for threshold changes
+	Validators                        map[string]Validator             // Validator details
+	MerkleRoot                        string                           // Merkle root of the current state
+	BlockSyncLogs                     []BlockSyncLog                   // Logs for block synchronization
 }
 
 // BlockchainConsensusCoinState represents the internal state of the blockchain consensus.
@@ -429,6 +456,7 @@ type ComplianceLedger struct {
 	CacheMonitoring           []CacheMonitor                      // Cache monitoring data
 	CacheUsageHistory         []CacheUsage                        // History of cache usage
 	MigrationCompliances      []MigrationCompliance               // Migration compliance data
+	BlockedMigrations         map[string]string                   // Non-compliant migrations mapped to the rules they violated
 	Licenses                  map[string]License                  // Licenses for compliance-related software
 	EncryptionStandards       map[string]EncryptionStandard       // Encryption standards
 	EncryptionPolicies        map[string]EncryptionPolicy         // Encryption policies
@@ -499,6 +527,7 @@ type DataManagementLedger struct {
 	CacheMonitoring       bool                                // Indicates if cache monitoring is enabled
 	Oracles               map[string]OracleSubmission         // Tracks oracle submissions
 	Aggregations          map[string]AggregationValidation    // Tracks data aggregations
+	RequiredConfirmations int                                 // Distinct validators needed to validate an aggregation
 	DataTransmissions     []DataTransmission                  // Tracks data transmissions
 	DataTransferRecords   map[string]DataTransferRecord       // Tracks data transfer records
 }
@@ -526,6 +555,7 @@ type DeFiLedger struct {
 	Collateral                map[string]map[string]*CollateralRecord   // Tracks collateral by entity ID
 	InsurancePolicies         map[string]InsurancePolicy                // Active insurance policies
 	InsuranceClaims           map[string]InsuranceClaim                 // Filed insurance claims
+	InsurancePayouts          map[string]InsurancePayout                // Claim payouts by claim ID
 	AssetPools                map[string]AssetPool                      // Asset pools for DeFi
 	YieldFarmingRecords       map[string]YieldFarmingRecord             // Records of yield farming activities
 	Bets                      map[string]Bet
@@ -541,31 +571,31 @@ type DeFiLedger struct {
 	ContributionLimits        map[string]ContributionLimits
 	PausedCampaigns           map[string]bool
 	InsuranceEscrowBalances   map[string]float64
-    Transactions   map[string][]LiquidityPoolTransaction
-    LPStakings     map[string][]LPStaking
-	PredictionEvents map[string]PredictionEvent
-    Predictions      map[string][]Prediction
-    ParticipantHistories map[string][]ParticipantPrediction
-	StakingPrograms      map[string]StakingProgram
-    StakingParticipants  map[string][]StakingParticipant
-	RewardHistories        map[string][]RewardRecord     // userID -> reward history
-    StakingSnapshots       map[string][]StakingSnapshot // programID -> snapshots
-	Loans            map[string]Loan
-    CollateralEscrows map[string]string // LoanID -> Collateral (encrypted)
-    LoanRepayments   map[string]float64 // LoanID -> RepaymentAmount
-	LoanAudits                map[string][]LoanAuditRecord // LoanID -> Audit Records
-    LatePayments              map[string][]LatePaymentRecord // LoanID -> Late Payment Records
-    CollateralRequirements    map[string]float64 // LoanID -> Minimum Collateral
-    LoanRepaymentSchedules    map[string][]time.Time // LoanID -> Repayment Schedule
-    InterestRatePeriods       map[string]time.Duration // LoanID -> Interest Rate Period
-    SyntheticAssetPrices    map[string][]SyntheticAssetPriceChange // AssetID -> Price Change History
-    AssetDividends          map[string]float64 // AssetID -> Dividend Amount
-    AssetDividendRates      map[string]float64 // AssetID -> Dividend Rate
-	SyntheticAssetMarketCap map[string][]MarketCapRecord       // AssetID -> MarketCap History
-    SyntheticAssetVolatility map[string][]VolatilityRecord     // AssetID -> Volatility History
-	YieldFarmPools       map[string]YieldFarmPool           // PoolID -> YieldFarmPool
-    YieldFarmEarnings    map[string]map[string]YieldFarmEarning // PoolID -> UserID -> YieldFarmEarning
-	YieldFarmPerformance    map[string]PoolPerformanceMetrics  // PoolID -> Performance Metrics
+	Transactions              map[string][]LiquidityPoolTransaction
+	LPStakings                map[string][]LPStaking
+	PredictionEvents          map[string]PredictionEvent
+	Predictions               map[string][]Prediction
+	ParticipantHistories      map[string][]ParticipantPrediction
+	StakingPrograms           map[string]StakingProgram
+	StakingParticipants       map[string][]StakingParticipant
+	RewardHistories           map[string][]RewardRecord    // userID -> reward history
+	StakingSnapshots          map[string][]StakingSnapshot // programID -> snapshots
+	Loans                     map[string]Loan
+	CollateralEscrows         map[string]string                      // LoanID -> Collateral (encrypted)
+	LoanRepayments            map[string]float64                     // LoanID -> RepaymentAmount
+	LoanAudits                map[string][]LoanAuditRecord           // LoanID -> Audit Records
+	LatePayments              map[string][]LatePaymentRecord         // LoanID -> Late Payment Records
+	CollateralRequirements    map[string]float64                     // LoanID -> Minimum Collateral
+	LoanRepaymentSchedules    map[string][]time.Time                 // LoanID -> Repayment Schedule
+	InterestRatePeriods       map[string]time.Duration               // LoanID -> Interest Rate Period
+	SyntheticAssetPrices      map[string][]SyntheticAssetPriceChange // AssetID -> Price Change History
+	AssetDividends            map[string]float64                     // AssetID -> Dividend Amount
+	AssetDividendRates        map[string]float64                     // AssetID -> Dividend Rate
+	SyntheticAssetMarketCap   map[string][]MarketCapRecord           // AssetID -> MarketCap History
+	SyntheticAssetVolatility  map[string][]VolatilityRecord          // AssetID -> Volatility History
+	YieldFarmPools            map[string]YieldFarmPool               // PoolID -> YieldFarmPool
+	YieldFarmEarnings         map[string]map[string]YieldFarmEarning // PoolID -> UserID -> YieldFarmEarning
+	YieldFarmPerformance      map[string]PoolPerformanceMetrics      // PoolID -> Performance Metrics
 }
 
 // EnvironmentSystemCoreLedger manages system configurations, flags, and safe mode operations.
@@ -643,63 +673,78 @@ type EnvironmentSystemCoreLedger struct {
 	ReconciliationProcesses map[string]bool                 // ContextID -> IsActive
 	ReconciliationStatuses  map[string]ReconciliationStatus // ContextID -> Status
 	FinalizationRecords     map[string]FinalizationRecord   // EntityID -> Finalization Details
+	FinalityLog             []FinalityLogEntry              // Ordered finality events, replayed on startup
 	TrapEvents              []TrapEvent
 	ExceptionLogs           []ExceptionLogEntry
 	InterruptHandlers       map[string]InterruptHandler
 	SystemHaltLogs          []SystemHaltLog
 	RetryableOperations     map[string]int
-	Interrupts              map[string]Interrupt      // InterruptID as key
-	RecoveryLogs            []RecoveryLog             // Logs of all recovery actions
-	SystemStatus            string                    // e.g., "running", "halted", "recovering"
-	TrapConditions          map[string]TrapCondition  // Trap conditions by ID
-	EmergencyAlert          *EmergencyAlert           // Current emergency alert (if any)
-	DebugModeLogs           []string                  // Encrypted debug mode entries
-	RecoveryLog             []string                  // Encrypted recovery reasons
-	DiagnosticLogs          []string                  // Encrypted diagnostic results
-	SelfTestResults         []SelfTestResult          // Log of all self-test results
-	CriticalInterrupts      map[string]func() error   // Map of interrupt handlers
-	TrapTimeouts            map[string]TrapTimeout    // Map of trap timeouts
-	SafeModeLogs            []SafeModeEntry           // Logs for safe mode entries
-	AutoRecoveryEnabled     bool                      // Status of automatic recovery
-	NetworkStatus           *NetworkStatus            // Tracks overall network health.
-	BlockHeight             int                       // Current blockchain height.
-	LatestSubBlock          string                    // Most recent validated sub-block.
-	NodeHealthRecords       map[string]NodeHealth     // NodeID -> NodeHealth.
-	BlockEvents             []BlockEvent              // Log of block-related events.
-	EnvironmentVars         map[string]string         // Map of environment variables.
-	BlockchainParameters    map[string]string         // Map of parameter names to values.
-	TrafficReports          []NetworkTrafficReport    // Log of network traffic reports.
-	BlockValidationLogs     []BlockValidationResult   // Log of block validation results.
-	ProcessStates           map[string]ProcessState   // Map of process states by process ID.
-	ResourceAllocations     []ResourceAllocation      // Log of resource allocations.
-	SystemLockState         *SystemLockState          // Current lock state of the system.
-	ResourceMonitorLogs     []string                  // Log of resource monitoring reports (encrypted).
-	SystemConstants         map[string]SystemConstant // Map of constant names to their values.
-	SecurityEvents          []SecurityEvent           // Log of security-related events.
-	MaintenanceLogs         []MaintenanceLog          // Log of maintenance activities.
+	Interrupts              map[string]Interrupt               // InterruptID as key
+	RecoveryLogs            []RecoveryLog                      // Logs of all recovery actions
+	SystemStatus            string                             // e.g., "running", "halted", "recovering"
+	TrapConditions          map[string]TrapCondition           // Trap conditions by ID
+	EmergencyAlert          *EmergencyAlert                    // Current emergency alert (if any)
+	EmergencyAcks           map[string]bool                    // nodeID -> acknowledged, for nodes notified of the current emergency
+	DebugModeLogs           []string                           // Encrypted debug mode entries
+	RecoveryLog             []string                           // Encrypted recovery reasons
+	DiagnosticLogs          []string                           // Encrypted diagnostic results
+	SelfTestResults         []SelfTestResult                   // Log of all self-test results
+	CriticalInterrupts      map[string]func() error            // Map of interrupt handlers
+	TrapTimeouts            map[string]TrapTimeout             // Map of trap timeouts
+	SafeModeLogs            []SafeModeEntry                    // Logs for safe mode entries
+	AutoRecoveryEnabled     bool                               // Status of automatic recovery
+	NetworkStatus           *NetworkStatus                     // Tracks overall network health.
+	BlockHeight             int                                // Current blockchain height.
+	LatestSubBlock          string                             // Most recent validated sub-block.
+	NodeHealthRecords       map[string]NodeHealth              // NodeID -> NodeHealth.
+	BlockEvents             []BlockEvent                       // Log of block-related events.
+	BlockSubscribers        map[string]map[int]chan BlockEvent // BlockID -> subscriber ID -> event channel.
+	nextBlockSubscriberID   int                                // ID assigned to the next block subscriber.
+	EnvironmentVars         map[string]string                  // Map of environment variables.
+	BlockchainParameters    map[string]string                  // Map of parameter names to values.
+	ParameterSpecs          map[string]ParameterSpec           // Map of parameter names to their type and bounds.
+	ParameterChangeLog      []ParameterChange                  // Log of validated parameter updates.
+	TrafficReports          []NetworkTrafficReport             // Log of network traffic reports.
+	TrafficSamples          []TrafficData                      // Traffic data collected for reporting.
+	TransferMetrics         []DataTransferMetrics              // Data transfer metrics collected for reporting.
+	trafficReportStop       chan struct{}                      // Stops the scheduled traffic report loop.
+	BlockValidationLogs     []BlockValidationResult            // Log of block validation results.
+	ProcessStates           map[string]ProcessState            // Map of process states by process ID.
+	ResourceAllocations     []ResourceAllocation               // Log of resource allocations.
+	SystemLockState         *SystemLockState                   // Current lock state of the system.
+	ResourceMonitorLogs     []string                           // Log of resource monitoring reports (encrypted).
+	SystemConstants         map[string]SystemConstant          // Map of constant names to their values.
+	SecurityEvents          []SecurityEvent                    // Log of security-related events.
+	MaintenanceLogs         []MaintenanceLog                   // Log of maintenance activities.
 	RecoveryEvents          []RecoveryEvent
 	DiagnosticEvents        []DiagnosticEvent
 	PanicHandlerConfigs     []PanicHandler
+	panicHandlers           map[string]func(recovered interface{}) // Handler ID -> function invoked on a recovered panic
 	EmergencyOverrides      []EmergencyOverride
 	PanicStatuses           []PanicStatus
 	FailedOperations        []FailedOperation
 	ShutdownEvents          []ShutdownEvent
 	ExecutionPolicies       []ExecutionPolicy
 	HandoverEvents          []HandoverEvent
+	activeHandovers         map[string]*HandoverEvent // Context ID -> handover awaiting acknowledgement or completion
 	RetentionPolicies       []RetentionPolicy
 	RecoveryProtocols       []RecoveryProtocol
 	SystemEvents            []SystemEvent
 	SystemMetrics           []MetricRecord
-	SystemHooks             map[string]HookRecord // Mapping event ID to HookRecord
+	SystemHooks             map[string]HookRecord       // Mapping event ID to HookRecord
+	hookHandlers            map[string][]registeredHook // Event ID -> handlers in invocation order
 	SystemRoles             []RoleRecord
 	SystemProfiles          []ProfileRecord
-	SystemProfileManager    SystemProfileManager    // Manages system profiles and configurations.
-	SystemStateSynchronizer SystemStateSynchronizer // Ensures synchronization of state across the system.
-	SystemState             SystemState             // Tracks and stores the current system state.
-	SystemManager           SystemManager           // Oversees general system operations and management.
-	OverrideManager         OverrideManager         // Handles override configurations and operations.
-	OperationManager        OperationManager        // Manages system-level operations and processes.
-	AutomationManager       AutomationManager       // Facilitates and monitors automation processes.
+	SystemProfileManager    SystemProfileManager             // Manages system profiles and configurations.
+	Profiles                map[string]*SystemProfileManager // profileID -> registered system profile
+	ActiveProfileID         string                           // ID of the currently active system profile
+	ActivePermissions       []string                         // Permissions granted by the active profile
+	SystemStateSynchronizer SystemStateSynchronizer          // Ensures synchronization of state across the system.
+	SystemState             SystemState                      // Tracks and stores the current system state.
+	SystemManager           SystemManager                    // Oversees general system operations and management.
+	OverrideManager         OverrideManager                  // Handles override configurations and operations.
+	OperationManager        OperationManager                 // Manages system-level operations and processes.
+	AutomationManager       AutomationManager                // Facilitates and monitors automation processes.
 }
 
 // GovernanceLedger handles governance proposals, voting, and policy tracking.
@@ -775,6 +820,8 @@ type HighAvailabilityLedger struct {
 	RedundancyConfig           RedundancyConfig                  // Redundancy configuration
 	DeduplicationConfig        DeduplicationConfig               // Deduplication configuration
 	StandbyConfig              StandbyConfig                     // Standby configuration
+	StandbyNodes               map[string]StandbyNode            // Standby nodes brought online
+	ActivePrimary              string                            // Node currently serving as primary
 	SimulationResults          map[string]SimulationResult       // Simulation results
 	ResourceQuotaConfig        ResourceQuotaConfig               // Resource quota configuration
 	ResourceScalingConfig      ResourceScalingConfig             // Resource scaling configuration
@@ -783,6 +830,7 @@ type HighAvailabilityLedger struct {
 	FailbackPriority           string                            // Failback priority
 	LastUpdated                time.Time                         // Last updated timestamp
 	NodeMetrics                map[string]NodeMetrics            // Metrics for system nodes
+	NodeCapabilities           map[string][]string               // Capabilities advertised by each node
 	FailoverThreshold          FailoverThreshold                 // Thresholds for failover conditions
 	RecoveryManager            RecoveryManager                   // Handles system recovery processes, checkpoints, and restoration.
 	FallbackManager            FallbackManager                   // Manages fallback strategies during system failures.
@@ -1058,6 +1106,7 @@ type MetadataManagementLedger struct {
 
 // MonitoringMaintenanceLedger handles health checks, performance monitoring, and maintenance logs.
 type MonitoringMaintenanceLedger struct {
+	sync.Mutex
 	HealthMetrics                map[string]HealthMetric       // System health metrics
 	HealthStatusVerifications    map[string]string             // Health status verifications
 	SystemErrors                 map[string]SystemErrorEntry   // System error entries
@@ -1076,6 +1125,9 @@ type MonitoringMaintenanceLedger struct {
 	ObserverManager              ObserverManager               // Tracks and manages observers monitoring system components or processes.
 	MonitoringSystem             MonitoringSystem
 	MaintenanceEvents            []MaintenanceEvent
+	MaintenanceLogs              []MaintenanceLog              // Logs of completed maintenance
+	DeferDuringMaintenance       bool                          // Defer operations during maintenance windows instead of rejecting them
+	DeferredOperations           []DeferredOperation           // Operations deferred until their maintenance completes
 	CPUUsageHistory              []float64
 	MemoryUsageHistory           []float64
 	SystemChecks            map[string]SystemCheck
@@ -1504,6 +1556,7 @@ type UtilityLedger struct {
 	UtilityLedgerState    UtilityLedgerState                // Tracks the state of the utility ledger
 	Tasks                 map[string]TaskRecord             // Ledger task records
 	OrchestrationRequests map[string]OrchestrationRequest   // Tracks orchestration requests
+	OrchestrationQueue    []string                          // IDs of orchestration requests waiting for capacity
 	OrchestrationRecords  map[string]map[string]interface{} // Stores orchestration records
 	SystemHookRegistry    SystemHookRegistry                // Manages and executes system-level hooks for events, actions, and transitions.
 	InterruptManager      InterruptManager                  // Handles system-level interrupts and ensures safe operations during interruptions.
@@ -0,0 +1,247 @@
+package ledger
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// registerCounterApplier registers a mutation kind whose applier adds its
+// payload to counter, and returns the kind.
+func registerCounterApplier(t testing.TB, counter *int64) string {
+	kind := t.Name() + "/counter"
+	if err := RegisterMutationApplier(kind, func(l *Ledger, payload []byte) error {
+		var n int64
+		if err := json.Unmarshal(payload, &n); err != nil {
+			return err
+		}
+		atomic.AddInt64(counter, n)
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterMutationApplier: %v", err)
+	}
+	return kind
+}
+
+func newWriteBehindLedger(t testing.TB) *Ledger {
+	l := &Ledger{}
+	l.HighAvailabilityLedger.WriteAheadLogConfig.Path = filepath.Join(t.TempDir(), "ledger.wal")
+	return l
+}
+
+func TestSubmitMutationAppliesImmediatelyWithoutWriteBehind(t *testing.T) {
+	var counter int64
+	kind := registerCounterApplier(t, &counter)
+	l := &Ledger{}
+
+	if err := l.SubmitMutation(LedgerMutation{Kind: kind, Payload: json.RawMessage("3")}); err != nil {
+		t.Fatalf("SubmitMutation: %v", err)
+	}
+	if got := atomic.LoadInt64(&counter); got != 3 {
+		t.Fatalf("counter = %d, want 3", got)
+	}
+}
+
+func TestSubmitMutationRejectsUnknownKind(t *testing.T) {
+	l := &Ledger{}
+	if err := l.SubmitMutation(LedgerMutation{Kind: "no-such-kind", Payload: json.RawMessage("1")}); err == nil {
+		t.Fatal("expected an error for an unregistered mutation kind")
+	}
+}
+
+func TestEnableWriteBehindRequiresAbsolutePath(t *testing.T) {
+	l := &Ledger{}
+	if err := l.EnableWriteBehind(10, time.Hour); err == nil {
+		t.Fatal("expected an error without a configured write-ahead log path")
+	}
+	l.HighAvailabilityLedger.WriteAheadLogConfig.Path = "relative.wal"
+	if err := l.EnableWriteBehind(10, time.Hour); err == nil {
+		t.Fatal("expected an error for a relative write-ahead log path")
+	}
+}
+
+func TestFlushNowPersistsAllBufferedEntries(t *testing.T) {
+	var counter int64
+	kind := registerCounterApplier(t, &counter)
+	l := newWriteBehindLedger(t)
+
+	if err := l.EnableWriteBehind(1000, time.Hour); err != nil {
+		t.Fatalf("EnableWriteBehind: %v", err)
+	}
+	defer l.DisableWriteBehind()
+
+	for i := 0; i < 50; i++ {
+		if err := l.SubmitMutation(LedgerMutation{Kind: kind, Payload: json.RawMessage("1")}); err != nil {
+			t.Fatalf("SubmitMutation: %v", err)
+		}
+	}
+	if got := atomic.LoadInt64(&counter); got != 0 {
+		t.Fatalf("counter = %d before flush, want 0", got)
+	}
+
+	if err := l.FlushNow(); err != nil {
+		t.Fatalf("FlushNow: %v", err)
+	}
+	if got := atomic.LoadInt64(&counter); got != 50 {
+		t.Fatalf("counter = %d after flush, want 50", got)
+	}
+
+	remaining, err := readWriteBehindWAL(l.HighAvailabilityLedger.WriteAheadLogConfig.Path)
+	if err != nil {
+		t.Fatalf("readWriteBehindWAL: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("write-ahead log holds %d entries after flush, want 0", len(remaining))
+	}
+}
+
+func TestWriteBehindFlushesWhenBatchFills(t *testing.T) {
+	var counter int64
+	kind := registerCounterApplier(t, &counter)
+	l := newWriteBehindLedger(t)
+
+	if err := l.EnableWriteBehind(5, time.Hour); err != nil {
+		t.Fatalf("EnableWriteBehind: %v", err)
+	}
+	defer l.DisableWriteBehind()
+
+	for i := 0; i < 5; i++ {
+		if err := l.SubmitMutation(LedgerMutation{Kind: kind, Payload: json.RawMessage("1")}); err != nil {
+			t.Fatalf("SubmitMutation: %v", err)
+		}
+	}
+	if got := atomic.LoadInt64(&counter); got != 5 {
+		t.Fatalf("counter = %d, want 5 once the batch filled", got)
+	}
+}
+
+func TestWriteBehindDropsFailingMutation(t *testing.T) {
+	var counter int64
+	kind := registerCounterApplier(t, &counter)
+	l := newWriteBehindLedger(t)
+
+	if err := l.EnableWriteBehind(1000, time.Hour); err != nil {
+		t.Fatalf("EnableWriteBehind: %v", err)
+	}
+	defer l.DisableWriteBehind()
+
+	l.SubmitMutation(LedgerMutation{Kind: kind, Payload: json.RawMessage(`"not a number"`)})
+	l.SubmitMutation(LedgerMutation{Kind: kind, Payload: json.RawMessage("2")})
+
+	if err := l.FlushNow(); err == nil {
+		t.Fatal("expected FlushNow to report the failing mutation")
+	}
+	if got := atomic.LoadInt64(&counter); got != 2 {
+		t.Fatalf("counter = %d, want 2: a failing mutation must not block the ones behind it", got)
+	}
+	if err := l.FlushNow(); err != nil {
+		t.Fatalf("second FlushNow: %v, want the failing mutation to have been dropped", err)
+	}
+}
+
+func TestEnableWriteBehindReplaysLog(t *testing.T) {
+	var counter int64
+	kind := registerCounterApplier(t, &counter)
+	l := newWriteBehindLedger(t)
+
+	if err := l.EnableWriteBehind(1000, time.Hour); err != nil {
+		t.Fatalf("EnableWriteBehind: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := l.SubmitMutation(LedgerMutation{Kind: kind, Payload: json.RawMessage("1")}); err != nil {
+			t.Fatalf("SubmitMutation: %v", err)
+		}
+	}
+
+	// Simulate a crash: drop the buffer without flushing it.
+	l.writeBehindMu.Lock()
+	wb := l.writeBehind
+	l.writeBehind = nil
+	l.writeBehindMu.Unlock()
+	close(wb.stop)
+	<-wb.done
+	wb.wal.Close()
+
+	restarted := &Ledger{}
+	restarted.HighAvailabilityLedger.WriteAheadLogConfig.Path = l.HighAvailabilityLedger.WriteAheadLogConfig.Path
+	if err := restarted.EnableWriteBehind(1000, time.Hour); err != nil {
+		t.Fatalf("EnableWriteBehind after crash: %v", err)
+	}
+	defer restarted.DisableWriteBehind()
+
+	if got := atomic.LoadInt64(&counter); got != 3 {
+		t.Fatalf("counter = %d after replay, want 3", got)
+	}
+}
+
+func TestSubmitContractStateUpdatesVirtualMachineLedger(t *testing.T) {
+	l := &Ledger{}
+	l.VirtualMachineLedger.ContractState = make(map[string]ContractState)
+
+	if err := l.SubmitContractState("contract-1", map[string]interface{}{"result": "ok"}); err != nil {
+		t.Fatalf("SubmitContractState: %v", err)
+	}
+	state, exists := l.VirtualMachineLedger.ContractState["contract-1"]
+	if !exists || state.StateData["result"] != "ok" {
+		t.Fatalf("contract state = %+v, want result=ok", state)
+	}
+}
+
+func TestSubmitContractStatePreservesTypesWithoutWriteBehind(t *testing.T) {
+	l := &Ledger{}
+	l.VirtualMachineLedger.ContractState = make(map[string]ContractState)
+	executedAt := time.Now()
+	ciphertext := string([]byte{0xff, 0xfe, 0x00, 0x80})
+
+	update := map[string]interface{}{"executed_at": executedAt, "gas": 21000, "encrypted_result": ciphertext}
+	if err := l.SubmitContractState("contract-1", update); err != nil {
+		t.Fatalf("SubmitContractState: %v", err)
+	}
+	data := l.VirtualMachineLedger.ContractState["contract-1"].StateData
+	if got, ok := data["executed_at"].(time.Time); !ok || !got.Equal(executedAt) {
+		t.Fatalf("executed_at = %#v, want the original time.Time", data["executed_at"])
+	}
+	if got, ok := data["gas"].(int); !ok || got != 21000 {
+		t.Fatalf("gas = %#v, want int 21000", data["gas"])
+	}
+	if data["encrypted_result"] != ciphertext {
+		t.Fatalf("encrypted_result = %q, want the ciphertext unchanged", data["encrypted_result"])
+	}
+}
+
+// benchmarkSubmit measures parallel SubmitMutation throughput with every
+// mutation made durable in the write-ahead log. A batch size of 1 applies and
+// truncates the log on every submission, which is the unbatched baseline.
+func benchmarkSubmit(b *testing.B, batchSize int) {
+	var counter int64
+	kind := registerCounterApplier(b, &counter)
+	l := newWriteBehindLedger(b)
+	if err := l.EnableWriteBehind(batchSize, 10*time.Millisecond); err != nil {
+		b.Fatalf("EnableWriteBehind: %v", err)
+	}
+	defer l.DisableWriteBehind()
+
+	m := LedgerMutation{Kind: kind, Payload: json.RawMessage("1")}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := l.SubmitMutation(m); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	if err := l.FlushNow(); err != nil {
+		b.Fatal(err)
+	}
+	b.StopTimer()
+	if got := atomic.LoadInt64(&counter); got != int64(b.N) {
+		b.Fatalf("applied %d mutations, want %d", got, b.N)
+	}
+}
+
+func BenchmarkSubmitMutationUnbatched(b *testing.B) { benchmarkSubmit(b, 1) }
+
+func BenchmarkSubmitMutationWriteBehind(b *testing.B) { benchmarkSubmit(b, 256) }
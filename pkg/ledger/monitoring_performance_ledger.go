@@ -1,5 +1,12 @@
 package ledger
 
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
 // RecordPerformanceMetrics logs performance metrics for the ledger.
 func (l *Ledger) RecordPerformanceMetrics(entityID string, metrics string) error {
 	l.lock.Lock()
@@ -19,8 +26,8 @@ func (l *Ledger) RecordPerformanceMetrics(entityID string, metrics string) error
 
 // RecordMaintenanceEvent records a maintenance event in the ledger.
 func (mml *MonitoringMaintenanceLedger) RecordMaintenanceEvent(eventID, details string) error {
-	mml.mu.Lock()
-	defer mml.mu.Unlock()
+	mml.Lock()
+	defer mml.Unlock()
 
 	event := MaintenanceEvent{
 		EventID:   eventID,
@@ -35,8 +42,8 @@ func (mml *MonitoringMaintenanceLedger) RecordMaintenanceEvent(eventID, details
 
 // GetCPUUsage returns the average CPU usage from the history.
 func (mml *MonitoringMaintenanceLedger) GetCPUUsage() (float64, error) {
-	mml.mu.Lock()
-	defer mml.mu.Unlock()
+	mml.Lock()
+	defer mml.Unlock()
 
 	if len(mml.CPUUsageHistory) == 0 {
 		return 0, fmt.Errorf("no CPU usage data available")
@@ -52,8 +59,8 @@ func (mml *MonitoringMaintenanceLedger) GetCPUUsage() (float64, error) {
 
 // GetMemoryUsage returns the average memory usage from the history.
 func (mml *MonitoringMaintenanceLedger) GetMemoryUsage() (float64, error) {
-	mml.mu.Lock()
-	defer mml.mu.Unlock()
+	mml.Lock()
+	defer mml.Unlock()
 
 	if len(mml.MemoryUsageHistory) == 0 {
 		return 0, fmt.Errorf("no memory usage data available")
@@ -2189,3 +2196,149 @@ func (l *Ledger) ResetAllResourceUtilizationCounters() error {
     l.ResourceUtilizationLogs = []ResourceAlert{}
     return nil
 }
+
+// ScheduleMaintenance schedules a maintenance window. Windows may not overlap pending maintenance.
+func (mml *MonitoringMaintenanceLedger) ScheduleMaintenance(desc string, start, end time.Time) (eventID string, err error) {
+	if desc == "" {
+		return "", fmt.Errorf("maintenance description cannot be empty")
+	}
+	if !end.After(start) {
+		return "", fmt.Errorf("maintenance window must end after it starts")
+	}
+
+	mml.Lock()
+	defer mml.Unlock()
+
+	for _, event := range mml.MaintenanceEvents {
+		if event.Completed {
+			continue
+		}
+		if start.Before(event.ScheduledEnd) && event.ScheduledStart.Before(end) {
+			return "", fmt.Errorf("maintenance window overlaps scheduled maintenance %s", event.EventID)
+		}
+	}
+
+	event := MaintenanceEvent{
+		EventID:        generateUniqueID(),
+		Description:    desc,
+		ScheduledStart: start,
+		ScheduledEnd:   end,
+	}
+	mml.MaintenanceEvents = append(mml.MaintenanceEvents, event)
+
+	log.Printf("[INFO] Maintenance %s scheduled from %s to %s", event.EventID, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	return event.EventID, nil
+}
+
+// InMaintenanceWindow reports whether now falls inside a pending maintenance window and returns its event ID.
+func (mml *MonitoringMaintenanceLedger) InMaintenanceWindow(now time.Time) (bool, string) {
+	mml.Lock()
+	defer mml.Unlock()
+
+	return mml.activeMaintenance(now)
+}
+
+// activeMaintenance returns the pending maintenance window covering now. The caller must hold the lock.
+func (mml *MonitoringMaintenanceLedger) activeMaintenance(now time.Time) (bool, string) {
+	for _, event := range mml.MaintenanceEvents {
+		if event.Completed {
+			continue
+		}
+		if !now.Before(event.ScheduledStart) && now.Before(event.ScheduledEnd) {
+			return true, event.EventID
+		}
+	}
+	return false, ""
+}
+
+// GuardOperation checks an operation against active maintenance windows. During maintenance the
+// operation is deferred when DeferDuringMaintenance is set and rejected otherwise. A deferred run is
+// called by CompleteMaintenance once the window's maintenance completes; callers must not run it
+// themselves.
+func (mml *MonitoringMaintenanceLedger) GuardOperation(operation string, run func() error, now time.Time) (deferred bool, err error) {
+	if run == nil {
+		return false, fmt.Errorf("operation %s has nothing to run", operation)
+	}
+
+	mml.Lock()
+	defer mml.Unlock()
+
+	active, eventID := mml.activeMaintenance(now)
+	if !active {
+		return false, nil
+	}
+
+	if mml.DeferDuringMaintenance {
+		mml.DeferredOperations = append(mml.DeferredOperations, DeferredOperation{
+			Name:       operation,
+			EventID:    eventID,
+			DeferredAt: now,
+			Run:        run,
+		})
+		log.Printf("[INFO] Operation %s deferred during maintenance %s", operation, eventID)
+		return true, nil
+	}
+
+	return false, fmt.Errorf("operation %s rejected during maintenance %s", operation, eventID)
+}
+
+// CompleteMaintenance marks scheduled maintenance as completed, replays the operations deferred
+// during it in the order they were deferred, and logs the completion. Replayed operations that
+// fail are dropped, noted in the log and reported in the returned error.
+func (mml *MonitoringMaintenanceLedger) CompleteMaintenance(eventID string, now time.Time) (*MaintenanceLog, error) {
+	mml.Lock()
+
+	index := -1
+	for i, event := range mml.MaintenanceEvents {
+		if event.EventID == eventID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		mml.Unlock()
+		return nil, fmt.Errorf("maintenance %s not found", eventID)
+	}
+	event := mml.MaintenanceEvents[index]
+	if event.Completed {
+		mml.Unlock()
+		return nil, fmt.Errorf("maintenance %s is already completed", eventID)
+	}
+
+	event.Completed = true
+	event.PerformedAt = now
+	mml.MaintenanceEvents[index] = event
+
+	// Drain the operations waiting on this maintenance
+	var replay, remaining []DeferredOperation
+	for _, op := range mml.DeferredOperations {
+		if op.EventID == eventID {
+			replay = append(replay, op)
+		} else {
+			remaining = append(remaining, op)
+		}
+	}
+	mml.DeferredOperations = remaining
+	mml.Unlock()
+
+	// Replay without the lock so operations may consult the ledger themselves
+	var failures []error
+	for _, op := range replay {
+		if err := op.Run(); err != nil {
+			failures = append(failures, fmt.Errorf("deferred operation %s: %w", op.Name, err))
+		}
+	}
+
+	entry := MaintenanceLog{
+		Description: fmt.Sprintf("Maintenance %s completed: %s (replayed %d deferred operations, %d failed)",
+			eventID, event.Description, len(replay), len(failures)),
+		Timestamp: now,
+	}
+
+	mml.Lock()
+	mml.MaintenanceLogs = append(mml.MaintenanceLogs, entry)
+	mml.Unlock()
+
+	log.Printf("[INFO] Maintenance %s completed at %s", eventID, now.Format(time.RFC3339))
+	return &entry, errors.Join(failures...)
+}
@@ -0,0 +1,76 @@
+package ledger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInMaintenanceWindowDetectsScheduledWindow(t *testing.T) {
+	l := &MonitoringMaintenanceLedger{}
+	start := time.Now()
+	eventID, err := l.ScheduleMaintenance("upgrade storage", start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance: %v", err)
+	}
+
+	if active, _ := l.InMaintenanceWindow(start.Add(-time.Minute)); active {
+		t.Fatal("expected no maintenance before the window starts")
+	}
+	if active, id := l.InMaintenanceWindow(start.Add(30 * time.Minute)); !active || id != eventID {
+		t.Fatalf("InMaintenanceWindow = %v, %q; want true, %q", active, id, eventID)
+	}
+	if active, _ := l.InMaintenanceWindow(start.Add(time.Hour)); active {
+		t.Fatal("expected no maintenance once the window has ended")
+	}
+}
+
+func TestGuardOperationRejectsDuringMaintenance(t *testing.T) {
+	l := &MonitoringMaintenanceLedger{}
+	start := time.Now()
+	l.ScheduleMaintenance("upgrade storage", start, start.Add(time.Hour))
+
+	deferred, err := l.GuardOperation("compact", func() error { return nil }, start.Add(time.Minute))
+	if deferred || err == nil {
+		t.Fatalf("GuardOperation = %v, %v; want the operation rejected", deferred, err)
+	}
+}
+
+func TestCompleteMaintenanceReplaysDeferredOperations(t *testing.T) {
+	l := &MonitoringMaintenanceLedger{DeferDuringMaintenance: true}
+	start := time.Now()
+	eventID, _ := l.ScheduleMaintenance("upgrade storage", start, start.Add(time.Hour))
+
+	var ran []string
+	for _, name := range []string{"compact", "reindex"} {
+		name := name
+		deferred, err := l.GuardOperation(name, func() error {
+			ran = append(ran, name)
+			if name == "reindex" {
+				return errors.New("index locked")
+			}
+			return nil
+		}, start.Add(time.Minute))
+		if !deferred || err != nil {
+			t.Fatalf("GuardOperation(%s) = %v, %v; want it deferred", name, deferred, err)
+		}
+	}
+
+	entry, err := l.CompleteMaintenance(eventID, start.Add(2*time.Hour))
+	if err == nil || !strings.Contains(err.Error(), "reindex") {
+		t.Fatalf("CompleteMaintenance error = %v, want the failed reindex reported", err)
+	}
+	if len(ran) != 2 || ran[0] != "compact" || ran[1] != "reindex" {
+		t.Fatalf("replayed %v, want [compact reindex] in order", ran)
+	}
+	if entry == nil || !strings.Contains(entry.Description, eventID) || len(l.MaintenanceLogs) != 1 {
+		t.Fatalf("log entry = %+v, want the completion logged", entry)
+	}
+	if len(l.DeferredOperations) != 0 {
+		t.Fatalf("%d deferred operations left, want the queue drained", len(l.DeferredOperations))
+	}
+	if _, err := l.CompleteMaintenance(eventID, start.Add(3*time.Hour)); err == nil {
+		t.Fatal("expected completing the same maintenance twice to fail")
+	}
+}
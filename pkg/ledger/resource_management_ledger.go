@@ -235,3 +235,122 @@ func (l *ResourceManagementLedger) GetResourcePoolingPolicy() (string, error) {
     }
     return l.ResourcePoolingPolicy.Policy, nil
 }
+
+// PoolPolicyReserveHeadroom keeps poolHeadroom of every pooled resource
+// unallocated so bursts can still be served.
+const PoolPolicyReserveHeadroom = "reserve_headroom"
+
+// poolHeadroom is the fraction of capacity held back under PoolPolicyReserveHeadroom.
+const poolHeadroom = 0.1
+
+// SetPoolCapacity sets the total resources available to the pool. It fails if
+// the new capacity is below what is already allocated.
+func (l *ResourceManagementLedger) SetPoolCapacity(capacity ResourceRequirements) error {
+    l.Lock()
+    defer l.Unlock()
+
+    a := l.ResourcePool.Allocated
+    if a.CPU > capacity.CPU || a.Memory > capacity.Memory || a.Storage > capacity.Storage || a.NetworkBandwidth > capacity.NetworkBandwidth {
+        return fmt.Errorf("pool capacity cannot be set below current allocations")
+    }
+    l.ResourcePool.Capacity = capacity
+    l.ResourcePool.LastUpdated = time.Now()
+    return nil
+}
+
+// AllocateFromPool reserves req from the resource pool. Requests that would
+// exceed the pool's available capacity, as limited by the pooling policy,
+// are rejected.
+func (l *ResourceManagementLedger) AllocateFromPool(req ResourceRequirements) (allocationID string, err error) {
+    l.Lock()
+    defer l.Unlock()
+
+    if !l.ResourcePool.Enabled {
+        return "", fmt.Errorf("resource pooling is disabled")
+    }
+    if req.CPU < 0 || req.Memory < 0 || req.Storage < 0 || req.NetworkBandwidth < 0 {
+        return "", fmt.Errorf("resource requirements cannot be negative")
+    }
+
+    limit := l.poolLimit()
+    a := l.ResourcePool.Allocated
+    if a.CPU+req.CPU > limit.CPU || a.Memory+req.Memory > limit.Memory ||
+        a.Storage+req.Storage > limit.Storage || a.NetworkBandwidth+req.NetworkBandwidth > limit.NetworkBandwidth {
+        return "", fmt.Errorf("insufficient pooled resources for request %+v", req)
+    }
+
+    allocationID = generateUniqueID()
+    if l.ResourcePool.Allocations == nil {
+        l.ResourcePool.Allocations = make(map[string]ResourceRequirements)
+    }
+    l.ResourcePool.Allocations[allocationID] = req
+    l.ResourcePool.Allocated = ResourceRequirements{
+        CPU:              a.CPU + req.CPU,
+        Memory:           a.Memory + req.Memory,
+        Storage:          a.Storage + req.Storage,
+        NetworkBandwidth: a.NetworkBandwidth + req.NetworkBandwidth,
+    }
+    l.ResourcePool.LastUpdated = time.Now()
+    fmt.Printf("Pool allocation %s reserved %+v.\n", allocationID, req)
+    return allocationID, nil
+}
+
+// ReleaseToPool returns an allocation's resources to the pool.
+func (l *ResourceManagementLedger) ReleaseToPool(allocationID string) error {
+    l.Lock()
+    defer l.Unlock()
+
+    req, exists := l.ResourcePool.Allocations[allocationID]
+    if !exists {
+        return fmt.Errorf("pool allocation %s not found", allocationID)
+    }
+    delete(l.ResourcePool.Allocations, allocationID)
+    a := l.ResourcePool.Allocated
+    l.ResourcePool.Allocated = ResourceRequirements{
+        CPU:              a.CPU - req.CPU,
+        Memory:           a.Memory - req.Memory,
+        Storage:          a.Storage - req.Storage,
+        NetworkBandwidth: a.NetworkBandwidth - req.NetworkBandwidth,
+    }
+    l.ResourcePool.LastUpdated = time.Now()
+    fmt.Printf("Pool allocation %s released.\n", allocationID)
+    return nil
+}
+
+// PoolUtilization returns the mean fraction of pool capacity allocated across
+// all resource types the pool provides, from 0 to 1.
+func (l *ResourceManagementLedger) PoolUtilization() float64 {
+    l.Lock()
+    defer l.Unlock()
+
+    c, a := l.ResourcePool.Capacity, l.ResourcePool.Allocated
+    pairs := [][2]int{{a.CPU, c.CPU}, {a.Memory, c.Memory}, {a.Storage, c.Storage}, {a.NetworkBandwidth, c.NetworkBandwidth}}
+
+    total, count := 0.0, 0
+    for _, p := range pairs {
+        if p[1] > 0 {
+            total += float64(p[0]) / float64(p[1])
+            count++
+        }
+    }
+    if count == 0 {
+        return 0
+    }
+    return total / float64(count)
+}
+
+// poolLimit returns the resources the pooling policy allows to be allocated.
+// The caller must hold the lock.
+func (l *ResourceManagementLedger) poolLimit() ResourceRequirements {
+    c := l.ResourcePool.Capacity
+    if l.ResourcePoolingPolicy.Policy != PoolPolicyReserveHeadroom {
+        return c
+    }
+    usable := func(v int) int { return int(float64(v) * (1 - poolHeadroom)) }
+    return ResourceRequirements{
+        CPU:              usable(c.CPU),
+        Memory:           usable(c.Memory),
+        Storage:          usable(c.Storage),
+        NetworkBandwidth: usable(c.NetworkBandwidth),
+    }
+}
@@ -0,0 +1,62 @@
+package ledger
+
+import "testing"
+
+func newPoolTestLedger(policy string) *ResourceManagementLedger {
+	l := &ResourceManagementLedger{
+		ResourcePool:          ResourcePool{Enabled: true},
+		ResourcePoolingPolicy: ResourcePoolingPolicy{Policy: policy},
+	}
+	l.SetPoolCapacity(ResourceRequirements{CPU: 10, Memory: 100})
+	return l
+}
+
+func TestAllocateFromPoolAndRelease(t *testing.T) {
+	l := newPoolTestLedger("")
+
+	id, err := l.AllocateFromPool(ResourceRequirements{CPU: 5, Memory: 50})
+	if err != nil {
+		t.Fatalf("AllocateFromPool: %v", err)
+	}
+	if got := l.PoolUtilization(); got != 0.5 {
+		t.Fatalf("PoolUtilization = %v, want 0.5", got)
+	}
+
+	if err := l.ReleaseToPool(id); err != nil {
+		t.Fatalf("ReleaseToPool: %v", err)
+	}
+	if got := l.PoolUtilization(); got != 0 {
+		t.Fatalf("PoolUtilization after release = %v, want 0", got)
+	}
+	if err := l.ReleaseToPool(id); err == nil {
+		t.Fatal("expected releasing the same allocation twice to fail")
+	}
+	if _, err := l.AllocateFromPool(ResourceRequirements{CPU: 10, Memory: 100}); err != nil {
+		t.Fatalf("AllocateFromPool after release: %v", err)
+	}
+}
+
+func TestAllocateFromPoolRejectsOverAllocation(t *testing.T) {
+	l := newPoolTestLedger("")
+	if _, err := l.AllocateFromPool(ResourceRequirements{CPU: 8}); err != nil {
+		t.Fatalf("AllocateFromPool: %v", err)
+	}
+
+	if _, err := l.AllocateFromPool(ResourceRequirements{CPU: 3}); err == nil {
+		t.Fatal("expected an allocation beyond pool capacity to be rejected")
+	}
+	if got := l.ResourcePool.Allocated.CPU; got != 8 {
+		t.Fatalf("Allocated.CPU = %d, want 8 after the rejected request", got)
+	}
+}
+
+func TestAllocateFromPoolHonorsHeadroomPolicy(t *testing.T) {
+	l := newPoolTestLedger(PoolPolicyReserveHeadroom)
+
+	if _, err := l.AllocateFromPool(ResourceRequirements{CPU: 10}); err == nil {
+		t.Fatal("expected the reserved headroom to be unavailable")
+	}
+	if _, err := l.AllocateFromPool(ResourceRequirements{CPU: 9}); err != nil {
+		t.Fatalf("AllocateFromPool within headroom limit: %v", err)
+	}
+}
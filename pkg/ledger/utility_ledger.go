@@ -5,6 +5,9 @@ import (
     "encoding/hex"
     "errors"
     "fmt"
+    "math"
+    "sort"
+    "strings"
     "time"
 )
 
@@ -55,7 +58,8 @@ func (l *UtilityLedger) RecordTaskCreation(creator string, details map[string]in
 
 
 
-// RecordTaskAllocation assigns a task to a specific entity in the ledger.
+// RecordTaskAllocation assigns a task to a specific entity in the ledger, moving it to the
+// assigned state so that it can later be completed.
 func (l *UtilityLedger) RecordTaskAllocation(taskID, assignee string) error {
     l.Lock()
     defer l.Unlock()
@@ -65,15 +69,20 @@ func (l *UtilityLedger) RecordTaskAllocation(taskID, assignee string) error {
         return errors.New("task not found")
     }
 
+    comment := fmt.Sprintf("Task allocated to %s.", assignee)
+    if err := l.transitionTask(&task, TaskStatusAssigned, comment, time.Now()); err != nil {
+        return err
+    }
+
     task.Assignee = assignee
-    task.Status = "allocated"
     l.Tasks[taskID] = task
 
     return nil
 }
 
 
-// RecordTaskCompletion records the completion of a task in the ledger.
+// RecordTaskCompletion records the completion of a task in the ledger. The task must be in a
+// state that may move to completed, exactly as for CompleteTask.
 func (l *UtilityLedger) RecordTaskCompletion(taskID string, subBlockID string, blockID string) error {
     l.Lock()
     defer l.Unlock()
@@ -83,9 +92,17 @@ func (l *UtilityLedger) RecordTaskCompletion(taskID string, subBlockID string, b
         return errors.New("task not found")
     }
 
+    if task.Status == TaskStatusCreated {
+        return fmt.Errorf("task %s cannot be completed before it is assigned", taskID)
+    }
+
     now := time.Now()
+    comment := fmt.Sprintf("Task completed in sub-block %s of block %s.", subBlockID, blockID)
+    if err := l.transitionTask(&task, TaskStatusCompleted, comment, now); err != nil {
+        return err
+    }
+
     task.CompletedAt = &now
-    task.Status = "completed"
     task.SubBlockID = subBlockID
     task.BlockID = blockID
     l.Tasks[taskID] = task
@@ -217,31 +234,25 @@ func (l *UtilityLedger) RecordOrchestrationCompletion(dataID string, details map
 
 
 
-// RecordTaskFailure records the failure of a task in the ledger.
+// RecordTaskFailure records the failure of a task in the ledger. The task must be in a
+// state that may move to failed, exactly as for FailTask.
 func (l *UtilityLedger) RecordTaskFailure(taskID string, executorNode string, errorMessage string) error {
     l.Lock()
     defer l.Unlock()
 
-    if l.Tasks == nil {
-        l.Tasks = make(map[string]TaskRecord)
-    }
-
-    // Check if task already exists in the ledger, if not create a new one
-    taskRecord, exists := l.Tasks[taskID]
+    task, exists := l.Tasks[taskID]
     if !exists {
-        taskRecord = TaskRecord{
-            ID:           taskID,
-            ExecutorNode: executorNode,
-            Status:       "Failed",
-        }
+        return errors.New("task not found")
     }
 
-    // Update task failure details
-    taskRecord.ErrorMessage = errorMessage
-    taskRecord.Status = "Failed"
+    comment := fmt.Sprintf("Task failed on %s: %s", executorNode, errorMessage)
+    if err := l.transitionTask(&task, TaskStatusFailed, comment, time.Now()); err != nil {
+        return err
+    }
 
-    // Update ledger
-    l.Tasks[taskID] = taskRecord
+    task.ExecutorNode = executorNode
+    task.ErrorMessage = errorMessage
+    l.Tasks[taskID] = task
 
     // Simulate logging to persistent storage (e.g., database, file, etc.)
     fmt.Printf("Task %s failed by %s with error: %s\n", taskID, executorNode, errorMessage)
@@ -249,3 +260,318 @@ func (l *UtilityLedger) RecordTaskFailure(taskID string, executorNode string, er
     return nil
 }
 
+
+// Task lifecycle states enforced by CreateTask, AssignTask, RecordTaskAllocation, CompleteTask,
+// RecordTaskCompletion, FailTask and RecordTaskFailure.
+const (
+	TaskStatusCreated   = "created"
+	TaskStatusAssigned  = "assigned"
+	TaskStatusCompleted = "completed"
+	TaskStatusFailed    = "failed"
+)
+
+// validTaskTransitions lists the states a task may move to from each state.
+var validTaskTransitions = map[string][]string{
+	TaskStatusCreated:  {TaskStatusAssigned, TaskStatusFailed},
+	TaskStatusAssigned: {TaskStatusCompleted, TaskStatusFailed},
+}
+
+// transitionTask moves a task to the next status if the transition is allowed and records it in the task history.
+func (l *UtilityLedger) transitionTask(task *TaskRecord, next, comment string, now time.Time) error {
+	allowed := false
+	for _, status := range validTaskTransitions[task.Status] {
+		if status == next {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("invalid task transition for %s: %s -> %s", task.ID, task.Status, next)
+	}
+
+	task.Status = next
+
+	if l.UtilityLedgerState.TaskHistory == nil {
+		l.UtilityLedgerState.TaskHistory = make(map[string]TaskHistory)
+	}
+	history := l.UtilityLedgerState.TaskHistory[task.ID]
+	history.TaskID = task.ID
+	history.Updates = append(history.Updates, TaskEvent{
+		Timestamp: now,
+		Status:    next,
+		Comment:   comment,
+	})
+	l.UtilityLedgerState.TaskHistory[task.ID] = history
+
+	return nil
+}
+
+// CreateTask creates a new task in the created state.
+func (l *UtilityLedger) CreateTask(creator string, details map[string]interface{}) (*TaskRecord, error) {
+	if creator == "" {
+		return nil, errors.New("creator must be provided")
+	}
+
+	id, err := l.RecordTaskCreation(creator, details)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	task := l.Tasks[id]
+	return &task, nil
+}
+
+// AssignTask assigns a created task to an assignee and executor node.
+func (l *UtilityLedger) AssignTask(taskID, assignee, executorNode string) error {
+	if assignee == "" || executorNode == "" {
+		return errors.New("assignee and executor node must be provided")
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	task, exists := l.Tasks[taskID]
+	if !exists {
+		return errors.New("task not found")
+	}
+
+	comment := fmt.Sprintf("Task assigned to %s on node %s.", assignee, executorNode)
+	if err := l.transitionTask(&task, TaskStatusAssigned, comment, time.Now()); err != nil {
+		return err
+	}
+
+	task.Assignee = assignee
+	task.ExecutorNode = executorNode
+	l.Tasks[taskID] = task
+
+	return nil
+}
+
+// CompleteTask marks an assigned task as completed.
+func (l *UtilityLedger) CompleteTask(taskID string, now time.Time) error {
+	l.Lock()
+	defer l.Unlock()
+
+	task, exists := l.Tasks[taskID]
+	if !exists {
+		return errors.New("task not found")
+	}
+
+	if task.Status == TaskStatusCreated {
+		return fmt.Errorf("task %s cannot be completed before it is assigned", taskID)
+	}
+
+	if err := l.transitionTask(&task, TaskStatusCompleted, "Task completed successfully.", now); err != nil {
+		return err
+	}
+
+	completedAt := now
+	task.CompletedAt = &completedAt
+	l.Tasks[taskID] = task
+
+	return nil
+}
+
+// FailTask marks a created or assigned task as failed with the given error message.
+func (l *UtilityLedger) FailTask(taskID, errMsg string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	task, exists := l.Tasks[taskID]
+	if !exists {
+		return errors.New("task not found")
+	}
+
+	if err := l.transitionTask(&task, TaskStatusFailed, errMsg, time.Now()); err != nil {
+		return err
+	}
+
+	task.ErrorMessage = errMsg
+	l.Tasks[taskID] = task
+
+	return nil
+}
+
+// SubmitOrchestration records a new orchestration request and returns it.
+func (l *UtilityLedger) SubmitOrchestration(requester string, resources map[string]interface{}) (*OrchestrationRequest, error) {
+	if requester == "" {
+		return nil, errors.New("requester must be provided")
+	}
+	if len(resources) == 0 {
+		return nil, errors.New("at least one resource must be requested")
+	}
+	for resourceType, amount := range resources {
+		if _, ok := orchestrationAmount(amount); !ok {
+			return nil, fmt.Errorf("invalid amount for resource %s: %v", resourceType, amount)
+		}
+	}
+
+	id, err := l.RecordOrchestrationRequest(requester, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	request := l.OrchestrationRequests[id]
+	return &request, nil
+}
+
+// MatchResources checks an orchestration request against available capacity, matching each
+// requested resource type against the total capacity of that type across all contexts. A
+// matched request reserves what it needs by decrementing the capacity in available, so later
+// matches against the same slice only see what is left. Requests that cannot be satisfied are
+// queued until capacity becomes available.
+func (l *UtilityLedger) MatchResources(requestID string, available []ContextResources) (bool, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	request, exists := l.OrchestrationRequests[requestID]
+	if !exists {
+		return false, fmt.Errorf("orchestration request %s not found", requestID)
+	}
+	if request.Status == "fulfilled" || request.Status == "matched" {
+		return false, fmt.Errorf("orchestration request %s is already %s", requestID, request.Status)
+	}
+
+	// Whole units needed and available for each requested resource type
+	needed := make(map[string]int)
+	for resourceType, value := range request.Resources {
+		amount, _ := orchestrationAmount(value)
+		if amount > 0 {
+			needed[resourceType] = int(math.Ceil(amount))
+		}
+	}
+	capacity := make(map[string]int)
+	for _, resources := range available {
+		capacity[resources.ResourceType] += resources.Amount
+	}
+
+	resourceTypes := make([]string, 0, len(needed))
+	for resourceType := range needed {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	var short []string
+	for _, resourceType := range resourceTypes {
+		if capacity[resourceType] < needed[resourceType] {
+			short = append(short, fmt.Sprintf("%s (need %d, have %d)", resourceType, needed[resourceType], capacity[resourceType]))
+		}
+	}
+
+	if len(short) > 0 {
+		if request.Status != "queued" {
+			l.OrchestrationQueue = append(l.OrchestrationQueue, requestID)
+		}
+		request.Status = "queued"
+		l.OrchestrationRequests[requestID] = request
+		l.recordOrchestrationHistory(requestID, "queue", "Insufficient capacity for "+strings.Join(short, ", "))
+		return false, nil
+	}
+
+	// Reserve the request's resources, drawing on contexts in the order given
+	now := time.Now()
+	var reserved []string
+	for _, resourceType := range resourceTypes {
+		remaining := needed[resourceType]
+		for i := range available {
+			if remaining == 0 {
+				break
+			}
+			if available[i].ResourceType != resourceType || available[i].Amount == 0 {
+				continue
+			}
+			take := available[i].Amount
+			if take > remaining {
+				take = remaining
+			}
+			available[i].Amount -= take
+			available[i].ReservedAt = now
+			remaining -= take
+			reserved = append(reserved, fmt.Sprintf("%d %s from context %s", take, resourceType, available[i].ContextID))
+		}
+	}
+
+	request.Status = "matched"
+	l.dequeueOrchestration(requestID)
+	l.OrchestrationRequests[requestID] = request
+	l.recordOrchestrationHistory(requestID, "match", "Reserved "+strings.Join(reserved, ", "))
+
+	return true, nil
+}
+
+// FulfillOrchestration marks a matched orchestration request as fulfilled.
+func (l *UtilityLedger) FulfillOrchestration(requestID string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	request, exists := l.OrchestrationRequests[requestID]
+	if !exists {
+		return fmt.Errorf("orchestration request %s not found", requestID)
+	}
+	if request.Status != "matched" {
+		return fmt.Errorf("orchestration request %s cannot be fulfilled in status %s", requestID, request.Status)
+	}
+
+	request.Status = "fulfilled"
+	l.OrchestrationRequests[requestID] = request
+	l.dequeueOrchestration(requestID)
+
+	l.recordOrchestrationHistory(requestID, "fulfill", fmt.Sprintf("Orchestration request fulfilled for %s", request.Requester))
+
+	return nil
+}
+
+// QueuedOrchestrations returns the IDs of orchestration requests waiting for capacity, oldest first.
+func (l *UtilityLedger) QueuedOrchestrations() []string {
+	l.Lock()
+	defer l.Unlock()
+
+	queued := make([]string, len(l.OrchestrationQueue))
+	copy(queued, l.OrchestrationQueue)
+	return queued
+}
+
+// dequeueOrchestration removes a request from the orchestration queue. The caller must hold the lock.
+func (l *UtilityLedger) dequeueOrchestration(requestID string) {
+	for i, id := range l.OrchestrationQueue {
+		if id == requestID {
+			l.OrchestrationQueue = append(l.OrchestrationQueue[:i], l.OrchestrationQueue[i+1:]...)
+			return
+		}
+	}
+}
+
+// recordOrchestrationHistory appends an orchestration action to the history. The caller must hold the lock.
+func (l *UtilityLedger) recordOrchestrationHistory(requestID, action, details string) {
+	if l.UtilityLedgerState.OrchestrationHistory == nil {
+		l.UtilityLedgerState.OrchestrationHistory = make(map[string]OrchestrationRecord)
+	}
+	l.UtilityLedgerState.OrchestrationHistory[requestID+"-"+action] = OrchestrationRecord{
+		RecordID:  requestID,
+		Action:    action,
+		Timestamp: time.Now(),
+		Details:   details,
+	}
+}
+
+// orchestrationAmount converts a requested resource amount to a float64.
+func orchestrationAmount(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), v >= 0
+	case int64:
+		return float64(v), v >= 0
+	case float64:
+		return v, v >= 0
+	case float32:
+		return float64(v), v >= 0
+	default:
+		return 0, false
+	}
+}
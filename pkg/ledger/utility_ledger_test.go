@@ -0,0 +1,146 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchResourcesReservesFulfillableRequest(t *testing.T) {
+	l := &UtilityLedger{}
+	request, err := l.SubmitOrchestration("alice", map[string]interface{}{"cpu": 4, "memory": 8.0})
+	if err != nil {
+		t.Fatalf("SubmitOrchestration: %v", err)
+	}
+	available := []ContextResources{
+		{ContextID: "ctx-1", ResourceType: "cpu", Amount: 3},
+		{ContextID: "ctx-2", ResourceType: "cpu", Amount: 2},
+		{ContextID: "ctx-1", ResourceType: "memory", Amount: 16},
+	}
+
+	matched, err := l.MatchResources(request.ID, available)
+	if err != nil || !matched {
+		t.Fatalf("MatchResources = %v, %v; want true, nil", matched, err)
+	}
+	if available[0].Amount != 0 || available[1].Amount != 1 || available[2].Amount != 8 {
+		t.Fatalf("capacity left = %d, %d, %d; want 0, 1, 8", available[0].Amount, available[1].Amount, available[2].Amount)
+	}
+	if err := l.FulfillOrchestration(request.ID); err != nil {
+		t.Fatalf("FulfillOrchestration: %v", err)
+	}
+}
+
+func TestMatchResourcesQueuesOverCapacityRequest(t *testing.T) {
+	l := &UtilityLedger{}
+	first, _ := l.SubmitOrchestration("alice", map[string]interface{}{"cpu": 4})
+	second, _ := l.SubmitOrchestration("bob", map[string]interface{}{"cpu": 2})
+	available := []ContextResources{{ContextID: "ctx-1", ResourceType: "cpu", Amount: 5}}
+
+	if matched, err := l.MatchResources(first.ID, available); err != nil || !matched {
+		t.Fatalf("first MatchResources = %v, %v; want true, nil", matched, err)
+	}
+
+	// Only one CPU is left once the first request has reserved its share.
+	matched, err := l.MatchResources(second.ID, available)
+	if err != nil {
+		t.Fatalf("MatchResources: %v", err)
+	}
+	if matched {
+		t.Fatal("expected the over-capacity request not to match")
+	}
+	if queued := l.QueuedOrchestrations(); len(queued) != 1 || queued[0] != second.ID {
+		t.Fatalf("queue = %v, want [%s]", queued, second.ID)
+	}
+	if err := l.FulfillOrchestration(second.ID); err == nil {
+		t.Fatal("expected a queued request not to be fulfillable")
+	}
+}
+
+func TestMatchResourcesMatchesEachTypeSeparately(t *testing.T) {
+	l := &UtilityLedger{}
+	request, _ := l.SubmitOrchestration("alice", map[string]interface{}{"cpu": 1, "gpu": 1})
+	available := []ContextResources{{ContextID: "ctx-1", ResourceType: "cpu", Amount: 10}}
+
+	if matched, _ := l.MatchResources(request.ID, available); matched {
+		t.Fatal("expected a request for a resource type with no capacity not to match")
+	}
+	if available[0].Amount != 10 {
+		t.Fatalf("cpu capacity = %d, want 10: nothing is reserved for an unmatched request", available[0].Amount)
+	}
+}
+
+func TestTaskLifecycleValidTransitions(t *testing.T) {
+	l := &UtilityLedger{}
+	task, err := l.CreateTask("alice", map[string]interface{}{"job": "index"})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := l.AssignTask(task.ID, "bob", "node-1"); err != nil {
+		t.Fatalf("AssignTask: %v", err)
+	}
+	if err := l.CompleteTask(task.ID, time.Now()); err != nil {
+		t.Fatalf("CompleteTask: %v", err)
+	}
+	if got := l.Tasks[task.ID]; got.Status != TaskStatusCompleted || got.CompletedAt == nil {
+		t.Fatalf("task = %+v, want completed with CompletedAt set", got)
+	}
+}
+
+func TestTaskLifecycleRejectsIllegalTransitions(t *testing.T) {
+	l := &UtilityLedger{}
+	task, _ := l.CreateTask("alice", nil)
+
+	if err := l.CompleteTask(task.ID, time.Now()); err == nil {
+		t.Fatal("expected completing an unassigned task to be rejected")
+	}
+	if err := l.RecordTaskCompletion(task.ID, "sub-1", "block-1"); err == nil {
+		t.Fatal("expected RecordTaskCompletion to reject an unassigned task too")
+	}
+
+	if err := l.FailTask(task.ID, "executor crashed"); err != nil {
+		t.Fatalf("FailTask: %v", err)
+	}
+	if err := l.AssignTask(task.ID, "bob", "node-1"); err == nil {
+		t.Fatal("expected a failed task not to be reassignable")
+	}
+}
+
+func TestRecordTaskCompletionFollowsAllocation(t *testing.T) {
+	l := &UtilityLedger{}
+	task, _ := l.CreateTask("alice", nil)
+
+	if err := l.RecordTaskAllocation(task.ID, "validator-1"); err != nil {
+		t.Fatalf("RecordTaskAllocation: %v", err)
+	}
+	if err := l.RecordTaskCompletion(task.ID, "sub-1", "block-1"); err != nil {
+		t.Fatalf("RecordTaskCompletion: %v", err)
+	}
+	if err := l.RecordTaskCompletion(task.ID, "sub-2", "block-2"); err == nil {
+		t.Fatal("expected a completed task not to be completed again")
+	}
+}
+
+func TestRecordTaskFailureFollowsLifecycle(t *testing.T) {
+	l := &UtilityLedger{}
+	task, _ := l.CreateTask("alice", nil)
+
+	if err := l.RecordTaskFailure("missing", "node-1", "boom"); err == nil {
+		t.Fatal("expected failing an unknown task to be rejected")
+	}
+	if _, exists := l.Tasks["missing"]; exists {
+		t.Fatal("expected no task to be created for an unknown ID")
+	}
+
+	if err := l.RecordTaskFailure(task.ID, "node-1", "boom"); err != nil {
+		t.Fatalf("RecordTaskFailure: %v", err)
+	}
+	if got := l.Tasks[task.ID]; got.Status != TaskStatusFailed || got.ErrorMessage != "boom" {
+		t.Fatalf("task = %+v, want status %q with the error recorded", got, TaskStatusFailed)
+	}
+
+	done, _ := l.CreateTask("alice", nil)
+	l.RecordTaskAllocation(done.ID, "validator-1")
+	l.RecordTaskCompletion(done.ID, "sub-1", "block-1")
+	if err := l.RecordTaskFailure(done.ID, "node-1", "late error"); err == nil {
+		t.Fatal("expected a completed task not to be marked failed")
+	}
+}
@@ -22,8 +22,12 @@ type FullPrunedNode struct {
 	mutex             sync.Mutex                     // Mutex for thread-safe operations
 	SyncInterval      time.Duration                  // Interval for syncing with other full nodes
 	SNVM              *common.VirtualMachine // Virtual Machine for executing smart contracts
+	KeepRecentBlocks  int                            // Number of recent blocks retained when pruning
 }
 
+// defaultKeepRecentBlocks is used when KeepRecentBlocks is not set.
+const defaultKeepRecentBlocks = 1000
+
 // NewFullPrunedNode initializes a new full pruned node in the blockchain network.
 func NewFullPrunedNode(nodeID string, prunedBlockchain *ledger.PrunedBlockchain, consensusEngine *common.SynnergyConsensus, encryptionService *common.Encryption, networkManager *network.NetworkManager, syncInterval time.Duration) *FullPrunedNode {
 	return &FullPrunedNode{
@@ -148,12 +152,16 @@ func (fn *FullPrunedNode) PruneOldBlocks() error {
 	fn.mutex.Lock()
 	defer fn.mutex.Unlock()
 
-	// Custom logic to prune old blocks based on certain conditions (e.g., block age, disk space usage).
-	prunedCount, err := fn.PrunedBlockchain.Prune()
-	if err != nil {
+	keepRecent := fn.KeepRecentBlocks
+	if keepRecent <= 0 {
+		keepRecent = defaultKeepRecentBlocks
+	}
+
+	prunedBefore := fn.PrunedBlockchain.PrunedBlockHeight
+	if err := fn.PrunedBlockchain.Prune(keepRecent); err != nil {
 		return fmt.Errorf("error during pruning: %v", err)
 	}
 
-	fmt.Printf("%d blocks pruned from the blockchain.\n", prunedCount)
+	fmt.Printf("%d blocks pruned from the blockchain.\n", fn.PrunedBlockchain.PrunedBlockHeight-prunedBefore)
 	return nil
 }
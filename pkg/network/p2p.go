@@ -180,6 +180,12 @@ func (p2p *P2PNetwork) listenForMessages(peerAddress string) {
 			continue
 		}
 
+		// Drop messages from quarantined peers
+		if err := p2p.authorizeCommunication(peerAddress); err != nil {
+			fmt.Printf("Dropped message from %s: %v\n", peerAddress, err)
+			continue
+		}
+
 		// Construct the P2PMessage
 		message := P2PMessage{
 			Sender:  peerAddress,
@@ -193,8 +199,27 @@ func (p2p *P2PNetwork) listenForMessages(peerAddress string) {
 }
 
 
+// authorizeCommunication rejects communication between this node and peer when either
+// has been isolated in the ledger.
+func (p2p *P2PNetwork) authorizeCommunication(peer string) error {
+	if p2p.LedgerInstance == nil {
+		return nil
+	}
+	for _, entity := range []string{p2p.Address, peer} {
+		if err := p2p.LedgerInstance.AdvancedSecurityLedger.AuthorizeEntityOperation(entity, "communication"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SendMessage sends an encrypted message to a specific peer
 func (p2p *P2PNetwork) SendMessage(recipient, content string) error {
+	// Refuse to talk to or for a quarantined entity
+	if err := p2p.authorizeCommunication(recipient); err != nil {
+		return err
+	}
+
 	// Check if the recipient peer is connected
 	peer, exists := p2p.Peers[recipient]
 	if !exists {
@@ -19,11 +19,18 @@ func NewMigrationManager(ledgerInstance *ledger.Ledger) *MigrationManager {
     }
 }
 
-// MigrateContract initiates the migration of a smart contract to a new version.
+// MigrateContract initiates the migration of a smart contract to a new version. The
+// migration is identified by the old contract ID for compliance, and is refused while
+// compliance validation has it blocked.
 func (mm *MigrationManager) MigrateContract(oldContractID, newCode, owner string, newParameters map[string]interface{}) (*MigratedContract, error) {
     mm.mutex.Lock()
     defer mm.mutex.Unlock()
 
+    // Refuse migrations that failed compliance validation
+    if err := mm.LedgerInstance.ComplianceLedger.CheckMigrationAllowed(oldContractID); err != nil {
+        return nil, err
+    }
+
     // Retrieve the old contract
     oldContract, err := mm.LedgerInstance.RetrieveContract(oldContractID)
     if err != nil {